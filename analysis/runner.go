@@ -6,8 +6,10 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/sandialabs/bibcheck/lookup"
+	"github.com/sandialabs/bibcheck/match"
 )
 
 const DefaultWorkers = 4
@@ -33,10 +35,27 @@ const (
 type Summary struct {
 	Mismatch bool
 	Comment  string
+	// Match is the deterministic comparison of the entry against the best
+	// matched record. Run fills it in when Summarize leaves it nil.
+	Match *match.Result
+}
+
+// MatchSummary summarizes a lookup result with the deterministic field
+// comparison alone, for use when no LLM summarizer is configured.
+func MatchSummary(result *lookup.Result) Summary {
+	m := result.Match()
+	if m == nil {
+		return Summary{}
+	}
+	return Summary{
+		Mismatch: m.Verdict != match.VerdictMatch,
+		Comment:  m.Explain(),
+		Match:    m,
+	}
 }
 
 type Entry struct {
-	ID int
+	ID string
 
 	ExtractionStatus Status
 	Text             string
@@ -62,31 +81,44 @@ type Snapshot struct {
 	Done      bool
 }
 
+// Config describes an analysis run. Each stage function receives a context
+// that is canceled when the run's context is, or when the entry's
+// EntryTimeout elapses.
 type Config struct {
-	EntryIDs  []int
+	EntryIDs  []string
 	Workers   int
-	Extract   func(int) (string, error)
-	Lookup    func(string) (*lookup.Result, error)
-	Summarize func(*lookup.Result) (Summary, error)
+	Extract   func(context.Context, string) (string, error)
+	Lookup    func(context.Context, string) (*lookup.Result, error)
+	Summarize func(context.Context, *lookup.Result) (Summary, error)
 	Progress  func(Snapshot)
+	// EntryTimeout bounds the time spent on one entry, from the start of its
+	// extraction to the end of its summary. Zero means no limit.
+	EntryTimeout time.Duration
 }
 
 type job struct {
-	index int
-	stage Stage
-	entry Entry
+	index    int
+	stage    Stage
+	entry    Entry
+	deadline time.Time
 }
 
 type table struct {
 	mu        sync.Mutex
 	cond      *sync.Cond
 	entries   []Entry
+	deadlines []time.Time
+	timeout   time.Duration
 	completed int
 	stopped   bool
 }
 
-func newTable(ids []int) *table {
-	t := &table{entries: make([]Entry, len(ids))}
+func newTable(ids []string, timeout time.Duration) *table {
+	t := &table{
+		entries:   make([]Entry, len(ids)),
+		deadlines: make([]time.Time, len(ids)),
+		timeout:   timeout,
+	}
 	t.cond = sync.NewCond(&t.mu)
 	for i, id := range ids {
 		t.entries[i] = Entry{
@@ -117,6 +149,9 @@ func (t *table) claim(ctx context.Context) (job, bool) {
 			case e.ExtractionStatus == StatusPending:
 				e.ExtractionStatus = StatusActive
 				stage = StageExtraction
+				if t.timeout > 0 {
+					t.deadlines[i] = time.Now().Add(t.timeout)
+				}
 			case e.ExtractionStatus == StatusCompleted && e.LookupStatus == StatusPending:
 				e.LookupStatus = StatusActive
 				stage = StageLookup
@@ -126,7 +161,7 @@ func (t *table) claim(ctx context.Context) (job, bool) {
 			default:
 				continue
 			}
-			return job{index: i, stage: stage, entry: *e}, true
+			return job{index: i, stage: stage, entry: *e, deadline: t.deadlines[i]}, true
 		}
 		t.cond.Wait()
 	}
@@ -192,6 +227,22 @@ func (t *table) snapshot() Snapshot {
 	}
 }
 
+// stageContext derives the context for one stage of j from the run context.
+func stageContext(ctx context.Context, j job) (context.Context, context.CancelFunc) {
+	if j.deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, j.deadline)
+}
+
+func summarize(ctx context.Context, cfg Config, result *lookup.Result) (Summary, error) {
+	summary, err := cfg.Summarize(ctx, result)
+	if err == nil && summary.Match == nil && result != nil {
+		summary.Match = result.Match()
+	}
+	return summary, err
+}
+
 func Run(ctx context.Context, cfg Config) (Snapshot, error) {
 	if len(cfg.EntryIDs) == 0 {
 		return Snapshot{}, fmt.Errorf("no bibliography entries")
@@ -207,7 +258,7 @@ func Run(ctx context.Context, cfg Config) (Snapshot, error) {
 		workers = len(cfg.EntryIDs)
 	}
 
-	t := newTable(cfg.EntryIDs)
+	t := newTable(cfg.EntryIDs, cfg.EntryTimeout)
 	updates := make(chan struct{}, workers*2+1)
 	var dispatch sync.WaitGroup
 	dispatch.Add(1)
@@ -248,16 +299,18 @@ func Run(ctx context.Context, cfg Config) (Snapshot, error) {
 					return
 				}
 				notify()
+				stageCtx, cancel := stageContext(ctx, j)
 				var value any
 				var err error
 				switch j.stage {
 				case StageExtraction:
-					value, err = cfg.Extract(j.entry.ID)
+					value, err = cfg.Extract(stageCtx, j.entry.ID)
 				case StageLookup:
-					value, err = cfg.Lookup(j.entry.Text)
+					value, err = cfg.Lookup(stageCtx, j.entry.Text)
 				case StageSummary:
-					value, err = cfg.Summarize(j.entry.Result)
+					value, err = summarize(stageCtx, cfg, j.entry.Result)
 				}
+				cancel()
 				t.complete(j, value, err)
 				notify()
 			}
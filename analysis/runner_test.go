@@ -4,6 +4,7 @@ package analysis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -20,21 +21,21 @@ func TestRunSkipsActiveEarlierEntry(t *testing.T) {
 	done := make(chan error, 1)
 	go func() {
 		_, err := Run(context.Background(), Config{
-			EntryIDs: []int{1, 2},
+			EntryIDs: []string{"1", "2"},
 			Workers:  2,
-			Extract: func(id int) (string, error) {
-				if id == 1 {
+			Extract: func(_ context.Context, id string) (string, error) {
+				if id == "1" {
 					close(firstStarted)
 					<-releaseFirst
 				} else {
 					close(secondStarted)
 				}
-				return fmt.Sprintf("entry %d", id), nil
+				return fmt.Sprintf("entry %s", id), nil
 			},
-			Lookup: func(text string) (*lookup.Result, error) {
+			Lookup: func(_ context.Context, text string) (*lookup.Result, error) {
 				return &lookup.Result{Text: text}, nil
 			},
-			Summarize: func(*lookup.Result) (Summary, error) { return Summary{}, nil },
+			Summarize: func(context.Context, *lookup.Result) (Summary, error) { return Summary{}, nil },
 		})
 		done <- err
 	}()
@@ -57,13 +58,16 @@ func TestRunSkipsActiveEarlierEntry(t *testing.T) {
 
 func TestRunReportsStageFailureAsTerminal(t *testing.T) {
 	result, err := Run(context.Background(), Config{
-		EntryIDs: []int{1},
+		EntryIDs: []string{"1"},
 		Workers:  1,
-		Extract: func(int) (string, error) {
+		Extract: func(context.Context, string) (string, error) {
 			return "", fmt.Errorf("bad extraction")
 		},
-		Lookup:    func(string) (*lookup.Result, error) { t.Fatal("unexpected lookup"); return nil, nil },
-		Summarize: func(*lookup.Result) (Summary, error) { t.Fatal("unexpected summary"); return Summary{}, nil },
+		Lookup: func(context.Context, string) (*lookup.Result, error) { t.Fatal("unexpected lookup"); return nil, nil },
+		Summarize: func(context.Context, *lookup.Result) (Summary, error) {
+			t.Fatal("unexpected summary")
+			return Summary{}, nil
+		},
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -78,11 +82,11 @@ func TestRunSerializesProgressCallbacks(t *testing.T) {
 	inCallback := false
 	concurrent := false
 	_, err := Run(context.Background(), Config{
-		EntryIDs: []int{1, 2, 3},
+		EntryIDs: []string{"1", "2", "3"},
 		Workers:  3,
-		Extract:  func(id int) (string, error) { return fmt.Sprint(id), nil },
-		Lookup:   func(text string) (*lookup.Result, error) { return &lookup.Result{Text: text}, nil },
-		Summarize: func(*lookup.Result) (Summary, error) {
+		Extract:  func(_ context.Context, id string) (string, error) { return fmt.Sprint(id), nil },
+		Lookup:   func(_ context.Context, text string) (*lookup.Result, error) { return &lookup.Result{Text: text}, nil },
+		Summarize: func(context.Context, *lookup.Result) (Summary, error) {
 			return Summary{}, nil
 		},
 		Progress: func(Snapshot) {
@@ -114,15 +118,18 @@ func TestRunCancellationStopsNewClaims(t *testing.T) {
 	errCh := make(chan error, 1)
 	go func() {
 		result, err := Run(ctx, Config{
-			EntryIDs: []int{1, 2},
+			EntryIDs: []string{"1", "2"},
 			Workers:  1,
-			Extract: func(id int) (string, error) {
+			Extract: func(_ context.Context, id string) (string, error) {
 				close(started)
 				<-release
 				return fmt.Sprint(id), nil
 			},
-			Lookup:    func(string) (*lookup.Result, error) { t.Fatal("unexpected lookup"); return nil, nil },
-			Summarize: func(*lookup.Result) (Summary, error) { t.Fatal("unexpected summary"); return Summary{}, nil },
+			Lookup: func(context.Context, string) (*lookup.Result, error) { t.Fatal("unexpected lookup"); return nil, nil },
+			Summarize: func(context.Context, *lookup.Result) (Summary, error) {
+				t.Fatal("unexpected summary")
+				return Summary{}, nil
+			},
 		})
 		resultCh <- result
 		errCh <- err
@@ -138,3 +145,63 @@ func TestRunCancellationStopsNewClaims(t *testing.T) {
 		t.Fatalf("completed = %d, want 0", result.Completed)
 	}
 }
+
+func TestRunCancellationReachesActiveStage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err := Run(ctx, Config{
+		EntryIDs: []string{"1"},
+		Workers:  1,
+		Extract: func(ctx context.Context, id string) (string, error) {
+			close(started)
+			<-ctx.Done()
+			return "", ctx.Err()
+		},
+		Lookup:    func(context.Context, string) (*lookup.Result, error) { return nil, nil },
+		Summarize: func(context.Context, *lookup.Result) (Summary, error) { return Summary{}, nil },
+	})
+	if err != context.Canceled {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRunEntryTimeoutSpansStages(t *testing.T) {
+	result, err := Run(context.Background(), Config{
+		EntryIDs:     []string{"1", "2"},
+		Workers:      2,
+		EntryTimeout: 50 * time.Millisecond,
+		Extract: func(_ context.Context, id string) (string, error) {
+			if id == "1" {
+				time.Sleep(60 * time.Millisecond)
+			}
+			return id, nil
+		},
+		Lookup: func(ctx context.Context, text string) (*lookup.Result, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return &lookup.Result{Text: text}, nil
+		},
+		Summarize: func(context.Context, *lookup.Result) (Summary, error) { return Summary{}, nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Entries[0].LookupError; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("entry 1 lookup error = %v, want deadline exceeded", err)
+	}
+	if result.Entries[1].SummaryStatus != StatusCompleted {
+		t.Fatalf("entry 2 = %+v, want completed", result.Entries[1])
+	}
+}
+
+func TestMatchSummaryWithoutRecords(t *testing.T) {
+	summary := MatchSummary(&lookup.Result{Text: "entry"})
+	if summary.Match != nil || summary.Comment != "" || summary.Mismatch {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+}
@@ -6,6 +6,7 @@ package arxiv
 // https://info.arxiv.org/help/api/user-manual.html
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/httpcache"
 	"github.com/sandialabs/bibcheck/internal/wasmhttp"
 )
 
@@ -23,6 +25,8 @@ var ErrDoesNotExist = errors.New("no arxiv entry found")
 // Client represents a client for the arXiv API
 type Client struct {
 	httpClient *http.Client
+	transport  http.RoundTripper
+	cache      *httpcache.Cache
 }
 
 // Feed represents the Atom feed response from arXiv
@@ -89,17 +93,32 @@ func (e *Entry) ToString() string {
 	return s
 }
 
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTransport sends requests the cache cannot answer through t instead of
+// http.DefaultTransport.
+func WithTransport(t http.RoundTripper) Option {
+	return func(c *Client) { c.transport = t }
+}
+
+// WithCache replaces the response cache; nil disables caching.
+func WithCache(cache *httpcache.Cache) Option {
+	return func(c *Client) { c.cache = cache }
+}
+
 // NewClient creates a new arXiv API client with proper identification
-func NewClient() *Client {
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+func NewClient(options ...Option) *Client {
+	c := &Client{cache: httpcache.Default()}
+	for _, option := range options {
+		option(c)
 	}
+	c.httpClient = c.cache.Client(30*time.Second, c.transport)
+	return c
 }
 
 // GetByID retrieves metadata for a specific arXiv ID
-func (c *Client) GetByID(arxivID string) (*Entry, error) {
+func (c *Client) GetByID(ctx context.Context, arxivID string) (*Entry, error) {
 	// Extract just the ID part if full URL is provided
 	id := extractArxivID(arxivID)
 
@@ -107,7 +126,7 @@ func (c *Client) GetByID(arxivID string) (*Entry, error) {
 	apiURL := fmt.Sprintf("http://export.arxiv.org/api/query?id_list=%s", id)
 
 	// Make the request with proper headers
-	req, err := http.NewRequest("GET", apiURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -0,0 +1,50 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package bibliography
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NumericIDs returns the identifiers "1" through "n".
+func NumericIDs(n int) []string {
+	ids := make([]string, max(n, 0))
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+	return ids
+}
+
+// CleanIDs strips the brackets a document puts around its entry identifiers,
+// e.g. "[Smi20]" or "(Smith, 2020)", and drops empty and repeated ones.
+func CleanIDs(ids []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, id := range ids {
+		id = CleanID(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+// CleanID strips the brackets and trailing punctuation around one identifier.
+func CleanID(id string) string {
+	id = strings.TrimSpace(id)
+	for {
+		trimmed := strings.TrimSpace(strings.TrimRight(id, ".:"))
+		for _, pair := range []string{"[]", "()", "{}", "<>"} {
+			if len(trimmed) >= 2 && trimmed[0] == pair[0] && trimmed[len(trimmed)-1] == pair[1] {
+				trimmed = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			}
+		}
+		if trimmed == id {
+			return id
+		}
+		id = trimmed
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package bibliography
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumericIDs(t *testing.T) {
+	if got := NumericIDs(3); !reflect.DeepEqual(got, []string{"1", "2", "3"}) {
+		t.Fatalf("unexpected IDs: %q", got)
+	}
+	if got := NumericIDs(-1); len(got) != 0 {
+		t.Fatalf("unexpected IDs: %q", got)
+	}
+}
+
+func TestCleanIDs(t *testing.T) {
+	got := CleanIDs([]string{"[Smi20]", " (Smith, 2020) ", "12.", "[Smi20]", "", "[]", "Knuth84:"})
+	want := []string{"Smi20", "Smith, 2020", "12", "Knuth84"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package bibtex reads BibTeX databases so their entries can be checked
+// without extracting them from a rendered PDF.
+package bibtex
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/sandialabs/bibcheck/latex"
+)
+
+// Entry is one BibTeX entry. Field names are lower-case and field values are
+// kept as written, with macros expanded and concatenations joined.
+type Entry struct {
+	Type   string
+	Key    string
+	Fields map[string]string
+}
+
+// months are the predefined BibTeX month macros.
+var months = map[string]string{
+	"jan": "January", "feb": "February", "mar": "March", "apr": "April",
+	"may": "May", "jun": "June", "jul": "July", "aug": "August",
+	"sep": "September", "oct": "October", "nov": "November", "dec": "December",
+}
+
+// Parse reads the entries of a BibTeX database, skipping @comment and
+// @preamble blocks and expanding @string macros. Like BibTeX, it rejects a
+// database that repeats an entry key, ignoring case.
+func Parse(data []byte) ([]Entry, error) {
+	p := &parser{src: string(data), macros: map[string]string{}}
+	for k, v := range months {
+		p.macros[k] = v
+	}
+
+	list := []Entry{}
+	// seen holds the line of each entry, by lower-case key
+	seen := map[string]int{}
+	for {
+		at := strings.IndexByte(p.src[p.pos:], '@')
+		if at < 0 {
+			return list, nil
+		}
+		p.pos += at + 1
+		kind := strings.ToLower(p.identifier())
+		p.space()
+		if p.pos >= len(p.src) || (p.src[p.pos] != '{' && p.src[p.pos] != '(') {
+			// a stray "@", e.g. in an e-mail address between entries
+			continue
+		}
+		closer := byte('}')
+		if p.src[p.pos] == '(' {
+			closer = ')'
+		}
+		p.pos++
+
+		switch kind {
+		case "comment", "preamble":
+			if err := p.skipGroup(closer); err != nil {
+				return nil, err
+			}
+		case "string":
+			name, value, err := p.field()
+			if err != nil {
+				return nil, err
+			}
+			p.macros[name] = value
+			if err := p.skipGroup(closer); err != nil {
+				return nil, err
+			}
+		default:
+			line := p.line()
+			e, err := p.entry(kind, closer)
+			if err != nil {
+				return nil, err
+			}
+			if e.Key != "" {
+				if first, ok := seen[strings.ToLower(e.Key)]; ok {
+					return nil, fmt.Errorf("bibtex line %d: repeated entry key %q, first used on line %d", line, e.Key, first)
+				}
+				seen[strings.ToLower(e.Key)] = line
+			}
+			list = append(list, e)
+		}
+	}
+}
+
+type parser struct {
+	src    string
+	pos    int
+	macros map[string]string
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("bibtex line %d: %s", p.line(), fmt.Sprintf(format, args...))
+}
+
+// line returns the 1-based line of the current position.
+func (p *parser) line() int {
+	return 1 + strings.Count(p.src[:min(p.pos, len(p.src))], "\n")
+}
+
+func (p *parser) space() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if unicode.IsSpace(rune(c)) || strings.IndexByte(`{}(),="#%`, c) >= 0 {
+			break
+		}
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *parser) entry(kind string, closer byte) (Entry, error) {
+	p.space()
+	e := Entry{Type: kind, Fields: map[string]string{}}
+	e.Key = strings.TrimSpace(p.identifier())
+	for {
+		p.space()
+		if p.pos >= len(p.src) {
+			return e, p.errorf("unterminated entry %q", e.Key)
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+			continue
+		case closer:
+			p.pos++
+			return e, nil
+		}
+		name, value, err := p.field()
+		if err != nil {
+			return e, err
+		}
+		e.Fields[name] = value
+	}
+}
+
+func (p *parser) field() (string, string, error) {
+	p.space()
+	name := strings.ToLower(p.identifier())
+	if name == "" {
+		return "", "", p.errorf("expected a field name")
+	}
+	p.space()
+	if p.pos >= len(p.src) || p.src[p.pos] != '=' {
+		return "", "", p.errorf("expected \"=\" after %q", name)
+	}
+	p.pos++
+
+	var b strings.Builder
+	for {
+		p.space()
+		if p.pos >= len(p.src) {
+			return "", "", p.errorf("unterminated value for %q", name)
+		}
+		switch c := p.src[p.pos]; c {
+		case '{':
+			value, err := p.braced()
+			if err != nil {
+				return "", "", err
+			}
+			b.WriteString(value)
+		case '"':
+			value, err := p.quoted()
+			if err != nil {
+				return "", "", err
+			}
+			b.WriteString(value)
+		default:
+			word := p.identifier()
+			if word == "" {
+				return "", "", p.errorf("unexpected %q in value for %q", c, name)
+			}
+			if value, ok := p.macros[strings.ToLower(word)]; ok {
+				b.WriteString(value)
+			} else {
+				b.WriteString(word)
+			}
+		}
+		p.space()
+		if p.pos < len(p.src) && p.src[p.pos] == '#' {
+			p.pos++
+			continue
+		}
+		return name, strings.TrimSpace(b.String()), nil
+	}
+}
+
+// braced returns the contents of the brace group at p.pos, keeping nested
+// braces, which protect case and LaTeX commands.
+func (p *parser) braced() (string, error) {
+	start := p.pos
+	depth := 0
+	for ; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return p.src[start+1 : p.pos-1], nil
+			}
+		}
+	}
+	p.pos = start
+	return "", p.errorf("unbalanced braces")
+}
+
+func (p *parser) quoted() (string, error) {
+	start := p.pos
+	depth := 0
+	for p.pos++; p.pos < len(p.src); p.pos++ {
+		switch p.src[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '"':
+			if depth == 0 {
+				p.pos++
+				return p.src[start+1 : p.pos-1], nil
+			}
+		}
+	}
+	p.pos = start
+	return "", p.errorf("unterminated quoted value")
+}
+
+// skipGroup moves past the closer of the current @-block.
+func (p *parser) skipGroup(closer byte) error {
+	depth := 0
+	for ; p.pos < len(p.src); p.pos++ {
+		switch c := p.src[p.pos]; {
+		case c == '{':
+			depth++
+		case c == '}' && depth > 0:
+			depth--
+		case c == closer && depth == 0:
+			p.pos++
+			return nil
+		}
+	}
+	return p.errorf("unterminated block")
+}
+
+// Field returns the named field converted to plain text.
+func (e *Entry) Field(name string) string {
+	return latex.ToText(e.Fields[name])
+}
+
+// Authors returns the authors as "Given Family" names, or the editors when
+// the entry has no authors.
+func (e *Entry) Authors() []string {
+	names, _ := e.names()
+	return names
+}
+
+// Incomplete reports whether the author list ends in "and others".
+func (e *Entry) Incomplete() bool {
+	_, others := e.names()
+	return others
+}
+
+func (e *Entry) names() ([]string, bool) {
+	raw := e.Fields["author"]
+	if strings.TrimSpace(raw) == "" {
+		raw = e.Fields["editor"]
+	}
+	names := []string{}
+	others := false
+	for _, name := range splitNames(raw) {
+		if strings.EqualFold(name, "others") {
+			others = true
+			continue
+		}
+		names = append(names, displayName(name))
+	}
+	return names, others
+}
+
+// splitNames splits a BibTeX name list on " and " outside braces.
+func splitNames(s string) []string {
+	names := []string{}
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		if depth == 0 && i+5 <= len(s) && strings.EqualFold(s[i:i+5], " and ") {
+			names = append(names, s[start:i])
+			start = i + 5
+			i += 4
+		}
+	}
+	names = append(names, s[start:])
+
+	out := names[:0]
+	for _, name := range names {
+		if name = strings.Join(strings.Fields(name), " "); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// displayName turns "Family, Given" and "Family, Jr, Given" into
+// "Given Family" (with any suffix last).
+func displayName(name string) string {
+	parts := strings.Split(name, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	switch len(parts) {
+	case 2:
+		name = parts[1] + " " + parts[0]
+	case 3:
+		name = parts[2] + " " + parts[0] + " " + parts[1]
+	}
+	return latex.ToText(name)
+}
+
+// Venue returns where the entry was published.
+func (e *Entry) Venue() string {
+	for _, name := range []string{"journal", "booktitle", "school", "institution", "publisher", "howpublished"} {
+		if v := e.Field(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// DOI returns the entry's DOI without a resolver prefix.
+func (e *Entry) DOI() string {
+	doi := strings.TrimSpace(e.Fields["doi"])
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		if len(doi) >= len(prefix) && strings.EqualFold(doi[:len(prefix)], prefix) {
+			return doi[len(prefix):]
+		}
+	}
+	return doi
+}
+
+// Arxiv returns the entry's arXiv identifier, if it has one.
+func (e *Entry) Arxiv() string {
+	prefix := strings.ToLower(e.Fields["archiveprefix"] + e.Fields["eprinttype"])
+	if strings.Contains(prefix, "arxiv") || e.Fields["arxiv"] != "" {
+		return strings.TrimSpace(e.Fields["eprint"] + e.Fields["arxiv"])
+	}
+	return ""
+}
+
+// Text renders the entry as a bibliography entry, including its identifiers
+// so that lookup sources can find them.
+func (e *Entry) Text() string {
+	parts := []string{}
+	if authors := e.Authors(); len(authors) > 0 {
+		list := strings.Join(authors, ", ")
+		if e.Incomplete() {
+			list += " et al"
+		}
+		parts = append(parts, list)
+	}
+	if title := e.Field("title"); title != "" {
+		parts = append(parts, title)
+	}
+
+	venue := e.Venue()
+	for _, detail := range []struct{ label, name string }{
+		{"vol. ", "volume"}, {"no. ", "number"}, {"pp. ", "pages"},
+	} {
+		if v := e.Field(detail.name); v != "" {
+			venue = strings.TrimPrefix(venue+", "+detail.label+v, ", ")
+		}
+	}
+	if year := e.Field("year"); year != "" {
+		venue = strings.TrimPrefix(venue+", "+year, ", ")
+	} else if date := e.Field("date"); date != "" {
+		venue = strings.TrimPrefix(venue+", "+date, ", ")
+	}
+	if venue != "" {
+		parts = append(parts, venue)
+	}
+
+	if doi := e.DOI(); doi != "" {
+		parts = append(parts, "doi:"+doi)
+	}
+	if id := e.Arxiv(); id != "" {
+		parts = append(parts, "arXiv:"+id)
+	}
+	if url := strings.TrimSpace(e.Fields["url"]); url != "" {
+		parts = append(parts, url)
+	}
+
+	text := ""
+	for _, part := range parts {
+		part = strings.TrimRight(part, ".")
+		text += part + ". "
+	}
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package bibtex
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sandialabs/bibcheck/entries"
+)
+
+const testBib = `
+% a line comment with an @ sign
+@string{jpdc = "Journal of Parallel and Distributed Computing"}
+@comment{ignored @article{nope, title={Nope}} }
+@preamble{"\newcommand{\noop}[1]{}"}
+
+@Article{edwards2014kokkos,
+  author    = {Edwards, H. Carter and Trott, Christian R. and Sunderland, Daniel},
+  title     = {{Kokkos}: Enabling manycore performance portability through polymorphic memory access patterns},
+  journal   = jpdc,
+  volume    = 74,
+  number    = {12},
+  pages     = {3202--3216},
+  year      = 2014,
+  month     = dec,
+  doi       = {https://doi.org/10.1016/j.jpdc.2014.07.003},
+}
+
+@misc(vaswani2017attention,
+  title = "Attention Is All You Need",
+  author = "Vaswani, Ashish and Shazeer, Noam and others",
+  eprint = {1706.03762},
+  archivePrefix = {arXiv},
+  note = "Version " # "7"
+)
+
+@software{kokkos_repo,
+  author = {{Kokkos Team}},
+  title = {Kokkos Core},
+  url = {https://github.com/kokkos/kokkos},
+}
+`
+
+func TestParse(t *testing.T) {
+	list, err := Parse([]byte(testBib))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("len = %d, want 3: %+v", len(list), list)
+	}
+
+	kokkos := list[0]
+	if kokkos.Type != "article" || kokkos.Key != "edwards2014kokkos" {
+		t.Fatalf("unexpected entry: %+v", kokkos)
+	}
+	if got := kokkos.Venue(); got != "Journal of Parallel and Distributed Computing" {
+		t.Fatalf("venue = %q", got)
+	}
+	if got := kokkos.Fields["month"]; got != "December" {
+		t.Fatalf("month = %q", got)
+	}
+	if got := kokkos.DOI(); got != "10.1016/j.jpdc.2014.07.003" {
+		t.Fatalf("doi = %q", got)
+	}
+	want := []string{"H. Carter Edwards", "Christian R. Trott", "Daniel Sunderland"}
+	if got := kokkos.Authors(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("authors = %q", got)
+	}
+
+	attention := list[1]
+	if attention.Arxiv() != "1706.03762" || !attention.Incomplete() {
+		t.Fatalf("unexpected arXiv entry: %+v", attention)
+	}
+	if got := attention.Fields["note"]; got != "Version 7" {
+		t.Fatalf("note = %q", got)
+	}
+
+	if got := list[2].Authors(); !reflect.DeepEqual(got, []string{"Kokkos Team"}) {
+		t.Fatalf("corporate author = %q", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		`@article{key, title = {unbalanced}`,
+		`@article{key, title = "open}`,
+		`@article{key, title {missing equals}}`,
+	} {
+		if _, err := Parse([]byte(src)); err == nil {
+			t.Errorf("expected an error for %q", src)
+		}
+	}
+}
+
+func TestParseRejectsRepeatedKeys(t *testing.T) {
+	src := "@article{smith, title = {First}}\n\n@book{Smith, title = {Second}}\n"
+	_, err := Parse([]byte(src))
+	if err == nil {
+		t.Fatal("expected an error for a repeated key")
+	}
+	if want := `bibtex line 3: repeated entry key "Smith", first used on line 1`; err.Error() != want {
+		t.Fatalf("error = %q, want %q", err, want)
+	}
+}
+
+func TestEntryText(t *testing.T) {
+	list, err := Parse([]byte(testBib))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := list[0].Text()
+	want := "H. Carter Edwards, Christian R. Trott, Daniel Sunderland. Kokkos: Enabling manycore performance portability through polymorphic memory access patterns. Journal of Parallel and Distributed Computing, vol. 74, no. 12, pp. 3202–3216, 2014. doi:10.1016/j.jpdc.2014.07.003."
+	if got != want {
+		t.Fatalf("text = %q\nwant   %q", got, want)
+	}
+	if doi := entries.ExtractDOI(got); doi != "10.1016/j.jpdc.2014.07.003" {
+		t.Fatalf("DOI not recoverable from text: %q", doi)
+	}
+
+	if got := entries.ExtractArxiv(list[1].Text()); got != "https://arxiv.org/abs/1706.03762" {
+		t.Fatalf("arXiv ID not recoverable from %q", list[1].Text())
+	}
+	if got := list[1].Text(); !strings.Contains(got, "Noam Shazeer et al.") {
+		t.Fatalf("text = %q", got)
+	}
+}
+
+func TestParserAnswersFromFields(t *testing.T) {
+	list, err := Parse([]byte(testBib))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser(list, nil)
+
+	text := list[0].Text()
+	title, err := p.ParseTitle(context.Background(), text)
+	if err != nil || !strings.HasPrefix(title, "Kokkos: Enabling") {
+		t.Fatalf("title = %q, %v", title, err)
+	}
+	authors, err := p.ParseAuthors(context.Background(), list[1].Text())
+	if err != nil || len(authors.Authors) != 2 || !authors.Incomplete {
+		t.Fatalf("authors = %+v, %v", authors, err)
+	}
+	online, err := p.ParseOnline(context.Background(), list[2].Text())
+	if err != nil || online.URL != "https://github.com/kokkos/kokkos" {
+		t.Fatalf("online = %+v, %v", online, err)
+	}
+
+	if _, err := p.ParseTitle(context.Background(), "not in the database"); err == nil {
+		t.Fatal("expected an error without a fallback parser")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package bibtex
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/entries"
+)
+
+// Parser answers entries.Parser questions from the structured fields of
+// BibTeX entries instead of asking an LLM. Entry texts it does not know, and
+// questions its entries cannot answer, go to the fallback parser, if any.
+type Parser struct {
+	byText   map[string]*Entry
+	fallback entries.Parser
+}
+
+// NewParser returns a parser for the texts rendered by Entry.Text.
+func NewParser(list []Entry, fallback entries.Parser) *Parser {
+	p := &Parser{byText: map[string]*Entry{}, fallback: fallback}
+	for i := range list {
+		p.byText[list[i].Text()] = &list[i]
+	}
+	return p
+}
+
+func (p *Parser) lookup(text string) (*Entry, error) {
+	if e, ok := p.byText[strings.TrimSpace(text)]; ok {
+		return e, nil
+	}
+	if p.fallback == nil {
+		return nil, fmt.Errorf("entry is not from the BibTeX database")
+	}
+	return nil, nil
+}
+
+func (p *Parser) ParseURL(ctx context.Context, text string) (string, error) {
+	e, err := p.lookup(text)
+	if err != nil {
+		return "", err
+	}
+	if e == nil {
+		return p.fallback.ParseURL(ctx, text)
+	}
+	return strings.TrimSpace(e.Fields["url"]), nil
+}
+
+func (p *Parser) ParseOnline(ctx context.Context, text string) (*entries.Online, error) {
+	e, err := p.lookup(text)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return p.fallback.ParseOnline(ctx, text)
+	}
+	return &entries.Online{
+		Title:   e.Field("title"),
+		Authors: e.Authors(),
+		URL:     strings.TrimSpace(e.Fields["url"]),
+	}, nil
+}
+
+func (p *Parser) ParseAuthors(ctx context.Context, text string) (*entries.Authors, error) {
+	e, err := p.lookup(text)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return p.fallback.ParseAuthors(ctx, text)
+	}
+	return &entries.Authors{Authors: e.Authors(), Incomplete: e.Incomplete()}, nil
+}
+
+func (p *Parser) ParseTitle(ctx context.Context, text string) (string, error) {
+	e, err := p.lookup(text)
+	if err != nil {
+		return "", err
+	}
+	if e == nil {
+		return p.fallback.ParseTitle(ctx, text)
+	}
+	return e.Field("title"), nil
+}
+
+func (p *Parser) ParsePub(ctx context.Context, text string) (string, error) {
+	e, err := p.lookup(text)
+	if err != nil {
+		return "", err
+	}
+	if e == nil {
+		return p.fallback.ParsePub(ctx, text)
+	}
+	return e.Venue(), nil
+}
@@ -0,0 +1,310 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package cassette records the HTTP traffic of a bibcheck run to a file and
+// replays it later without network access, so that tests of the full pipeline
+// are reproducible and need no API keys.
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+type Mode string
+
+const (
+	ModeRecord Mode = "record"
+	ModeReplay Mode = "replay"
+)
+
+// ErrNotRecorded is returned when replaying a request the cassette has no
+// response for.
+var ErrNotRecorded = errors.New("cassette has no recorded response")
+
+// redacted lists query parameters whose values are credentials. They are
+// neither written to the cassette nor used to match requests.
+var redacted = map[string]bool{
+	"access_token": true,
+	"api_key":      true,
+	"apikey":       true,
+	"key":          true,
+	"token":        true,
+}
+
+// Cassette is a set of recorded HTTP interactions. A nil *Cassette records and
+// replays nothing.
+type Cassette struct {
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Interaction is one recorded request and its response. Request bodies are
+// stored as a hash since they can be large and are only needed for matching.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method     string `json:"method"`
+	URL        string `json:"url"`
+	Accept     string `json:"accept,omitempty"`
+	BodySHA256 string `json:"body_sha256,omitempty"`
+}
+
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+	// Encoding is "base64" when Body is not valid UTF-8.
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type file struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Open returns a cassette stored at path. In replay mode the file must exist;
+// in record mode it is replaced as requests are made.
+func Open(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{path: path, mode: mode}
+	switch mode {
+	case ModeRecord:
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("create cassette dir: %w", err)
+		}
+		if err := c.save(); err != nil {
+			return nil, err
+		}
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read cassette: %w", err)
+		}
+		var f file
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parse cassette %s: %w", path, err)
+		}
+		c.interactions = f.Interactions
+		c.used = make([]bool, len(f.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q (supported: record, replay)", mode)
+	}
+	return c, nil
+}
+
+// Mode returns whether the cassette records or replays.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// Interactions returns a copy of the recorded interactions.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Transport returns a RoundTripper that records the responses of base, or
+// replays recorded responses without calling base. Requests to loopback
+// addresses, such as test servers, always go to base. A nil base uses
+// http.DefaultTransport; a nil cassette returns base unchanged.
+func (c *Cassette) Transport(base http.RoundTripper) http.RoundTripper {
+	if c == nil {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{cassette: c, base: base}
+}
+
+type transport struct {
+	cassette *Cassette
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isLoopback(req.URL.Hostname()) {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := newRequest(req, body)
+
+	if t.cassette.mode == ModeReplay {
+		recorded, ok := t.cassette.find(key)
+		if !ok {
+			return nil, fmt.Errorf("%w for %s %s", ErrNotRecorded, key.Method, key.URL)
+		}
+		return recorded.response(req)
+	}
+
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err := t.cassette.record(key, resp, respBody); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// find returns the first unused interaction matching key. Once all matching
+// interactions have been replayed the last one is repeated, so concurrent
+// identical requests get the same answer in any order.
+func (c *Cassette) find(key Request) (Interaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, interaction := range c.interactions {
+		if interaction.Request != key {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return interaction, true
+		}
+		last = i
+	}
+	if last < 0 {
+		return Interaction{}, false
+	}
+	return c.interactions[last], true
+}
+
+func (c *Cassette) record(key Request, resp *http.Response, body []byte) error {
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	recorded := Response{Status: resp.StatusCode, Header: header, Body: string(body)}
+	if !utf8.Valid(body) {
+		recorded.Body = base64.StdEncoding.EncodeToString(body)
+		recorded.Encoding = "base64"
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, Interaction{Request: key, Response: recorded})
+	return c.save()
+}
+
+// save writes the cassette. Callers hold c.mu or own c exclusively.
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(file{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+func (i Interaction) response(req *http.Request) (*http.Response, error) {
+	body := []byte(i.Response.Body)
+	if i.Response.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(i.Response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decode cassette body for %s: %w", i.Request.URL, err)
+		}
+		body = decoded
+	}
+	header := i.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Response.Status, http.StatusText(i.Response.Status)),
+		StatusCode:    i.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+	return body, nil
+}
+
+// newRequest describes req for matching, with credentials removed from the
+// query and the query parameters in a stable order.
+func newRequest(req *http.Request, body []byte) Request {
+	u := *req.URL
+	query := u.Query()
+	for name := range query {
+		if redacted[strings.ToLower(name)] {
+			query.Set(name, "REDACTED")
+		}
+	}
+	u.RawQuery = query.Encode()
+	u.Fragment = ""
+
+	r := Request{
+		Method: req.Method,
+		URL:    u.String(),
+		Accept: req.Header.Get("Accept"),
+	}
+	if len(body) > 0 {
+		// multipart boundaries are random, so they must not affect matching
+		if _, params, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err == nil && params["boundary"] != "" {
+			body = bytes.ReplaceAll(body, []byte(params["boundary"]), []byte("BOUNDARY"))
+		}
+		sum := sha256.Sum256(body)
+		r.BodySHA256 = hex.EncodeToString(sum[:])
+	}
+	return r
+}
+
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
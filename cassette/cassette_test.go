@@ -0,0 +1,184 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cassette
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type upstream struct {
+	calls int
+}
+
+func (u *upstream) RoundTrip(req *http.Request) (*http.Response, error) {
+	u.calls++
+	body := "response to " + req.Method + " " + req.URL.Path
+	if req.Body != nil {
+		sent, _ := io.ReadAll(req.Body)
+		body += " with " + string(sent)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"text/plain"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func roundTrip(t *testing.T, rt http.RoundTripper, req *http.Request) (string, error) {
+	t.Helper()
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body), nil
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	recorder, err := Open(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := &upstream{}
+	rt := recorder.Transport(live)
+
+	get, _ := http.NewRequest(http.MethodGet, "https://api.example.org/search?q=mpi&apiKey=secret", nil)
+	post, _ := http.NewRequest(http.MethodPost, "https://llm.example.org/chat/completions", strings.NewReader(`{"model":"m"}`))
+	post.Header.Set("Authorization", "Bearer secret")
+	wantGet, err := roundTrip(t, rt, get)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPost, err := roundTrip(t, rt, post)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("cassette contains credentials:\n%s", data)
+	}
+
+	player, err := Open(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline := &upstream{}
+	rt = player.Transport(offline)
+
+	get, _ = http.NewRequest(http.MethodGet, "https://api.example.org/search?apiKey=other&q=mpi", nil)
+	if got, err := roundTrip(t, rt, get); err != nil || got != wantGet {
+		t.Fatalf("replayed GET = %q, %v; want %q", got, err, wantGet)
+	}
+	post, _ = http.NewRequest(http.MethodPost, "https://llm.example.org/chat/completions", strings.NewReader(`{"model":"m"}`))
+	if got, err := roundTrip(t, rt, post); err != nil || got != wantPost {
+		t.Fatalf("replayed POST = %q, %v; want %q", got, err, wantPost)
+	}
+	if offline.calls != 0 {
+		t.Fatalf("replay made %d upstream calls", offline.calls)
+	}
+
+	post, _ = http.NewRequest(http.MethodPost, "https://llm.example.org/chat/completions", strings.NewReader(`{"model":"other"}`))
+	if _, err := roundTrip(t, rt, post); !errors.Is(err, ErrNotRecorded) {
+		t.Fatalf("unrecorded request error = %v, want ErrNotRecorded", err)
+	}
+}
+
+func TestReplayIgnoresMultipartBoundary(t *testing.T) {
+	upload := func() *http.Request {
+		var body strings.Builder
+		w := multipart.NewWriter(&body)
+		part, _ := w.CreateFormFile("file", "paper.pdf")
+		part.Write([]byte("%PDF-1.7"))
+		w.Close()
+		req, _ := http.NewRequest(http.MethodPost, "https://llm.example.org/extract/textract/create", strings.NewReader(body.String()))
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := Open(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := roundTrip(t, recorder.Transport(&upstream{}), upload()); err != nil {
+		t.Fatal(err)
+	}
+
+	player, err := Open(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := roundTrip(t, player.Transport(&upstream{}), upload()); err != nil {
+		t.Fatalf("replay with a new boundary: %v", err)
+	}
+}
+
+func TestReplayRepeatsLastMatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := Open(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.org/works/1", nil)
+	if _, err := roundTrip(t, recorder.Transport(&upstream{}), req); err != nil {
+		t.Fatal(err)
+	}
+
+	player, err := Open(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt := player.Transport(&upstream{})
+	for range 3 {
+		if _, err := roundTrip(t, rt, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoopbackRequestsPassThrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("local"))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := os.WriteFile(path, []byte(`{"interactions":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	player, err := Open(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if got, err := roundTrip(t, player.Transport(nil), req); err != nil || got != "local" {
+		t.Fatalf("loopback request = %q, %v", got, err)
+	}
+}
+
+func TestOpenReplayRequiresFile(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Fatal("expected an error for a missing cassette")
+	}
+	if _, err := Open(filepath.Join(t.TempDir(), "cassette.json"), Mode("rewind")); err == nil {
+		t.Fatal("expected an error for an unknown mode")
+	}
+}
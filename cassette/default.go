@@ -0,0 +1,68 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cassette
+
+import (
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/sandialabs/bibcheck/config"
+)
+
+var (
+	defaultOnce     sync.Once
+	defaultCassette *Cassette
+	defaultErr      error
+)
+
+// Default returns the cassette selected by the runtime settings, or nil when
+// none is configured. It is opened once per process.
+func Default() (*Cassette, error) {
+	defaultOnce.Do(func() {
+		settings := config.Runtime()
+		if settings.Cassette == "" {
+			return
+		}
+		mode := Mode(settings.CassetteMode)
+		if mode == "" {
+			mode = ModeReplay
+		}
+		defaultCassette, defaultErr = Open(settings.Cassette, mode)
+	})
+	return defaultCassette, defaultErr
+}
+
+// Transport wraps base with the Default cassette. If the configured cassette
+// cannot be opened, every request fails rather than reaching the network.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	c, err := Default()
+	if err != nil {
+		return failingTransport{err: err}
+	}
+	return c.Transport(base)
+}
+
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, t.err
+}
+
+// LookupEnv is os.LookupEnv, except that while a cassette is replayed it
+// reports unset variables as set to a placeholder. Tests that need an API key
+// use it so that they run offline from a recorded cassette.
+func LookupEnv(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	if c, err := Default(); err == nil && c != nil && c.Mode() == ModeReplay {
+		return "cassette-replay", true
+	}
+	return "", false
+}
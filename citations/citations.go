@@ -0,0 +1,133 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package citations finds the in-text citations in the body of a document
+// and cross-checks them against its bibliography.
+package citations
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Style is how a citation refers to a bibliography entry.
+type Style string
+
+const (
+	// StyleNumeric citations give entry numbers, e.g. "[12]" or "[3-5]".
+	StyleNumeric Style = "numeric"
+	// StyleLabel citations give alphanumeric labels, e.g. "[Smi20]".
+	StyleLabel Style = "label"
+	// StyleAuthorYear citations give the first author and year, e.g.
+	// "(Smith et al., 2020)" or "Smith (2020)".
+	StyleAuthorYear Style = "author-year"
+	// StyleKey citations give the citation keys of LaTeX \cite commands.
+	StyleKey Style = "key"
+)
+
+// Citation is one reference from the body to a bibliography entry. A
+// citation of several entries, like "[3-5]", yields one Citation for each.
+type Citation struct {
+	Style Style
+	// Text is the citation as it appears in the body.
+	Text string
+	// Ref identifies the cited entry: its number, label or key, or
+	// "Surname Year" for author-year citations.
+	Ref string
+}
+
+var (
+	headingRe = regexp.MustCompile(`(?im)^[ \t]*(?:\d+\.?|[IVX]+\.)?[ \t]*(?:references|bibliography|works cited|literature cited)[ \t]*$`)
+
+	bracketRe = regexp.MustCompile(`\[([^\[\]\n]{1,200})\]`)
+	numberRe  = regexp.MustCompile(`^(\d{1,4})(?:\s*[-–—]\s*(\d{1,4}))?$`)
+	labelRe   = regexp.MustCompile(`^[A-Za-z][A-Za-z+.\-]*\d{2,4}[a-z]?$`)
+
+	parenRe = regexp.MustCompile(`\(([^()]{1,300})\)`)
+	// a first author, optionally followed by "et al." or a second author
+	authorPattern = `((?:(?:van|von|de|der|den|del|della|di|da|du|le|la)\s+)*\p{Lu}[\p{L}'’\-]+)(?:\s+et\s+al\.?|\s+(?:and|&)\s+(?:(?:van|von|de|der|den|del|della|di|da|du|le|la)\s+)*\p{Lu}[\p{L}'’\-]+)?`
+	yearPattern   = `(?:1[5-9]|20)\d\d[a-z]?`
+	authorYearRe  = regexp.MustCompile(`^(?:(?:see|see also|e\.g\.|cf\.|i\.e\.|also),?\s+)*` + authorPattern + `,?\s+(` + yearPattern + `(?:\s*,\s*` + yearPattern + `)*)\b`)
+	narrativeRe   = regexp.MustCompile(authorPattern + `\s+\((` + yearPattern + `)\)`)
+	yearRe        = regexp.MustCompile(yearPattern)
+
+	citeRe       = regexp.MustCompile(`\\(?:[A-Za-z]*cite[A-Za-z]*\*?|nocite)\s*(?:\[[^\]]*\]\s*){0,2}\{([^}]*)\}`)
+	texCommentRe = regexp.MustCompile(`(?m)(^|[^\\])%.*$`)
+)
+
+// Body returns text up to its last references heading, so the entries of
+// the bibliography are not mistaken for citations.
+func Body(text string) string {
+	locs := headingRe.FindAllStringIndex(text, -1)
+	if len(locs) == 0 {
+		return text
+	}
+	return text[:locs[len(locs)-1][0]]
+}
+
+// Find returns the numeric, label and author-year citations in text.
+func Find(text string) []Citation {
+	cites := []Citation{}
+	for _, m := range bracketRe.FindAllStringSubmatch(text, -1) {
+		cites = append(cites, bracketed(m[0], m[1])...)
+	}
+	for _, m := range parenRe.FindAllStringSubmatch(text, -1) {
+		for _, part := range strings.Split(m[1], ";") {
+			a := authorYearRe.FindStringSubmatch(strings.TrimSpace(part))
+			if a == nil {
+				continue
+			}
+			for _, y := range yearRe.FindAllString(a[2], -1) {
+				cites = append(cites, Citation{Style: StyleAuthorYear, Text: m[0], Ref: a[1] + " " + y})
+			}
+		}
+	}
+	for _, m := range narrativeRe.FindAllStringSubmatch(text, -1) {
+		cites = append(cites, Citation{Style: StyleAuthorYear, Text: m[0], Ref: m[1] + " " + m[2]})
+	}
+	return cites
+}
+
+// bracketed returns the citations of a bracketed list like "[1, 3-5]" or
+// "[Knu84; Lam94]". Lists with anything else in them, like "[0, 1]" or
+// "[see 3]", are not citations.
+func bracketed(text, list string) []Citation {
+	cites := []Citation{}
+	for _, part := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == ';' }) {
+		part = strings.TrimSpace(part)
+		if m := numberRe.FindStringSubmatch(part); m != nil {
+			lo, _ := strconv.Atoi(m[1])
+			hi := lo
+			if m[2] != "" {
+				hi, _ = strconv.Atoi(m[2])
+			}
+			if lo < 1 || hi < lo || hi-lo > 100 {
+				return nil
+			}
+			for n := lo; n <= hi; n++ {
+				cites = append(cites, Citation{Style: StyleNumeric, Text: text, Ref: strconv.Itoa(n)})
+			}
+		} else if labelRe.MatchString(part) {
+			cites = append(cites, Citation{Style: StyleLabel, Text: text, Ref: part})
+		} else {
+			return nil
+		}
+	}
+	return cites
+}
+
+// FindLaTeX returns the keys cited by the \cite-family and \nocite commands
+// in LaTeX source. \nocite{*} yields a citation with Ref "*".
+func FindLaTeX(src string) []Citation {
+	src = texCommentRe.ReplaceAllString(src, "$1")
+	cites := []Citation{}
+	for _, m := range citeRe.FindAllStringSubmatch(src, -1) {
+		for _, key := range strings.Split(m[1], ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				cites = append(cites, Citation{Style: StyleKey, Text: m[0], Ref: key})
+			}
+		}
+	}
+	return cites
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package citations
+
+import (
+	"reflect"
+	"testing"
+)
+
+func refs(cites []Citation) []string {
+	out := []string{}
+	for _, cite := range cites {
+		out = append(out, string(cite.Style)+":"+cite.Ref)
+	}
+	return out
+}
+
+func TestFind(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		want []string
+	}{
+		{"As a Top 500 [ 17 ] class computer", []string{"numeric:17"}},
+		{"prior work [1, 3–5; 9]", []string{"numeric:1", "numeric:3", "numeric:4", "numeric:5", "numeric:9"}},
+		{"on the interval [0, 1] and in [see 3]", []string{}},
+		{"as in [Knu84, GJMM18a]", []string{"label:Knu84", "label:GJMM18a"}},
+		{"was shown (Smith et al., 2020; Jones and Lee 2019a, 2021).", []string{"author-year:Smith 2020", "author-year:Jones 2019a", "author-year:Jones 2021"}},
+		{"(see van der Berg, 2018)", []string{"author-year:van der Berg 2018"}},
+		{"Goglin et al. (2018) showed", []string{"author-year:Goglin 2018"}},
+		{"in (Section 3) of the 2020 report (n = 2020)", []string{}},
+	} {
+		if got := refs(Find(tc.text)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Find(%q) = %v, want %v", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestFindLaTeX(t *testing.T) {
+	src := `As shown~\cite{knuth84,lamport94} and \citep[p.~3]{smith20}.
+% \cite{commented}
+Also 50\% of \citet*{jones19}.`
+	want := []string{"key:knuth84", "key:lamport94", "key:smith20", "key:jones19"}
+	if got := refs(FindLaTeX(src)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("FindLaTeX = %v, want %v", got, want)
+	}
+}
+
+func TestBody(t *testing.T) {
+	text := "1 Introduction\nTop 500 [1].\n\nReferences\n[1] Top500. 2023.\n[2] Uncited. 2020.\n"
+	if got := Body(text); got != "1 Introduction\nTop 500 [1].\n\n" {
+		t.Fatalf("Body = %q", got)
+	}
+}
+
+func TestCheckNumeric(t *testing.T) {
+	entries := []Entry{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	report := Check(entries, Find("see [1] and [1, 4] on [0, 1] and (Smith, 2020)"))
+	if report == nil {
+		t.Fatal("expected a report")
+	}
+	if report.Cited["1"] != 2 {
+		t.Fatalf("Cited[1] = %d, want 2", report.Cited["1"])
+	}
+	if !reflect.DeepEqual(report.Uncited, []string{"2", "3"}) {
+		t.Fatalf("Uncited = %v", report.Uncited)
+	}
+	// the author-year citation resolves to nothing, so that style is ignored
+	if len(report.Unresolved) != 1 || report.Unresolved[0].Ref != "4" || report.Unresolved[0].Text != "[1, 4]" {
+		t.Fatalf("Unresolved = %+v", report.Unresolved)
+	}
+}
+
+func TestCheckAuthorYear(t *testing.T) {
+	entries := []Entry{
+		{ID: "1", Text: "Jones, A. and Smith, B. 2020. A survey."},
+		{ID: "2", Text: "Smith, B. 2020a. First paper."},
+		{ID: "3", Text: "Smith, B. 2020b. Second paper."},
+		{ID: "4", Text: "Müller, C. 2019. Uncited paper."},
+	}
+	report := Check(entries, Find("(Smith, 2020b; Jones et al., 2020) and Smith (2020a), but (Brown, 2017)."))
+	if report == nil {
+		t.Fatal("expected a report")
+	}
+	want := map[string]int{"1": 1, "2": 1, "3": 1}
+	if !reflect.DeepEqual(report.Cited, want) {
+		t.Fatalf("Cited = %v, want %v", report.Cited, want)
+	}
+	if !reflect.DeepEqual(report.Uncited, []string{"4"}) {
+		t.Fatalf("Uncited = %v", report.Uncited)
+	}
+	if len(report.Unresolved) != 1 || report.Unresolved[0].Ref != "Brown 2017" {
+		t.Fatalf("Unresolved = %+v", report.Unresolved)
+	}
+}
+
+func TestCheckCountsRepeatedUnresolvedOnce(t *testing.T) {
+	report := Check([]Entry{{ID: "knuth84"}, {ID: "lamport94"}}, FindLaTeX(`\cite{knuth84} \cite{missing} \cite{missing}`))
+	if len(report.Unresolved) != 1 || report.Unresolved[0].Count != 2 {
+		t.Fatalf("Unresolved = %+v", report.Unresolved)
+	}
+	if !reflect.DeepEqual(report.Uncited, []string{"lamport94"}) {
+		t.Fatalf("Uncited = %v", report.Uncited)
+	}
+}
+
+func TestCheckNociteAll(t *testing.T) {
+	report := Check([]Entry{{ID: "knuth84"}}, FindLaTeX(`\nocite{*}`))
+	if report == nil || len(report.Uncited) != 0 || len(report.Unresolved) != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestCheckWithoutResolvedCitations(t *testing.T) {
+	if report := Check([]Entry{{ID: "1"}}, Find("nothing cited [7]")); report != nil {
+		t.Fatalf("report = %+v, want nil", report)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package citations
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/match"
+)
+
+// Entry is a bibliography entry, as listed and extracted by bibcheck.
+type Entry struct {
+	ID   string
+	Text string
+}
+
+// Unresolved is a citation that refers to no bibliography entry.
+type Unresolved struct {
+	Citation
+	// Count is how many times the body makes the citation.
+	Count int
+}
+
+// Report is the citation graph of a document, reduced to what needs review.
+type Report struct {
+	// Cited counts the citations of each entry, by ID.
+	Cited map[string]int
+	// Uncited are the IDs of entries the body never cites, in bibliography
+	// order.
+	Uncited []string
+	// Unresolved are the citations that match no entry, in body order.
+	Unresolved []Unresolved
+}
+
+// Check resolves cites against entries. A style is only checked when at
+// least one of its citations resolves: otherwise the bracketed numbers or
+// parenthesized years it matched are more likely prose than citations. Check
+// returns nil when no citation resolves, which usually means the body text
+// could not be read.
+func Check(entries []Entry, cites []Citation) *Report {
+	resolved := make([]string, len(cites))
+	styles := map[Style]bool{}
+	all := false
+	for i, cite := range cites {
+		if cite.Style == StyleKey && cite.Ref == "*" {
+			all = true
+		} else if id := resolve(entries, cite); id != "" {
+			resolved[i] = id
+			styles[cite.Style] = true
+		}
+	}
+	if len(styles) == 0 && !all {
+		return nil
+	}
+
+	report := &Report{Cited: map[string]int{}}
+	unresolved := map[Citation]int{}
+	for i, cite := range cites {
+		if !styles[cite.Style] || cite.Ref == "*" {
+			continue
+		}
+		if resolved[i] == "" {
+			key := Citation{Style: cite.Style, Ref: cite.Ref}
+			if j, ok := unresolved[key]; ok {
+				report.Unresolved[j].Count++
+				continue
+			}
+			unresolved[key] = len(report.Unresolved)
+			report.Unresolved = append(report.Unresolved, Unresolved{Citation: cite, Count: 1})
+			continue
+		}
+		report.Cited[resolved[i]]++
+	}
+	for _, entry := range entries {
+		if report.Cited[entry.ID] == 0 && !all {
+			report.Uncited = append(report.Uncited, entry.ID)
+		}
+	}
+	return report
+}
+
+// resolve returns the ID of the entry cite refers to, or "".
+func resolve(entries []Entry, cite Citation) string {
+	switch cite.Style {
+	case StyleNumeric, StyleLabel, StyleKey:
+		for _, entry := range entries {
+			if strings.EqualFold(entry.ID, cite.Ref) {
+				return entry.ID
+			}
+		}
+	case StyleAuthorYear:
+		return resolveAuthorYear(entries, cite.Ref)
+	}
+	return ""
+}
+
+// resolveAuthorYear returns the entry with the surname and year of ref, a
+// "Surname Year" string. When several entries qualify, it prefers the one
+// whose year suffix matches, then the one that names the author earliest,
+// i.e. the one where the author is most likely the first author.
+func resolveAuthorYear(entries []Entry, ref string) string {
+	i := strings.LastIndex(ref, " ")
+	surname, yearRef := match.Tokens(ref[:i]), strings.ToLower(ref[i+1:])
+	yearDigits := yearRef[:4]
+
+	best, bestRank := "", []int{}
+	for _, entry := range entries {
+		tokens := match.Tokens(entry.ID + " " + entry.Text)
+		at := indexOfWords(tokens, surname)
+		if at < 0 {
+			continue
+		}
+		suffixMismatch := -1
+		for _, token := range tokens {
+			if token == yearRef {
+				suffixMismatch = 0
+				break
+			}
+			if strings.HasPrefix(token, yearDigits) && len(token) <= 5 {
+				suffixMismatch = 1
+			}
+		}
+		if suffixMismatch < 0 {
+			continue
+		}
+		if rank := []int{suffixMismatch, at}; best == "" || slices.Compare(rank, bestRank) < 0 {
+			best, bestRank = entry.ID, rank
+		}
+	}
+	return best
+}
+
+// indexOfWords returns the index of the first run of words in tokens, or -1.
+func indexOfWords(tokens, words []string) int {
+	if len(words) == 0 {
+		return -1
+	}
+	for i := 0; i+len(words) <= len(tokens); i++ {
+		if slices.Equal(tokens[i:i+len(words)], words) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sandialabs/bibcheck/documents"
+)
+
+// annotateColors are the note colors of the summary states that get a note
+// in the annotated PDF.
+var annotateColors = map[summaryState]string{
+	summaryStateRetracted: "#D62728",
+	summaryStateWrongDOI:  "#D62728",
+	summaryStateReview:    "#FFB000",
+	summaryStateError:     "#8C8C8C",
+}
+
+// writeAnnotatedPDF writes a copy of the PDF at pdfPath to path with a note,
+// carrying the summary comment, on each entry that needs review, could not
+// be checked, is retracted or has a wrong DOI.
+func writeAnnotatedPDF(path, pdfPath string, b *documents.Bibliography, views []entryView) error {
+	pdf, err := os.ReadFile(pdfPath)
+	if err != nil {
+		return fmt.Errorf("read pdf error: %w", err)
+	}
+	var notes []documents.Note
+	for _, view := range views {
+		col, ok := annotateColors[view.summaryState]
+		if !ok {
+			continue
+		}
+		text := fmt.Sprintf("Entry %s: %s", view.id, view.summaryState)
+		if view.summaryComment != "" {
+			text += "\n" + view.summaryComment
+		}
+		if view.published != nil {
+			text += "\nCite the published version: " + view.published.Citation
+		}
+		notes = append(notes, documents.Note{
+			ID:    view.id,
+			Entry: view.originalText,
+			Title: "bibcheck: " + string(view.summaryState),
+			Text:  text,
+			Color: col,
+		})
+	}
+	annotated, err := documents.AnnotatePDF(pdf, b, notes)
+	if err != nil {
+		return fmt.Errorf("annotate pdf error: %w", err)
+	}
+	if err := os.WriteFile(path, annotated, 0o644); err != nil {
+		return fmt.Errorf("write annotated pdf error: %w", err)
+	}
+	log.Printf("Wrote %s with notes on %d of %d entries", path, len(notes), len(views))
+	return nil
+}
@@ -28,12 +28,12 @@ var bibCmd = &cobra.Command{
 				shirty.WithModel(settings.ShirtyModel),
 			)
 
-			bibliography, err := shirtyClient.PrepareBibliography(filePath)
+			bibliography, err := shirtyClient.PrepareBibliography(cmd.Context(), filePath)
 			if err != nil {
 				log.Fatalf("prepare bibliography error: %v", err)
 			}
 
-			entries, err := shirtyClient.ExtractBib(bibliography)
+			entries, err := shirtyClient.ExtractBib(cmd.Context(), bibliography)
 			if err != nil {
 				log.Fatalf("openai error: %v", err)
 			}
@@ -47,12 +47,12 @@ var bibCmd = &cobra.Command{
 				openrouter.WithBaseURL(settings.OpenRouterBaseURL),
 			)
 
-			bibliography, err := client.PrepareBibliography(filePath)
+			bibliography, err := client.PrepareBibliography(cmd.Context(), filePath)
 			if err != nil {
 				log.Fatalf("prepare bibliography error: %v", err)
 			}
 
-			entries, err := client.ExtractBib(bibliography)
+			entries, err := client.ExtractBib(cmd.Context(), bibliography)
 			if err != nil {
 				log.Fatalf("analyze error: %v", err)
 			}
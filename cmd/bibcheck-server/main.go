@@ -7,16 +7,38 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/sandialabs/bibcheck/config"
 	"github.com/sandialabs/bibcheck/internal/server"
+	"github.com/sandialabs/bibcheck/web/workflow"
 )
 
 func main() {
 	options := server.Options{}
+	var analyzeEnvKeys bool
 
 	flag.StringVar(&options.Addr, "addr", server.DefaultAddr, "Address for the static web UI")
 	flag.StringVar(&options.WebDir, "web-dir", server.DefaultWebDir, "Directory containing the static web UI")
 	flag.Int64Var(&options.FetchMaxBytes, "fetch-max-bytes", server.DefaultFetchMaxBytes, "Maximum bytes to read from /api/fetch upstream responses")
+	flag.Int64Var(&options.FetchCacheBytes, "fetch-cache-bytes", server.DefaultFetchCacheBytes, "Maximum bytes of /api/fetch responses to cache in memory; -1 disables the cache")
+	flag.StringVar(&options.FetchCacheDir, "fetch-cache-dir", "", "Directory to also cache /api/fetch responses in, across restarts")
+	flag.Int64Var(&options.FetchCacheDiskBytes, "fetch-cache-disk-bytes", server.DefaultFetchCacheDiskBytes, "Maximum bytes of /api/fetch responses to cache in --fetch-cache-dir")
+	flag.DurationVar(&options.FetchCacheTTL, "fetch-cache-ttl", server.DefaultFetchCacheTTL, "How long to cache /api/fetch responses without Cache-Control or Expires headers")
+	flag.Float64Var(&options.FetchRate, "fetch-rate", server.DefaultFetchRate, "Average /api/fetch requests per second allowed from each client; -1 removes the limit")
+	flag.IntVar(&options.FetchBurst, "fetch-burst", server.DefaultFetchBurst, "Number of /api/fetch requests a client may make at once before --fetch-rate applies")
+	flag.IntVar(&options.FetchHostConcurrency, "fetch-host-concurrency", server.DefaultFetchHostConcurrency, "Maximum concurrent /api/fetch upstream requests to each host; -1 removes the cap")
+	flag.Func("trusted-proxies", "Comma-separated addresses or CIDR ranges of reverse proxies whose X-Forwarded-For header identifies the client (repeatable)", appendList(&options.TrustedProxies))
+	flag.Func("fetch-allow", "Comma-separated hosts, addresses or CIDR ranges /api/fetch may reach although they are not public (repeatable)", appendList(&options.FetchAllow))
+	flag.Func("fetch-deny", "Comma-separated hosts, addresses or CIDR ranges /api/fetch must not reach (repeatable)", appendList(&options.FetchDeny))
+	flag.IntVar(&options.AnalyzeWorkers, "analyze-workers", 0, "Number of /api/analyze jobs to run at once; the analysis API is disabled unless this is positive")
+	flag.IntVar(&options.AnalyzeQueue, "analyze-queue", server.DefaultAnalyzeQueue, "Number of /api/analyze jobs that may wait for a worker")
+	flag.Int64Var(&options.AnalyzeMaxBytes, "analyze-max-bytes", server.DefaultAnalyzeMaxBytes, "Maximum bytes of a document sent to /api/analyze")
+	flag.DurationVar(&options.AnalyzeTimeout, "analyze-timeout", server.DefaultAnalyzeTimeout, "Maximum time an /api/analyze job may run")
+	flag.DurationVar(&options.AnalyzeRetention, "analyze-retention", server.DefaultAnalyzeRetention, "How long the result of a finished /api/analyze job is kept")
+	flag.Float64Var(&options.AnalyzeRate, "analyze-rate", server.DefaultAnalyzeRate, "Average /api/analyze and /api/jobs requests per second allowed from each client; -1 removes the limit")
+	flag.IntVar(&options.AnalyzeBurst, "analyze-burst", server.DefaultAnalyzeBurst, "Number of /api/analyze and /api/jobs requests a client may make at once before --analyze-rate applies")
+	flag.BoolVar(&analyzeEnvKeys, "analyze-env-keys", false, "Let /api/analyze requests without an API key use the SHIRTY_API_KEY and OPENROUTER_API_KEY environment variables")
 	flag.Parse()
 
 	if flag.NArg() != 0 {
@@ -24,8 +46,30 @@ func main() {
 		os.Exit(2)
 	}
 
+	// analysis requests without their own keys only spend those of the
+	// environment when the operator says so
+	settings := config.Runtime()
+	options.AnalyzeKeys = workflow.Keys{ShirtyBaseURL: settings.ShirtyBaseURL}
+	if analyzeEnvKeys {
+		options.AnalyzeKeys.ShirtyAPIKey = settings.ShirtyAPIKey
+		options.AnalyzeKeys.OpenRouterAPIKey = settings.OpenRouterAPIKey
+	}
+
 	if err := server.Run(options); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
+
+// appendList returns a flag function that adds the comma-separated values of
+// each use of the flag to list.
+func appendList(list *[]string) func(string) error {
+	return func(value string) error {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*list = append(*list, item)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/httpcache"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect, prune, or clear the HTTP response cache",
+}
+
+var cacheInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show where the HTTP response cache is and how much it holds",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings := config.Runtime()
+		cache := openCache(settings)
+
+		stats, err := cache.Stats()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Directory: %s\n", stats.Dir)
+		fmt.Printf("Enabled:   %t\n", settings.HTTPCacheEnable)
+		fmt.Printf("TTL:       %s\n", settings.HTTPCacheTTL)
+		fmt.Printf("Entries:   %d (%d expired)\n", stats.Entries, stats.Expired)
+		fmt.Printf("Size:      %d bytes\n", stats.Bytes)
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove expired responses from the HTTP response cache",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		removed, err := openCache(config.Runtime()).Prune()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Removed %d expired responses\n", removed)
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove every response from the HTTP response cache",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cache := openCache(config.Runtime())
+		if err := cache.Clear(); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Cleared %s\n", cache.Dir())
+	},
+}
+
+// openCache opens the configured cache even when caching is disabled, so that
+// an old cache can still be inspected and removed.
+func openCache(settings config.Settings) *httpcache.Cache {
+	dir, err := config.HTTPCacheDir(settings)
+	if err != nil {
+		log.Fatalf("resolve http cache dir: %v", err)
+	}
+	return httpcache.New(dir, settings.HTTPCacheTTL)
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheInfoCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
@@ -17,7 +17,7 @@ var doiCmd = &cobra.Command{
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 
-		exists, err := lookup.CheckDOI(args[0])
+		exists, err := lookup.CheckDOI(cmd.Context(), args[0])
 		if err != nil {
 			log.Fatalf("retrieve DOI error: %v", err)
 		}
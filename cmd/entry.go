@@ -5,8 +5,8 @@ package cmd
 import (
 	"fmt"
 	"log"
-	"strconv"
 
+	"github.com/sandialabs/bibcheck/bibliography"
 	"github.com/sandialabs/bibcheck/config"
 	"github.com/sandialabs/bibcheck/openrouter"
 	"github.com/sandialabs/bibcheck/shirty"
@@ -14,31 +14,38 @@ import (
 )
 
 var entryCmd = &cobra.Command{
-	Use:   "entry [file.pdf] [id]",
+	Use:   "entry [file.pdf|file.bib|file.bbl|file.tex] [id]",
 	Short: "Extract a bibliography entry",
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		settings := config.Runtime()
 
 		filePath := args[0]
-		id, err := strconv.ParseInt(args[1], 10, 32)
-		if err != nil {
-			log.Fatalf("expected id %s to be int", args[1])
-		}
+		id := bibliography.CleanID(args[1])
 
-		if settings.ShirtyAPIKey != "" && settings.ShirtyBaseURL != "" {
+		if isLocalPath(filePath) {
+			local, err := readLocalEntries(filePath, nil)
+			if err != nil {
+				log.Fatal(err)
+			}
+			entryText, err := local.extract(id)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Println(entryText)
+		} else if settings.ShirtyAPIKey != "" && settings.ShirtyBaseURL != "" {
 			shirtyClient := shirty.NewWorkflow(
 				settings.ShirtyAPIKey,
 				settings.ShirtyBaseURL,
 				shirty.WithModel(settings.ShirtyModel),
 			)
 
-			bibliography, err := shirtyClient.PrepareBibliography(filePath)
+			bib, err := shirtyClient.PrepareBibliography(cmd.Context(), filePath)
 			if err != nil {
 				log.Fatalf("prepare bibliography error: %v", err)
 			}
 
-			entryText, err := shirtyClient.EntryFromBibliography(bibliography, int(id))
+			entryText, err := shirtyClient.EntryFromBibliography(cmd.Context(), bib, id)
 			if err != nil {
 				log.Fatalf("openai error: %v", err)
 			}
@@ -50,12 +57,12 @@ var entryCmd = &cobra.Command{
 				openrouter.WithBaseURL(settings.OpenRouterBaseURL),
 			)
 
-			bibliography, err := client.PrepareBibliography(filePath)
+			bib, err := client.PrepareBibliography(cmd.Context(), filePath)
 			if err != nil {
 				log.Fatalf("prepare bibliography error: %v", err)
 			}
 
-			entryText, err := client.EntryFromBibliography(bibliography, int(id))
+			entryText, err := client.EntryFromBibliography(cmd.Context(), bib, id)
 			if err != nil {
 				log.Fatalf("analyze error: %v", err)
 			}
@@ -0,0 +1,321 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/version"
+)
+
+type htmlLink struct {
+	Label string
+	URL   string
+}
+
+type htmlSource struct {
+	Name   string
+	Status string
+	Detail string
+	URL    string
+}
+
+type htmlEntry struct {
+	ID         string
+	State      string
+	Title      string
+	Text       string
+	Links      []htmlLink
+	Comment    string
+	Match      string
+	Suggestion string
+	Notices    []string
+	Sources    []htmlSource
+}
+
+type htmlCount struct {
+	State string
+	Count int
+}
+
+type htmlReport struct {
+	Title      string
+	Version    string
+	Total      int
+	Shown      int
+	HiddenOK   int
+	Counts     []htmlCount
+	Entries    []htmlEntry
+	Duplicates []string
+	Orphans    string
+}
+
+// renderHTMLDocument renders a self-contained HTML report with a card for
+// each entry, like the web UI's, and the document's counts, duplicates and
+// orphans.
+func renderHTMLDocument(doc documentView, views []entryView, carelessHideOK bool, singleEntry bool) (string, error) {
+	report := htmlReport{
+		Title:    "bibcheck report",
+		Version:  version.String(),
+		Total:    doc.total,
+		Shown:    doc.shown,
+		HiddenOK: doc.hiddenOK,
+		Counts: []htmlCount{
+			{string(summaryStateRetracted), doc.retracted},
+			{string(summaryStateWrongDOI), doc.wrongDOI},
+			{string(summaryStateReview), doc.review},
+			{string(summaryStateError), doc.errors},
+			{string(summaryStateOK), doc.explicitOK},
+			{string(summaryStateUnknown), doc.unknown},
+		},
+	}
+	if doc.input != "" {
+		report.Title += ": " + filepath.Base(doc.input)
+	}
+	for _, view := range views {
+		if shouldHideEntry(view, carelessHideOK, singleEntry) {
+			continue
+		}
+		entry := htmlEntry{
+			ID:      view.id,
+			State:   string(view.summaryState),
+			Title:   htmlSummaryTitle(view.summaryState),
+			Text:    view.originalText,
+			Links:   htmlLinks(view.originalText),
+			Comment: view.summaryComment,
+		}
+		if entry.Comment == "" {
+			entry.Comment = htmlSummaryFallback(view.summaryState)
+		}
+		if view.match != nil && view.match.Explain() != view.summaryComment {
+			entry.Match = view.match.Explain()
+		}
+		if view.published != nil {
+			entry.Suggestion = view.published.Citation
+		}
+		for _, notice := range view.notices {
+			entry.Notices = append(entry.Notices, notice.String())
+		}
+		for _, source := range view.sources {
+			// like the web UI, leave out sources that did not apply
+			if source.status == "skipped" {
+				continue
+			}
+			entry.Sources = append(entry.Sources, htmlSource{
+				Name:   source.name,
+				Status: source.status,
+				Detail: source.detail,
+				URL:    source.url,
+			})
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+
+	for _, group := range doc.duplicates {
+		report.Duplicates = append(report.Duplicates, fmt.Sprintf("Entries %s: %s", strings.Join(group.IDs, ", "), strings.Join(group.Reasons, "; ")))
+	}
+	if doc.orphans != nil {
+		report.Orphans = renderOrphans(doc.orphans)
+	}
+
+	var b strings.Builder
+	if err := htmlTemplate.Execute(&b, report); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// htmlLinks links to the DOI, arXiv and OSTI records the entry text names.
+func htmlLinks(text string) []htmlLink {
+	links := []htmlLink{}
+	if doi := entries.ExtractDOI(text); doi != "" {
+		links = append(links, htmlLink{Label: "doi:" + doi, URL: "https://doi.org/" + doi})
+	}
+	if arxiv := entries.ExtractArxiv(text); arxiv != "" {
+		links = append(links, htmlLink{Label: "arXiv:" + strings.TrimPrefix(arxiv, "https://arxiv.org/abs/"), URL: arxiv})
+	}
+	if osti := entries.ExtractOSTI(text); osti != "" {
+		links = append(links, htmlLink{Label: "OSTI " + osti, URL: "https://www.osti.gov/biblio/" + osti})
+	}
+	return links
+}
+
+// htmlSummaryTitle matches the web UI's summary card titles.
+func htmlSummaryTitle(state summaryState) string {
+	switch state {
+	case summaryStateOK:
+		return "Looks okay"
+	case summaryStateReview:
+		return "Review suggested"
+	case summaryStateRetracted:
+		return "Retracted"
+	case summaryStateWrongDOI:
+		return "Wrong DOI"
+	case summaryStateError:
+		return "Error"
+	default:
+		return "Unknown"
+	}
+}
+
+// htmlSummaryFallback matches the web UI's text for summaries without a
+// comment.
+func htmlSummaryFallback(state summaryState) string {
+	switch state {
+	case summaryStateError:
+		return "One or more lookup methods returned an error."
+	case summaryStateOK:
+		return "No issues found."
+	case summaryStateReview:
+		return "No matching metadata found."
+	case summaryStateRetracted:
+		return "The cited work has been retracted or withdrawn."
+	case summaryStateWrongDOI:
+		return "The DOI exists but points to a different work."
+	default:
+		return "Metadata was found but not compared."
+	}
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"label": func(status string) string { return strings.ReplaceAll(status, "-", " ") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+` + htmlStyle + `
+</style>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+<section class="status-band">
+  <div><strong>{{.Total}}</strong> entries analyzed</div>
+  <div><strong>{{.Shown}}</strong> shown{{if .HiddenOK}} ({{.HiddenOK}} hidden as okay){{end}}</div>
+  {{range .Counts}}<div class="count count-{{.State}}"><strong>{{.Count}}</strong> {{label .State}}</div>
+  {{end}}
+</section>
+{{range .Entries}}
+<article class="entry-card" id="entry-{{.ID}}">
+  <header>
+    <h2>Entry {{.ID}}</h2>
+    <span class="status {{.State}}">{{label .State}}</span>
+  </header>
+  <div class="entry-columns">
+    <div class="entry-pane">
+      <h3>Entry</h3>
+      <pre>{{.Text}}</pre>
+      {{if .Links}}<p class="links">{{range .Links}}<a href="{{.URL}}">{{.Label}}</a> {{end}}</p>{{end}}
+    </div>
+    <div class="entry-pane">
+      <h3>Lookups</h3>
+      {{if .Sources}}<div class="lookup-cards">
+      {{range .Sources}}<div class="lookup-card lookup-{{.Status}}">
+        <div class="lookup-card-header">
+          <strong>{{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</strong>
+          <span class="lookup-status">{{label .Status}}</span>
+        </div>
+        {{if .Detail}}<pre>{{.Detail}}</pre>{{end}}
+      </div>
+      {{end}}</div>{{else}}<div class="empty-card">No relevant lookup results.</div>{{end}}
+    </div>
+    <div class="entry-pane">
+      <h3>Analysis summary</h3>
+      <div class="summary-card summary-{{.State}}">
+        <div class="summary-card-header"><strong>{{.Title}}</strong></div>
+        {{if .Comment}}<pre>{{.Comment}}</pre>{{end}}
+        {{if .Match}}<pre class="match-breakdown">{{.Match}}</pre>{{end}}
+        {{if .Notices}}<ul>{{range .Notices}}<li>{{.}}</li>{{end}}</ul>{{end}}
+        {{if .Suggestion}}<p class="suggestion">Cite the published version: {{.Suggestion}}</p>{{end}}
+      </div>
+    </div>
+  </div>
+</article>
+{{else}}
+<p class="empty-card">No entries to display.</p>
+{{end}}
+{{if .Duplicates}}
+<section class="entry-card document-card">
+  <header><h2>Duplicate entries</h2></header>
+  <div class="entry-pane"><ul>{{range .Duplicates}}<li>{{.}}</li>{{end}}</ul></div>
+</section>
+{{end}}
+{{if .Orphans}}
+<section class="entry-card document-card">
+  <header><h2>Orphans</h2></header>
+  <div class="entry-pane"><pre>{{.Orphans}}</pre></div>
+</section>
+{{end}}
+<footer>Generated by bibcheck {{.Version}}</footer>
+</main>
+</body>
+</html>
+`))
+
+// htmlStyle is a condensed copy of the web UI's stylesheet, inlined so the
+// report has no external assets.
+const htmlStyle = `:root {
+  --blue: #0076a9;
+  --dark-blue: #003359;
+  --teal: #008e74;
+  --gray-25: #f9f9fa;
+  --gray-50: #f2f4f6;
+  --gray-100: #e5e8ec;
+  --gray-200: #cbd2d9;
+  --gray-700: #586370;
+  --gray-900: #323940;
+  --red: #ad0000;
+  --amber: #b36b00;
+  --amber-50: #fff8eb;
+  font-family: "Open Sans", "Segoe UI", Tahoma, sans-serif;
+  background: var(--gray-50);
+  color: var(--gray-900);
+}
+* { box-sizing: border-box; }
+body { margin: 0; }
+main { display: grid; gap: 16px; max-width: 1400px; margin: 0 auto; padding: 24px; }
+h1 { margin: 0; color: var(--dark-blue); font-size: 1.8rem; }
+h2, h3, p, ul { margin: 0; }
+a { color: var(--blue); }
+pre { margin: 0; white-space: pre-wrap; overflow-wrap: anywhere; font-size: 0.85rem; }
+ul { padding-left: 18px; font-size: 0.9rem; }
+.status-band { display: flex; flex-wrap: wrap; gap: 18px; padding: 14px; border: 1px solid var(--gray-100); border-left: 5px solid var(--blue); background: #ffffff; }
+.status-band strong { color: var(--dark-blue); }
+.count-retracted strong, .count-wrong-doi strong, .count-error strong { color: var(--red); }
+.count-review strong { color: var(--amber); }
+.count-ok strong { color: var(--teal); }
+.entry-card { border: 1px solid var(--gray-100); border-radius: 4px; background: #ffffff; }
+.entry-card > header { display: flex; justify-content: space-between; gap: 12px; padding: 14px; border-bottom: 1px solid var(--gray-100); border-top: 4px solid var(--blue); }
+.entry-card h2 { color: var(--dark-blue); font-size: 1rem; }
+.status { font-size: 0.85rem; text-transform: capitalize; color: var(--gray-700); }
+.status.ok { color: var(--teal); }
+.status.error, .status.retracted, .status.wrong-doi { color: var(--red); }
+.status.review { color: var(--amber); }
+.entry-columns { display: grid; grid-template-columns: minmax(0, 1fr) minmax(260px, 1.25fr) minmax(240px, 0.9fr); gap: 1px; background: var(--gray-100); }
+.entry-pane { display: grid; gap: 8px; align-content: start; min-width: 0; padding: 14px; background: #ffffff; }
+.entry-pane h3 { font-size: 0.85rem; color: var(--gray-700); text-transform: uppercase; }
+.links { display: flex; flex-wrap: wrap; gap: 10px; font-size: 0.85rem; }
+.lookup-cards { display: grid; gap: 10px; }
+.lookup-card, .summary-card, .empty-card { display: grid; gap: 8px; min-width: 0; border: 1px solid var(--gray-100); border-left: 4px solid var(--blue); border-radius: 4px; padding: 10px; background: #ffffff; }
+.lookup-card-header, .summary-card-header { display: flex; align-items: start; justify-content: space-between; gap: 10px; }
+.lookup-card strong, .summary-card strong { color: var(--dark-blue); font-size: 0.92rem; }
+.lookup-status { flex: 0 0 auto; border: 1px solid currentColor; border-radius: 999px; padding: 2px 7px; color: var(--gray-700); font-size: 0.72rem; text-transform: capitalize; }
+.lookup-error, .lookup-flagged, .lookup-wrong-work, .summary-error, .summary-retracted, .summary-wrong-doi { border-color: #e06666; border-left-color: var(--red); background: #fcf2f2; }
+.lookup-no-match, .lookup-not-found, .summary-review { border-color: #e8bd78; border-left-color: var(--amber); background: var(--amber-50); }
+.lookup-found, .lookup-matched, .summary-ok { border-color: #8bc8bd; border-left-color: var(--teal); background: #f2f9f8; }
+.summary-unknown, .empty-card { border-color: var(--gray-200); background: var(--gray-25); color: var(--gray-700); }
+.lookup-error .lookup-status, .lookup-flagged .lookup-status, .lookup-wrong-work .lookup-status { color: var(--red); }
+.lookup-found .lookup-status, .lookup-matched .lookup-status { color: var(--teal); }
+.lookup-no-match .lookup-status, .lookup-not-found .lookup-status { color: var(--amber); }
+.match-breakdown, .suggestion { font-size: 0.85rem; }
+footer { color: var(--gray-700); font-size: 0.8rem; }
+@media (max-width: 900px) { .entry-columns { grid-template-columns: 1fr; } }
+@media print { body { background: #ffffff; } .entry-card { break-inside: avoid; } }
+`
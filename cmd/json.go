@@ -2,71 +2,193 @@
 // SPDX-License-Identifier: BSD-3-Clause
 package cmd
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/sandialabs/bibcheck/duplicates"
+	"github.com/sandialabs/bibcheck/lookup"
+	"github.com/sandialabs/bibcheck/match"
+	"github.com/sandialabs/bibcheck/retraction"
+	"github.com/sandialabs/bibcheck/web/workflow"
+)
 
 type jsonSourceView struct {
 	Name   string `json:"name"`
 	Status string `json:"status"`
 	Detail string `json:"detail"`
+	URL    string `json:"url,omitempty"`
+}
+
+type jsonNotice struct {
+	Kind   string `json:"kind"`
+	DOI    string `json:"doi,omitempty"`
+	Date   string `json:"date,omitempty"`
+	Source string `json:"source"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type jsonMatchField struct {
+	Field   string  `json:"field"`
+	Verdict string  `json:"verdict"`
+	Score   float64 `json:"score"`
+	Detail  string  `json:"detail,omitempty"`
+}
+
+type jsonMatchView struct {
+	Source  string           `json:"source"`
+	Score   float64          `json:"score"`
+	Verdict string           `json:"verdict"`
+	Fields  []jsonMatchField `json:"fields"`
+}
+
+type jsonPublishedVersion struct {
+	DOI      string `json:"doi,omitempty"`
+	Citation string `json:"citation"`
+	Via      string `json:"via"`
 }
 
 type jsonEntryView struct {
-	Number         int              `json:"number"`
-	OriginalText   string           `json:"original_text"`
-	SummaryState   summaryState     `json:"summary_state"`
-	SummaryComment string           `json:"summary_comment"`
-	Sources        []jsonSourceView `json:"sources"`
+	ID             string                `json:"id"`
+	OriginalText   string                `json:"original_text"`
+	SummaryState   summaryState          `json:"summary_state"`
+	SummaryComment string                `json:"summary_comment"`
+	Match          *jsonMatchView        `json:"match,omitempty"`
+	Notices        []jsonNotice          `json:"notices,omitempty"`
+	Published      *jsonPublishedVersion `json:"published_version,omitempty"`
+	Sources        []jsonSourceView      `json:"sources"`
 }
 
 type jsonSummaryCounts struct {
-	OK      int `json:"ok"`
-	Review  int `json:"review"`
-	Error   int `json:"error"`
-	Unknown int `json:"unknown"`
+	OK        int `json:"ok"`
+	Retracted int `json:"retracted"`
+	Review    int `json:"review"`
+	Error     int `json:"error"`
+	Unknown   int `json:"unknown"`
+	WrongDOI  int `json:"wrong_doi"`
+}
+
+type jsonDuplicateGroup struct {
+	Entries []string `json:"entries"`
+	Reasons []string `json:"reasons"`
+}
+
+type jsonUnresolvedCitation struct {
+	Ref      string `json:"ref"`
+	Citation string `json:"citation"`
+	Style    string `json:"style"`
+	Count    int    `json:"count"`
+}
+
+type jsonOrphans struct {
+	Checked      bool                     `json:"checked"`
+	Note         string                   `json:"note,omitempty"`
+	CitedEntries int                      `json:"cited_entries"`
+	Uncited      []string                 `json:"uncited"`
+	Unresolved   []jsonUnresolvedCitation `json:"unresolved"`
 }
 
 type jsonDocumentView struct {
-	Format          string            `json:"format"`
-	TotalEntries    int               `json:"total_entries"`
-	ShownEntries    int               `json:"shown_entries"`
-	HiddenOKEntries int               `json:"hidden_ok_entries"`
-	SummaryCounts   jsonSummaryCounts `json:"summary_counts"`
-	Entries         []jsonEntryView   `json:"entries"`
+	Format          string               `json:"format"`
+	TotalEntries    int                  `json:"total_entries"`
+	ShownEntries    int                  `json:"shown_entries"`
+	HiddenOKEntries int                  `json:"hidden_ok_entries"`
+	SummaryCounts   jsonSummaryCounts    `json:"summary_counts"`
+	Entries         []jsonEntryView      `json:"entries"`
+	Duplicates      []jsonDuplicateGroup `json:"duplicates"`
+	Orphans         *jsonOrphans         `json:"orphans,omitempty"`
 }
 
 func renderJSONDocument(doc documentView, views []entryView, carelessHideOK bool, singleEntry bool) (string, error) {
+	out, err := json.MarshalIndent(toJSONDocument(outputFormatJSON, doc, views, carelessHideOK, singleEntry), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
+
+func toJSONDocument(format outputFormat, doc documentView, views []entryView, carelessHideOK bool, singleEntry bool) jsonDocumentView {
 	payload := jsonDocumentView{
-		Format:          string(outputFormatJSON),
+		Format:          string(format),
 		TotalEntries:    doc.total,
 		ShownEntries:    doc.shown,
 		HiddenOKEntries: doc.hiddenOK,
 		SummaryCounts: jsonSummaryCounts{
-			OK:      doc.explicitOK,
-			Review:  doc.review,
-			Error:   doc.errors,
-			Unknown: doc.unknown,
+			OK:        doc.explicitOK,
+			Retracted: doc.retracted,
+			Review:    doc.review,
+			Error:     doc.errors,
+			Unknown:   doc.unknown,
+			WrongDOI:  doc.wrongDOI,
 		},
-		Entries: []jsonEntryView{},
+		Entries:    []jsonEntryView{},
+		Duplicates: toJSONDuplicates(doc.duplicates),
+		Orphans:    toJSONOrphans(doc.orphans),
 	}
 
 	for _, view := range views {
 		if shouldHideEntry(view, carelessHideOK, singleEntry) {
 			continue
 		}
-		payload.Entries = append(payload.Entries, jsonEntryView{
-			Number:         view.number,
-			OriginalText:   view.originalText,
-			SummaryState:   view.summaryState,
-			SummaryComment: view.summaryComment,
-			Sources:        toJSONSources(view.sources),
+		payload.Entries = append(payload.Entries, toJSONEntry(view))
+	}
+	return payload
+}
+
+func toJSONEntry(view entryView) jsonEntryView {
+	return jsonEntryView{
+		ID:             view.id,
+		OriginalText:   view.originalText,
+		SummaryState:   view.summaryState,
+		SummaryComment: view.summaryComment,
+		Match:          toJSONMatch(view.match),
+		Notices:        toJSONNotices(view.notices),
+		Published:      toJSONPublished(view.published),
+		Sources:        toJSONSources(view.sources),
+	}
+}
+
+func toJSONDuplicates(groups []duplicates.Group) []jsonDuplicateGroup {
+	out := make([]jsonDuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		out = append(out, jsonDuplicateGroup{
+			Entries: group.IDs,
+			Reasons: group.Reasons,
 		})
 	}
+	return out
+}
 
-	out, err := json.MarshalIndent(payload, "", "  ")
-	if err != nil {
-		return "", err
+func toJSONOrphans(view *workflow.OrphansView) *jsonOrphans {
+	if view == nil {
+		return nil
+	}
+	out := &jsonOrphans{
+		Checked:      view.Note == "",
+		Note:         view.Note,
+		CitedEntries: view.Cited,
+		Uncited:      append([]string{}, view.Uncited...),
+		Unresolved:   make([]jsonUnresolvedCitation, 0, len(view.Unresolved)),
+	}
+	for _, cite := range view.Unresolved {
+		out.Unresolved = append(out.Unresolved, jsonUnresolvedCitation{
+			Ref:      cite.Ref,
+			Citation: cite.Citation,
+			Style:    cite.Style,
+			Count:    cite.Count,
+		})
+	}
+	return out
+}
+
+func toJSONPublished(published *lookup.PublishedResult) *jsonPublishedVersion {
+	if published == nil {
+		return nil
+	}
+	return &jsonPublishedVersion{
+		DOI:      published.DOI,
+		Citation: published.Citation,
+		Via:      published.Via,
 	}
-	return string(out) + "\n", nil
 }
 
 func toJSONSources(sources []sourceView) []jsonSourceView {
@@ -76,6 +198,45 @@ func toJSONSources(sources []sourceView) []jsonSourceView {
 			Name:   source.name,
 			Status: source.status,
 			Detail: source.detail,
+			URL:    source.url,
+		})
+	}
+	return out
+}
+
+func toJSONNotices(notices []retraction.Notice) []jsonNotice {
+	if len(notices) == 0 {
+		return nil
+	}
+	out := make([]jsonNotice, 0, len(notices))
+	for _, notice := range notices {
+		out = append(out, jsonNotice{
+			Kind:   string(notice.Kind),
+			DOI:    notice.DOI,
+			Date:   notice.Date,
+			Source: notice.Source,
+			Reason: notice.Reason,
+		})
+	}
+	return out
+}
+
+func toJSONMatch(m *match.Result) *jsonMatchView {
+	if m == nil {
+		return nil
+	}
+	out := &jsonMatchView{
+		Source:  m.Source,
+		Score:   m.Score,
+		Verdict: string(m.Verdict),
+		Fields:  make([]jsonMatchField, 0, len(m.Fields)),
+	}
+	for _, f := range m.Fields {
+		out.Fields = append(out.Fields, jsonMatchField{
+			Field:   f.Name,
+			Verdict: string(f.Verdict),
+			Score:   f.Score,
+			Detail:  f.Detail,
 		})
 	}
 	return out
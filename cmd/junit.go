@@ -0,0 +1,161 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitProblem `xml:"skipped,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out,omitempty"`
+}
+
+// junitProblem and junitOutput keep multi-line text readable as CDATA.
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",cdata"`
+}
+
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+// renderJUnitDocument reports each entry as a test case, failing entries
+// that need review, are retracted or have a wrong DOI, and erroring entries
+// that could not be checked. Duplicate entries and orphans are test cases of
+// their own.
+func renderJUnitDocument(doc documentView, views []entryView, carelessHideOK bool, singleEntry bool) (string, error) {
+	suite := junitTestSuite{Name: doc.input}
+	if suite.Name == "" {
+		suite.Name = "bibliography"
+	}
+
+	for _, view := range views {
+		if shouldHideEntry(view, carelessHideOK, singleEntry) {
+			continue
+		}
+		tc := junitTestCase{
+			Name:      "Entry " + view.id,
+			ClassName: "bibcheck.entries",
+			File:      doc.input,
+			Line:      doc.lines[view.id],
+			SystemOut: &junitOutput{Text: plainEntryDetails(view)},
+		}
+		problem := &junitProblem{Message: string(view.summaryState), Type: string(view.summaryState), Text: view.summaryComment}
+		if problem.Text == "" {
+			problem.Text = view.originalText
+		}
+		switch view.summaryState {
+		case summaryStateOK:
+		case summaryStateError:
+			tc.Error = problem
+		case summaryStateUnknown:
+			tc.Skipped = problem
+		default:
+			tc.Failure = problem
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if !singleEntry {
+		tc := junitTestCase{Name: "Duplicate entries", ClassName: "bibcheck.document", File: doc.input}
+		if len(doc.duplicates) > 0 {
+			tc.Failure = &junitProblem{
+				Message: "entries cite the same work",
+				Type:    ruleDuplicateEntry,
+				Text:    renderDuplicates(doc.duplicates),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	if doc.orphans != nil {
+		tc := junitTestCase{Name: "Orphans", ClassName: "bibcheck.document", File: doc.input}
+		switch {
+		case doc.orphans.Note != "":
+			tc.Skipped = &junitProblem{Message: doc.orphans.Note}
+		case len(doc.orphans.Uncited) > 0 || len(doc.orphans.Unresolved) > 0:
+			tc.Failure = &junitProblem{
+				Message: fmt.Sprintf("uncited entries: %d, citations with no entry: %d", len(doc.orphans.Uncited), len(doc.orphans.Unresolved)),
+				Type:    "orphans",
+				Text:    renderOrphans(doc.orphans),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	for _, tc := range suite.TestCases {
+		suite.Tests++
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Error != nil:
+			suite.Errors++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+	payload := junitTestSuites{
+		Name:     "bibcheck",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+	}
+	out, err := xml.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(out) + "\n", nil
+}
+
+// plainEntryDetails is the text rendering of an entry without colors, for
+// CI logs.
+func plainEntryDetails(view entryView) string {
+	var b strings.Builder
+	b.WriteString(renderLabeledBlock("Original", view.originalText))
+	if view.match != nil {
+		b.WriteString(renderLabeledBlock("Match", view.match.Explain()))
+	}
+	if view.published != nil {
+		b.WriteString(renderLabeledBlock("Suggestion", "cite the published version: "+view.published.Citation))
+	}
+	b.WriteString("Lookups:\n")
+	for _, source := range view.sources {
+		fmt.Fprintf(&b, "  %s: %s", source.name, source.status)
+		if source.detail != "" {
+			fmt.Fprintf(&b, " (%s)", source.detail)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -13,7 +13,7 @@ import (
 )
 
 var listEntriesCmd = &cobra.Command{
-	Use:   "list-entries [file.pdf]",
+	Use:   "list-entries [file.pdf|file.bib|file.bbl|file.tex]",
 	Short: "List bibliography entries",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -21,7 +21,15 @@ var listEntriesCmd = &cobra.Command{
 
 		filePath := args[0]
 
-		if settings.ShirtyAPIKey != "" && settings.ShirtyBaseURL != "" {
+		if isLocalPath(filePath) {
+			local, err := readLocalEntries(filePath, nil)
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, key := range local.keys {
+				fmt.Println(key)
+			}
+		} else if settings.ShirtyAPIKey != "" && settings.ShirtyBaseURL != "" {
 
 			shirtyWorkflow := shirty.NewWorkflow(
 				settings.ShirtyAPIKey,
@@ -29,30 +37,17 @@ var listEntriesCmd = &cobra.Command{
 				shirty.WithModel(settings.ShirtyModel),
 			)
 
-			bibliography, err := shirtyWorkflow.PrepareBibliography(filePath)
+			bibliography, err := shirtyWorkflow.PrepareBibliography(cmd.Context(), filePath)
 			if err != nil {
 				log.Fatalf("prepare bibliography error: %v", err)
 			}
 
-			format, err := shirtyWorkflow.BibIdFormat(bibliography)
+			ids, err := shirtyWorkflow.BibliographyEntryIDs(cmd.Context(), bibliography)
 			if err != nil {
-				log.Fatalf("error getting bib id format: %v", err)
+				log.Fatalf("error listing entries: %v", err)
 			}
-			switch format {
-			case shirty.BibIdFormatNumeric:
-
-				numEntries, err := shirtyWorkflow.NumBibEntries(bibliography)
-				if err != nil {
-					log.Fatalf("error getting number of entries: %v", err)
-				}
-				for e := range numEntries {
-					fmt.Println(e + 1)
-				}
-
-			case shirty.BibIdFormatAlphanumeric:
-				log.Fatal("unsupported format:", format)
-			default:
-				log.Fatal("unexpected format:", format)
+			for _, id := range ids {
+				fmt.Println(id)
 			}
 
 		} else if settings.OpenRouterAPIKey != "" && settings.OpenRouterBaseURL != "" {
@@ -61,29 +56,17 @@ var listEntriesCmd = &cobra.Command{
 				openrouter.WithBaseURL(settings.OpenRouterBaseURL),
 			)
 
-			bibliography, err := client.PrepareBibliography(filePath)
+			bibliography, err := client.PrepareBibliography(cmd.Context(), filePath)
 			if err != nil {
 				log.Fatalf("prepare bibliography error: %v", err)
 			}
 
-			format, err := client.BibIdFormat(bibliography)
+			ids, err := client.BibliographyEntryIDs(cmd.Context(), bibliography)
 			if err != nil {
-				log.Fatalf("error getting bib id format: %v", err)
+				log.Fatalf("error listing entries: %v", err)
 			}
-			switch format {
-			case openrouter.BibIdFormatNumeric:
-				numEntries, err := client.NumBibliographyEntries(bibliography)
-				if err != nil {
-					log.Fatalf("error getting number of entries: %v", err)
-				}
-				for e := range numEntries {
-					fmt.Println(e + 1)
-				}
-
-			case openrouter.BibIdFormatAlphanumeric:
-				log.Fatal("unsupported format:", format)
-			default:
-				log.Fatal("unexpected format:", format)
+			for _, id := range ids {
+				fmt.Println(id)
 			}
 
 		} else {
@@ -0,0 +1,109 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/bibtex"
+	"github.com/sandialabs/bibcheck/citations"
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/latex"
+)
+
+// localEntries are bibliography entries read from a BibTeX or LaTeX file
+// rather than extracted from a PDF by an LLM.
+type localEntries struct {
+	keys   []string
+	texts  []string
+	parser entries.Parser
+	// cites are the \cite commands of the LaTeX document, or nil if there
+	// is no document body to read.
+	cites []citations.Citation
+	// lines are the 1-based lines where the entries start, by key.
+	lines map[string]int
+}
+
+// isLocalPath reports whether path is a bibliography source bibcheck reads
+// without an LLM.
+func isLocalPath(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".bib", ".bbl", ".tex":
+		return true
+	}
+	return false
+}
+
+// readLocalEntries reads the entries of path. BibTeX fields answer parser
+// questions directly; anything else goes to fallback.
+func readLocalEntries(path string, fallback entries.Parser) (*localEntries, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	local := &localEntries{parser: fallback}
+	if strings.EqualFold(filepath.Ext(path), ".bib") {
+		list, err := bibtex.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse bibtex: %w", err)
+		}
+		for _, e := range list {
+			local.keys = append(local.keys, e.Key)
+			local.texts = append(local.texts, e.Text())
+		}
+		local.parser = bibtex.NewParser(list, fallback)
+	} else {
+		for _, item := range latex.BibItems(string(data)) {
+			if item.Key != "" && slices.Contains(local.keys, item.Key) {
+				return nil, fmt.Errorf("repeated \\bibitem key %q in %s", item.Key, path)
+			}
+			local.keys = append(local.keys, item.Key)
+			local.texts = append(local.texts, item.Text)
+		}
+		local.cites = readLaTeXCitations(path, data)
+	}
+
+	if len(local.texts) == 0 {
+		return nil, fmt.Errorf("no bibliography entries in %s", path)
+	}
+	local.lines = keyLines(string(data), local.keys)
+	return local, nil
+}
+
+// keyLines finds the line where each key's BibTeX entry or \bibitem starts.
+func keyLines(src string, keys []string) map[string]int {
+	lines := map[string]int{}
+	for _, key := range keys {
+		re := regexp.MustCompile(`(?:@\w+\s*[{(]|\\bibitem\s*(?:\[[^\]]*\])?\s*\{)\s*` + regexp.QuoteMeta(key) + `\s*[,}]`)
+		if loc := re.FindStringIndex(src); loc != nil {
+			lines[key] = strings.Count(src[:loc[0]], "\n") + 1
+		}
+	}
+	return lines
+}
+
+// readLaTeXCitations returns the citations of a .tex file, or of the .tex
+// file next to a .bbl file that BibTeX generated from it.
+func readLaTeXCitations(path string, data []byte) []citations.Citation {
+	if strings.EqualFold(filepath.Ext(path), ".bbl") {
+		var err error
+		if data, err = os.ReadFile(strings.TrimSuffix(path, filepath.Ext(path)) + ".tex"); err != nil {
+			return nil
+		}
+	}
+	return citations.FindLaTeX(string(data))
+}
+
+func (l *localEntries) extract(key string) (string, error) {
+	i := slices.Index(l.keys, key)
+	if i < 0 {
+		return "", fmt.Errorf("no entry with key %q", key)
+	}
+	return l.texts[i], nil
+}
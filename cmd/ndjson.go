@@ -0,0 +1,120 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+
+	analysisrunner "github.com/sandialabs/bibcheck/analysis"
+)
+
+// ndjsonEvent is one line of --format ndjson output. Event is "start" when the
+// run begins, "stage" when a stage of entry ID becomes active, completes or
+// fails, "entry" when entry ID has finished, "done" with the whole document
+// at the end, or "error" if the run stops early.
+type ndjsonEvent struct {
+	Event     string            `json:"event"`
+	Completed int               `json:"completed"`
+	Total     int               `json:"total_entries"`
+	ID        string            `json:"id,omitempty"`
+	Stage     string            `json:"stage,omitempty"`
+	Status    string            `json:"status,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Entry     *jsonEntryView    `json:"entry,omitempty"`
+	Document  *jsonDocumentView `json:"document,omitempty"`
+}
+
+// ndjsonWriter writes the events of a run as they happen, so that tools can
+// follow a long run and keep the entries that finished if it is interrupted.
+// A nil *ndjsonWriter writes nothing.
+type ndjsonWriter struct {
+	enc            *json.Encoder
+	total          int
+	completed      int
+	carelessHideOK bool
+	singleEntry    bool
+	started        bool
+	// stages holds the last status written for each stage of each entry,
+	// and finished the entries already written.
+	stages   map[string][3]analysisrunner.Status
+	finished map[string]bool
+	// err is the first write error, after which nothing more is written.
+	err error
+}
+
+func newNDJSONWriter(w io.Writer, total int, carelessHideOK bool, singleEntry bool) *ndjsonWriter {
+	return &ndjsonWriter{
+		enc:            json.NewEncoder(w),
+		total:          total,
+		carelessHideOK: carelessHideOK,
+		singleEntry:    singleEntry,
+		stages:         map[string][3]analysisrunner.Status{},
+		finished:       map[string]bool{},
+	}
+}
+
+// progress writes the changes since the previous snapshot.
+func (n *ndjsonWriter) progress(snapshot analysisrunner.Snapshot) {
+	if n == nil {
+		return
+	}
+	n.completed = snapshot.Completed
+	if !n.started {
+		n.started = true
+		n.write(ndjsonEvent{Event: "start"})
+	}
+	stageNames := [3]analysisrunner.Stage{analysisrunner.StageExtraction, analysisrunner.StageLookup, analysisrunner.StageSummary}
+	for _, entry := range snapshot.Entries {
+		statuses := [3]analysisrunner.Status{entry.ExtractionStatus, entry.LookupStatus, entry.SummaryStatus}
+		last := n.stages[entry.ID]
+		for i, status := range statuses {
+			if status == last[i] || (status != analysisrunner.StatusActive && status != analysisrunner.StatusCompleted && status != analysisrunner.StatusError) {
+				continue
+			}
+			n.write(ndjsonEvent{Event: "stage", ID: entry.ID, Stage: string(stageNames[i]), Status: string(status)})
+		}
+		n.stages[entry.ID] = statuses
+
+		if !entry.Terminal() || n.finished[entry.ID] {
+			continue
+		}
+		n.finished[entry.ID] = true
+		event := ndjsonEvent{Event: "entry", ID: entry.ID}
+		view := runEntryView(entry)
+		if entry.Result == nil {
+			event.Error = view.summaryComment
+		} else {
+			if shouldHideEntry(view, n.carelessHideOK, n.singleEntry) {
+				continue
+			}
+			payload := toJSONEntry(view)
+			event.Entry = &payload
+		}
+		n.write(event)
+	}
+}
+
+// fail writes that the run stopped early with err.
+func (n *ndjsonWriter) fail(err error) {
+	if n == nil {
+		return
+	}
+	n.write(ndjsonEvent{Event: "error", Error: err.Error()})
+}
+
+// done writes the document, and returns the first error writing any event.
+func (n *ndjsonWriter) done(doc documentView, views []entryView) error {
+	payload := toJSONDocument(outputFormatNDJSON, doc, views, n.carelessHideOK, n.singleEntry)
+	n.write(ndjsonEvent{Event: "done", Document: &payload})
+	return n.err
+}
+
+func (n *ndjsonWriter) write(event ndjsonEvent) {
+	if n.err != nil {
+		return
+	}
+	event.Completed = n.completed
+	event.Total = n.total
+	n.err = n.enc.Encode(event)
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	analysisrunner "github.com/sandialabs/bibcheck/analysis"
+	"github.com/sandialabs/bibcheck/citations"
+	"github.com/sandialabs/bibcheck/web/workflow"
+)
+
+// buildOrphansView cross-checks the document's in-text citations against the
+// entries of the run.
+func buildOrphansView(ctx context.Context, findCitations func(context.Context) ([]citations.Citation, error), runEntries []analysisrunner.Entry) *workflow.OrphansView {
+	if findCitations == nil {
+		return &workflow.OrphansView{Note: "No document body to read citations from.", Entries: len(runEntries)}
+	}
+	cites, err := findCitations(ctx)
+	if err != nil {
+		log.Printf("in-text citations error: %v", err)
+		return &workflow.OrphansView{Note: fmt.Sprintf("read document body: %v", err), Entries: len(runEntries)}
+	}
+
+	entries := make([]workflow.EntryState, len(runEntries))
+	for i, entry := range runEntries {
+		entries[i] = workflow.EntryState{ID: entry.ID, Text: entry.Text}
+	}
+	return workflow.BuildOrphansView(entries, cites)
+}
+
+func renderOrphans(view *workflow.OrphansView) string {
+	var b strings.Builder
+	if view.Note != "" {
+		fmt.Fprintf(&b, "Orphans: not checked (%s)\n", strings.TrimSuffix(view.Note, "."))
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Orphans: %d of %d entries cited in the text\n", view.Cited, view.Entries)
+	if len(view.Uncited) > 0 {
+		fmt.Fprintf(&b, "  Uncited entries: %s\n", strings.Join(view.Uncited, ", "))
+	}
+	if len(view.Unresolved) > 0 {
+		b.WriteString("  Citations with no entry:\n")
+		for _, cite := range view.Unresolved {
+			fmt.Fprintf(&b, "    %s, cited as %s", cite.Ref, cite.Citation)
+			if cite.Count > 1 {
+				fmt.Fprintf(&b, " (%d times)", cite.Count)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
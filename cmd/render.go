@@ -7,37 +7,51 @@ import (
 	"strings"
 
 	prettytext "github.com/jedib0t/go-pretty/v6/text"
+	"github.com/sandialabs/bibcheck/duplicates"
 	"github.com/sandialabs/bibcheck/lookup"
+	"github.com/sandialabs/bibcheck/match"
+	"github.com/sandialabs/bibcheck/retraction"
+	"github.com/sandialabs/bibcheck/web/workflow"
 )
 
 type summaryState string
 
 const (
-	summaryStateError   summaryState = "error"
-	summaryStateOK      summaryState = "ok"
-	summaryStateReview  summaryState = "review"
-	summaryStateUnknown summaryState = "unknown"
+	summaryStateError     summaryState = "error"
+	summaryStateOK        summaryState = "ok"
+	summaryStateRetracted summaryState = "retracted"
+	summaryStateReview    summaryState = "review"
+	summaryStateUnknown   summaryState = "unknown"
+	summaryStateWrongDOI  summaryState = "wrong-doi"
 )
 
 type summaryOutcome struct {
 	mismatch bool
 	comment  string
 	err      error
+	match    *match.Result
 }
 
 type sourceView struct {
 	name   string
 	status string
 	detail string
+	// url links to the record the source found, if any.
+	url string
 }
 
 // entryView is where rlookup.Result data gets translated into a display-oriented form.
 type entryView struct {
-	number         int
+	id             string
 	originalText   string
 	summaryState   summaryState
 	summaryComment string
-	sources        []sourceView
+	match          *match.Result
+	notices        []retraction.Notice
+	// published is the published version of the cited preprint or report,
+	// if the entry should cite it instead.
+	published *lookup.PublishedResult
+	sources   []sourceView
 }
 
 type documentView struct {
@@ -47,21 +61,38 @@ type documentView struct {
 	review     int
 	errors     int
 	explicitOK int
+	retracted  int
 	unknown    int
+	wrongDOI   int
+	duplicates []duplicates.Group
+	orphans    *workflow.OrphansView
+	// input is the analyzed file, and lines the lines its entries start on
+	// when it is a BibTeX or LaTeX file.
+	input string
+	lines map[string]int
 }
 
-func buildEntryView(number int, lr *lookup.Result, outcome summaryOutcome) entryView {
+func buildEntryView(id string, lr *lookup.Result, outcome summaryOutcome) entryView {
 	view := entryView{
-		number:       number,
+		id:           id,
 		originalText: lr.Text,
-		sources: []sourceView{
-			buildDOISourceView(lr),
-			buildOSTISourceView(lr),
-			buildArxivSourceView(lr),
-			buildElsevierSourceView(lr),
-			buildCrossrefSourceView(lr),
-			buildOnlineSourceView(lr),
-		},
+		match:        outcome.match,
+		notices:      lr.Retraction.Notices,
+	}
+	if lr.Suggestion() != "" {
+		published := lr.Published
+		view.published = &published
+	}
+	for _, status := range lr.Statuses() {
+		source := sourceView{
+			name:   status.Name,
+			status: string(status.State),
+			detail: status.Detail,
+		}
+		if status.Record != nil {
+			source.url = status.Record.URL
+		}
+		view.sources = append(view.sources, source)
 	}
 
 	switch {
@@ -78,6 +109,12 @@ func buildEntryView(number int, lr *lookup.Result, outcome summaryOutcome) entry
 	default:
 		view.summaryState = deriveSummaryStateFromSources(lr)
 	}
+	// a retracted work should not be cited however well the entry matches it
+	if lr.Retracted() {
+		view.summaryState = summaryStateRetracted
+	} else if lr.WrongDOI() {
+		view.summaryState = summaryStateWrongDOI
+	}
 
 	return view
 }
@@ -101,6 +138,10 @@ func buildDocumentView(views []entryView, carelessHideOK bool) documentView {
 			doc.review++
 		case summaryStateError:
 			doc.errors++
+		case summaryStateRetracted:
+			doc.retracted++
+		case summaryStateWrongDOI:
+			doc.wrongDOI++
 		default:
 			doc.unknown++
 		}
@@ -126,7 +167,7 @@ func renderDocument(doc documentView, views []entryView, carelessHideOK bool, si
 			fmt.Fprintf(&b, " (%d hidden by --careless-hide-ok)", doc.hiddenOK)
 		}
 		b.WriteString("\n")
-		fmt.Fprintf(&b, "Summary states: review=%d error=%d ok=%d unknown=%d\n", doc.review, doc.errors, doc.explicitOK, doc.unknown)
+		fmt.Fprintf(&b, "Summary states: retracted=%d wrong-doi=%d review=%d error=%d ok=%d unknown=%d\n", doc.retracted, doc.wrongDOI, doc.review, doc.errors, doc.explicitOK, doc.unknown)
 		b.WriteString("\n")
 	}
 
@@ -145,18 +186,41 @@ func renderDocument(doc documentView, views []entryView, carelessHideOK bool, si
 	if rendered == 0 {
 		b.WriteString("No entries to display.\n")
 	}
+	if len(doc.duplicates) > 0 {
+		b.WriteString("\n")
+		b.WriteString(renderDuplicates(doc.duplicates))
+	}
+	if doc.orphans != nil {
+		b.WriteString("\n")
+		b.WriteString(renderOrphans(doc.orphans))
+	}
+
+	return b.String()
+}
 
+func renderDuplicates(groups []duplicates.Group) string {
+	var b strings.Builder
+	b.WriteString("Duplicate entries:\n")
+	for _, group := range groups {
+		fmt.Fprintf(&b, "  Entries %s: %s\n", strings.Join(group.IDs, ", "), strings.Join(group.Reasons, "; "))
+	}
 	return b.String()
 }
 
 func renderEntry(view entryView) string {
 	var b strings.Builder
 
-	fmt.Fprintf(&b, "Entry %d [%s]\n", view.number, strings.ToUpper(string(view.summaryState)))
+	fmt.Fprintf(&b, "Entry %s [%s]\n", view.id, strings.ToUpper(string(view.summaryState)))
 	b.WriteString(renderLabeledBlock("Original", view.originalText))
 	if view.summaryComment != "" {
 		b.WriteString(renderLabeledBlock("Summary", view.summaryComment))
 	}
+	if view.match != nil && view.match.Explain() != view.summaryComment {
+		b.WriteString(renderLabeledBlock("Match", view.match.Explain()))
+	}
+	if view.published != nil {
+		b.WriteString(renderLabeledBlock("Suggestion", "cite the published version: "+view.published.Citation))
+	}
 	b.WriteString(renderSourceBlock("Lookups", view.sources))
 
 	return b.String()
@@ -181,16 +245,19 @@ func renderLabeledBlock(label, value string) string {
 }
 
 func deriveSummaryStateFromSources(lr *lookup.Result) summaryState {
-	if lr.OSTI.Error != nil || lr.Arxiv.Error != nil || lr.Elsevier.Error != nil || lr.Crossref.Error != nil || lr.Online.Error != nil || lr.DOIOrg.Error != nil {
+	if lr.HasError() {
 		return summaryStateError
 	}
-	if lr.OSTI.Record != nil || lr.Arxiv.Entry != nil || lr.Elsevier.Result != nil || lr.Crossref.Work != nil || lr.Online.Metadata != nil || lr.DOIOrg.Found {
+	if lr.HasMatch() {
 		return summaryStateUnknown
 	}
 	return summaryStateReview
 }
 
 func renderSourceBlock(label string, sources []sourceView) string {
+	if len(sources) == 0 {
+		return ""
+	}
 	var b strings.Builder
 	fmt.Fprintf(&b, "%s:\n", label)
 	for _, source := range sources {
@@ -204,108 +271,12 @@ func renderSourceBlock(label string, sources []sourceView) string {
 }
 
 func colorizeSourceStatus(status string) string {
-	switch status {
-	case "error":
+	switch lookup.SourceState(status) {
+	case lookup.SourceError, lookup.SourceFlagged, lookup.SourceWrongWork:
 		return prettytext.FgRed.Sprint(status)
-	case "skipped":
+	case lookup.SourceSkipped:
 		return prettytext.FgYellow.Sprint(status)
 	default:
 		return status
 	}
 }
-
-func buildDOISourceView(lr *lookup.Result) sourceView {
-	view := sourceView{name: "DOI", status: "skipped"}
-	switch {
-	case lr.DOIOrg.Found:
-		view.status = "found"
-		view.detail = "exists"
-	case lr.DOIOrg.Error != nil:
-		view.status = "error"
-		view.detail = lr.DOIOrg.Error.Error()
-	case lr.DOIOrg.ID != "":
-		view.status = "not-found"
-		view.detail = lr.DOIOrg.ID
-	}
-	return view
-}
-
-func buildOSTISourceView(lr *lookup.Result) sourceView {
-	view := sourceView{name: "OSTI", status: "skipped"}
-	switch {
-	case lr.OSTI.Record != nil:
-		view.status = "found"
-		view.detail = lr.OSTI.Record.ToString()
-	case lr.OSTI.Error != nil:
-		view.status = "error"
-		view.detail = lr.OSTI.Error.Error()
-	case lr.OSTI.ID != "":
-		view.status = "not-found"
-		view.detail = lr.OSTI.ID
-	}
-	return view
-}
-
-func buildArxivSourceView(lr *lookup.Result) sourceView {
-	view := sourceView{name: "arXiv", status: "skipped"}
-	switch {
-	case lr.Arxiv.Entry != nil:
-		view.status = "found"
-		view.detail = lr.Arxiv.Entry.ToString()
-	case lr.Arxiv.Error != nil:
-		view.status = "error"
-		view.detail = lr.Arxiv.Error.Error()
-	case lr.Arxiv.ID != "":
-		view.status = "not-found"
-		view.detail = lr.Arxiv.ID
-	}
-	return view
-}
-
-func buildElsevierSourceView(lr *lookup.Result) sourceView {
-	view := sourceView{name: "Elsevier", status: "skipped"}
-	switch {
-	case lr.Elsevier.Result != nil:
-		view.status = "matched"
-		view.detail = lr.Elsevier.Result.ToString()
-	case lr.Elsevier.Error != nil:
-		view.status = "error"
-		view.detail = lr.Elsevier.Error.Error()
-	case lr.Elsevier.Status == lookup.SearchStatusDone:
-		view.status = "no-match"
-	}
-	return view
-}
-
-func buildCrossrefSourceView(lr *lookup.Result) sourceView {
-	view := sourceView{name: "Crossref", status: "skipped"}
-	switch {
-	case lr.Crossref.Work != nil:
-		view.status = "matched"
-		view.detail = lr.Crossref.Work.ToString()
-	case lr.Crossref.Error != nil:
-		view.status = "error"
-		view.detail = lr.Crossref.Error.Error()
-	case lr.Crossref.Comment != "":
-		view.status = "no-match"
-		view.detail = lr.Crossref.Comment
-	case lr.Crossref.Status == lookup.SearchStatusDone:
-		view.status = "no-match"
-	}
-	return view
-}
-
-func buildOnlineSourceView(lr *lookup.Result) sourceView {
-	view := sourceView{name: "Online", status: "skipped"}
-	switch {
-	case lr.Online.Metadata != nil:
-		view.status = "found"
-		view.detail = lr.Online.Metadata.ToString()
-	case lr.Online.Error != nil:
-		view.status = "error"
-		view.detail = lr.Online.Error.Error()
-	case lr.Online.Status == lookup.SearchStatusDone:
-		view.status = "not-found"
-	}
-	return view
-}
@@ -3,55 +3,94 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	analysisrunner "github.com/sandialabs/bibcheck/analysis"
+	"github.com/sandialabs/bibcheck/bibliography"
+	"github.com/sandialabs/bibcheck/cassette"
+	"github.com/sandialabs/bibcheck/citations"
 	"github.com/sandialabs/bibcheck/config"
 	"github.com/sandialabs/bibcheck/crossref"
 	"github.com/sandialabs/bibcheck/documents"
+	"github.com/sandialabs/bibcheck/duplicates"
 	"github.com/sandialabs/bibcheck/elsevier"
 	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/export"
 	"github.com/sandialabs/bibcheck/lookup"
 	"github.com/sandialabs/bibcheck/openrouter"
+	"github.com/sandialabs/bibcheck/retraction"
 	"github.com/sandialabs/bibcheck/shirty"
 	"github.com/sandialabs/bibcheck/version"
 )
 
 var (
+	annotatePath   string
 	carelessHideOK bool
+	checkOrphans   bool
+	entryID        string
+	entryTimeout   time.Duration
+	exportPath     string
+	failOn         []string
 	format         outputFormat
 	pipeline       string
+	sources        []string
 	workers        int
 )
 
 const (
+	FlagAnnotatePDF    string = "annotate-pdf"
 	FlagCarelessHideOK string = "careless-hide-ok"
 	FlagEntry          string = "entry"
+	FlagEntryTimeout   string = "entry-timeout"
+	FlagExport         string = "export"
+	FlagFailOn         string = "fail-on"
 	FlagFormat         string = "format"
+	FlagOrphans        string = "orphans"
 	FlagPipeline       string = "pipeline"
+	FlagSources        string = "sources"
 	FlagWorkers        string = "workers"
 )
 
 type outputFormat string
 
 type summarizer interface {
-	Summarize(*lookup.Result) (bool, string, error)
+	Summarize(context.Context, *lookup.Result) (bool, string, error)
 }
 
 const (
-	outputFormatJSON outputFormat = "json"
-	outputFormatText outputFormat = "text"
+	outputFormatHTML   outputFormat = "html"
+	outputFormatJSON   outputFormat = "json"
+	outputFormatJUnit  outputFormat = "junit"
+	outputFormatNDJSON outputFormat = "ndjson"
+	outputFormatSARIF  outputFormat = "sarif"
+	outputFormatText   outputFormat = "text"
 )
 
+// failStates are the summary states --fail-on accepts.
+var failStates = []summaryState{
+	summaryStateError,
+	summaryStateRetracted,
+	summaryStateReview,
+	summaryStateUnknown,
+	summaryStateWrongDOI,
+}
+
 var rootCmd = &cobra.Command{
-	Use:   "bibcheck <pdf-file>",
-	Short: "Check bibliography entries in a PDF file",
+	Use:   "bibcheck <pdf-bib-bbl-or-tex-file>",
+	Short: "Check bibliography entries in a PDF, BibTeX or LaTeX file",
 	Long: `bibliograph-checker ` + version.String() + ` (` + version.GitSha() + `)
-A tool that analyzes bibliography entries in PDF files and verifies their existence.`,
+A tool that analyzes bibliography entries in PDF files and verifies their existence.
+BibTeX (.bib), LaTeX (.bbl, .tex) files are read directly, without LLM extraction.`,
 	Args:          cobra.ExactArgs(1),
 	SilenceErrors: true,
 	SilenceUsage:  true,
@@ -59,11 +98,30 @@ A tool that analyzes bibliography entries in PDF files and verifies their existe
 		if err := validateOutputFormat(format); err != nil {
 			return err
 		}
+		if err := validateFailOn(failOn); err != nil {
+			return err
+		}
+		var exportFormat export.Format
+		if exportPath != "" {
+			var err error
+			if exportFormat, err = export.FormatFor(exportPath); err != nil {
+				return fmt.Errorf("invalid --%s: %w", FlagExport, err)
+			}
+		}
+		var strategy []lookup.Step
+		if cmd.Flags().Changed(FlagSources) {
+			var err error
+			if strategy, err = lookup.StrategyFor(sources); err != nil {
+				return err
+			}
+		}
 
+		ctx := cmd.Context()
 		pdfPath := args[0]
+		if annotatePath != "" && isLocalPath(pdfPath) {
+			return fmt.Errorf("--%s needs a PDF input", FlagAnnotatePDF)
+		}
 		settings := config.Runtime()
-		entryStart := 1
-		var entryCount int
 
 		// set up clients depending on config
 		var openrouterClient *openrouter.Client
@@ -87,48 +145,10 @@ A tool that analyzes bibliography entries in PDF files and verifies their existe
 			elsevierClient = elsevier.NewClient(settings.ElsevierAPIKey)
 		}
 
-		var bibliography *documents.Bibliography
-		var err error
-
-		if shirtyProvider != nil {
-			bibliography, err = shirtyProvider.PrepareBibliography(pdfPath)
-			if err != nil {
-				return fmt.Errorf("prepare bibliography error: %w", err)
-			}
-		} else if openrouterClient != nil {
-			bibliography, err = openrouterClient.PrepareBibliography(pdfPath)
-			if err != nil {
-				return fmt.Errorf("prepare bibliography error: %w", err)
-			}
-		}
-
-		if cmd.Flags().Changed(FlagEntry) {
-			entryStart, _ = cmd.Flags().GetInt(FlagEntry)
-			entryCount = 1
-		} else {
-
-			// Get citation counts
-			if bibliography != nil && openrouterClient != nil && shirtyProvider == nil {
-				fmt.Println("Counting bibliography entries...")
-				entryCount, err = openrouterClient.NumBibliographyEntries(bibliography)
-				if err != nil {
-					return fmt.Errorf("bibliography size error: %w", err)
-				}
-				fmt.Printf("Found %d bibliographic entries\n", entryCount)
-			} else if bibliography != nil {
-				entryCount, err = shirtyProvider.NumBibEntries(bibliography)
-				if err != nil {
-					return fmt.Errorf("bibliography size error: %w", err)
-				}
-
-			} else {
-				return fmt.Errorf("need shirty or openrouter config")
-			}
-		}
-
 		var class entries.Classifier
 		var entryParser entries.Parser
 		var docBibliographyExtract documents.EntryFromBibliographyExtractor
+		var docEntryIDs documents.EntryIDLister
 		var docMeta documents.MetaExtractor
 
 		// default to using openrouter, if available
@@ -136,6 +156,7 @@ A tool that analyzes bibliography entries in PDF files and verifies their existe
 			class = openrouterClient
 			entryParser = openrouterClient
 			docBibliographyExtract = openrouterClient
+			docEntryIDs = openrouterClient
 			docMeta = openrouterClient
 		}
 
@@ -144,12 +165,108 @@ A tool that analyzes bibliography entries in PDF files and verifies their existe
 			class = shirtyProvider
 			entryParser = shirtyProvider
 			docBibliographyExtract = shirtyProvider
+			docEntryIDs = shirtyProvider
 			docMeta = shirtyProvider
 		}
 
+		var entryIDs []string
+		var extract func(context.Context, string) (string, error)
+		var findCitations func(context.Context) ([]citations.Citation, error)
+		var localLines map[string]int
+		var pdfBibliography *documents.Bibliography
+		if isLocalPath(pdfPath) {
+			// the entries are already in text form, so no LLM extraction is needed
+			local, err := readLocalEntries(pdfPath, entryParser)
+			if err != nil {
+				return err
+			}
+			entryParser = local.parser
+			entryIDs = local.keys
+			localLines = local.lines
+			extract = func(_ context.Context, id string) (string, error) {
+				return local.extract(id)
+			}
+			if local.cites != nil {
+				findCitations = func(context.Context) ([]citations.Citation, error) {
+					return local.cites, nil
+				}
+			}
+		} else {
+			var bibliography *documents.Bibliography
+			var err error
+			if shirtyProvider != nil {
+				bibliography, err = shirtyProvider.PrepareBibliography(ctx, pdfPath)
+				if err != nil {
+					return fmt.Errorf("prepare bibliography error: %w", err)
+				}
+			} else if openrouterClient != nil {
+				bibliography, err = openrouterClient.PrepareBibliography(ctx, pdfPath)
+				if err != nil {
+					return fmt.Errorf("prepare bibliography error: %w", err)
+				}
+			}
+			if docBibliographyExtract == nil || bibliography == nil {
+				return fmt.Errorf("need shirty or openrouter config")
+			}
+			pdfBibliography = bibliography
+
+			if !cmd.Flags().Changed(FlagEntry) {
+				log.Println("Listing bibliography entries...")
+				entryIDs, err = docEntryIDs.BibliographyEntryIDs(ctx, bibliography)
+				if err != nil {
+					return fmt.Errorf("bibliography entries error: %w", err)
+				}
+				log.Printf("Found %d bibliographic entries", len(entryIDs))
+			}
+			extract = func(ctx context.Context, id string) (string, error) {
+				return docBibliographyExtract.EntryFromBibliography(ctx, bibliography, id)
+			}
+
+			// textract reads the body where it is available, otherwise it is
+			// read locally
+			var textExtractor documents.TextExtractor
+			if shirtyProvider != nil {
+				textExtractor = shirtyProvider
+			}
+			findCitations = func(ctx context.Context) ([]citations.Citation, error) {
+				pdf, err := os.ReadFile(pdfPath)
+				if err != nil {
+					return nil, fmt.Errorf("read pdf error: %w", err)
+				}
+				log.Println("Reading document body...")
+				text, err := documents.BodyText(ctx, pdf, bibliography, textExtractor)
+				if err != nil {
+					return nil, fmt.Errorf("document body error: %w", err)
+				}
+				return citations.Find(citations.Body(text)), nil
+			}
+		}
+
+		if cmd.Flags().Changed(FlagEntry) {
+			id := bibliography.CleanID(entryID)
+			if isLocalPath(pdfPath) && !slices.Contains(entryIDs, id) {
+				return fmt.Errorf("no entry %q in %s", id, pdfPath)
+			}
+			entryIDs = []string{id}
+		}
+		if len(entryIDs) == 0 {
+			return fmt.Errorf("no bibliography entries in %s", pdfPath)
+		}
+
+		var retractionWatch *retraction.Watch
+		if settings.RetractionWatch != "" {
+			var err error
+			if retractionWatch, err = retraction.LoadWatch(settings.RetractionWatch); err != nil {
+				return err
+			}
+			log.Printf("Loaded Retraction Watch notices for %d works", retractionWatch.Len())
+		}
+
 		cfg := &lookup.EntryConfig{
-			ElsevierClient: elsevierClient,
-			CrossrefClient: crossref.NewClient(),
+			ElsevierClient:  elsevierClient,
+			CrossrefClient:  crossref.NewClient(),
+			RetractionWatch: retractionWatch,
+			Strategy:        strategy,
 		}
 
 		var summarizer summarizer
@@ -160,55 +277,75 @@ A tool that analyzes bibliography entries in PDF files and verifies their existe
 			summarizer = shirtyProvider
 		}
 
-		entryIDs := make([]int, entryCount)
-		for i := range entryIDs {
-			entryIDs[i] = entryStart + i
-		}
-		if docBibliographyExtract == nil || bibliography == nil {
-			return fmt.Errorf("requires something that can extract a bib entry from a pdf")
+		singleEntry := cmd.Flags().Changed(FlagEntry)
+		var events *ndjsonWriter
+		var progress func(analysisrunner.Snapshot)
+		if format == outputFormatNDJSON {
+			events = newNDJSONWriter(os.Stdout, len(entryIDs), carelessHideOK, singleEntry)
+			progress = events.progress
 		}
 
-		run, err := analysisrunner.Run(cmd.Context(), analysisrunner.Config{
-			EntryIDs: entryIDs,
-			Workers:  workers,
-			Extract: func(id int) (string, error) {
-				return docBibliographyExtract.EntryFromBibliography(bibliography, id)
-			},
-			Lookup: func(text string) (*lookup.Result, error) {
-				return lookup.Entry(text, pipeline, class, docMeta, entryParser, cfg)
+		run, err := analysisrunner.Run(ctx, analysisrunner.Config{
+			EntryIDs:     entryIDs,
+			Workers:      workers,
+			EntryTimeout: entryTimeout,
+			Extract:      extract,
+			Lookup: func(ctx context.Context, text string) (*lookup.Result, error) {
+				return lookup.Entry(ctx, text, pipeline, class, docMeta, entryParser, cfg)
 			},
-			Summarize: func(result *lookup.Result) (analysisrunner.Summary, error) {
+			Summarize: func(ctx context.Context, result *lookup.Result) (analysisrunner.Summary, error) {
 				if summarizer == nil {
-					return analysisrunner.Summary{}, nil
+					return analysisrunner.MatchSummary(result), nil
 				}
-				mismatch, comment, err := summarizer.Summarize(result)
+				mismatch, comment, err := summarizer.Summarize(ctx, result)
 				return analysisrunner.Summary{Mismatch: mismatch, Comment: comment}, err
 			},
+			Progress: progress,
 		})
 		if err != nil {
+			events.fail(err)
 			return err
 		}
+		events.progress(run)
 
 		views := []entryView{}
-		singleEntry := cmd.Flags().Changed(FlagEntry)
+		works := []duplicates.Entry{}
+		exported := []export.Entry{}
 		for _, entry := range run.Entries {
+			views = append(views, runEntryView(entry))
 			if entry.Result == nil {
-				if entry.ExtractionError != nil {
-					log.Printf("entry %d extraction error: %v", entry.ID, entry.ExtractionError)
-				} else if entry.LookupError != nil {
-					log.Printf("entry %d analysis error: %v", entry.ID, entry.LookupError)
+				log.Printf("entry %s %s", entry.ID, views[len(views)-1].summaryComment)
+				if entry.Text != "" {
+					exported = append(exported, export.Entry{ID: entry.ID, Text: entry.Text})
 				}
 				continue
 			}
-			outcome := summaryOutcome{
-				mismatch: entry.Summary.Mismatch,
-				comment:  entry.Summary.Comment,
-				err:      entry.SummaryError,
+			works = append(works, duplicates.FromResult(entry.ID, entry.Result))
+			exported = append(exported, export.FromResult(entry.ID, entry.Result))
+		}
+		if exportPath != "" {
+			if err := writeExport(exportPath, exportFormat, exported); err != nil {
+				return err
+			}
+		}
+
+		if annotatePath != "" {
+			if err := writeAnnotatedPDF(annotatePath, pdfPath, pdfBibliography, views); err != nil {
+				return err
 			}
-			views = append(views, buildEntryView(entry.ID, entry.Result, outcome))
 		}
 
 		doc := buildDocumentView(views, carelessHideOK)
+		doc.input = pdfPath
+		if isLocalPath(pdfPath) {
+			doc.lines = localLines
+		}
+		if !singleEntry {
+			doc.duplicates = duplicates.Find(works)
+		}
+		if checkOrphans && !singleEntry {
+			doc.orphans = buildOrphansView(ctx, findCitations, run.Entries)
+		}
 		switch format {
 		case outputFormatText:
 			fmt.Fprint(os.Stdout, renderDocument(doc, views, carelessHideOK, singleEntry))
@@ -218,34 +355,71 @@ A tool that analyzes bibliography entries in PDF files and verifies their existe
 				return fmt.Errorf("render json output: %w", err)
 			}
 			fmt.Fprint(os.Stdout, rendered)
+		case outputFormatHTML:
+			rendered, err := renderHTMLDocument(doc, views, carelessHideOK, singleEntry)
+			if err != nil {
+				return fmt.Errorf("render html output: %w", err)
+			}
+			fmt.Fprint(os.Stdout, rendered)
+		case outputFormatSARIF:
+			rendered, err := renderSARIFDocument(doc, views, carelessHideOK, singleEntry)
+			if err != nil {
+				return fmt.Errorf("render sarif output: %w", err)
+			}
+			fmt.Fprint(os.Stdout, rendered)
+		case outputFormatJUnit:
+			rendered, err := renderJUnitDocument(doc, views, carelessHideOK, singleEntry)
+			if err != nil {
+				return fmt.Errorf("render junit output: %w", err)
+			}
+			fmt.Fprint(os.Stdout, rendered)
+		case outputFormatNDJSON:
+			if err := events.done(doc, views); err != nil {
+				return fmt.Errorf("render ndjson output: %w", err)
+			}
 		default:
 			return fmt.Errorf("unsupported output format %q", format)
 		}
-		return nil
+		return checkFailOn(views, failOn)
 	},
 }
 
 func init() {
 	// don't include the `completion` subcommand
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.PersistentFlags().String("cassette", "", "Record HTTP traffic to, or replay it from, this file")
+	rootCmd.PersistentFlags().String("cassette-mode", string(cassette.ModeReplay), "Cassette mode: record or replay")
 	rootCmd.PersistentFlags().String("elsevier-api-key", "", "Elsevier API key")
+	rootCmd.PersistentFlags().String("http-cache-dir", "", "Directory for cached HTTP responses")
+	rootCmd.PersistentFlags().Bool("http-cache-enabled", true, "Cache metadata and URL responses on disk")
+	rootCmd.PersistentFlags().Duration("http-cache-ttl", config.DefaultHTTPCacheTTL, "How long cached HTTP responses are reused")
+	rootCmd.PersistentFlags().String("llm-cache-dir", "", "Directory for cached LLM responses")
+	rootCmd.PersistentFlags().Bool("llm-cache-enabled", true, "Reuse LLM responses to identical requests; set to false for fresh answers")
 	rootCmd.PersistentFlags().String("openai-audit-dir", "", "Directory for OpenAI API audit logs")
 	rootCmd.PersistentFlags().Bool("openai-audit-enabled", true, "Enable OpenAI API audit logging")
 	rootCmd.PersistentFlags().String("openrouter-api-key", "", "OpenRouter API key")
 	rootCmd.PersistentFlags().String("openrouter-base-url", config.DefaultOpenRouterBaseURL, "Openrouter-compatible API url")
+	rootCmd.PersistentFlags().String("retraction-watch-csv", "", "Retraction Watch database export used to flag retracted works")
 	rootCmd.PersistentFlags().String("shirty-api-key", "", "shirty.sandia.gov API key")
 	rootCmd.PersistentFlags().String("shirty-base-url", config.DefaultShirtyBaseURL, "Shirty base URL")
 	rootCmd.PersistentFlags().String("shirty-model", config.DefaultShirtyModel, "Default Shirty model")
 	if err := config.BindFlags(rootCmd.PersistentFlags()); err != nil {
 		panic(err)
 	}
+	rootCmd.Flags().StringVar(&annotatePath, FlagAnnotatePDF, "", "Write a copy of the input PDF with a note on each entry that needs attention to this file")
 	rootCmd.Flags().BoolVar(&carelessHideOK, FlagCarelessHideOK, false, "Hide entries whose summary explicitly says they look okay")
-	rootCmd.Flags().Int(FlagEntry, -1, "Analyze a single entry")
-	rootCmd.Flags().Var(newOutputFormatValue(&format), FlagFormat, "Output format: text or json")
+	rootCmd.Flags().StringVar(&entryID, FlagEntry, "", "Analyze a single entry, by its bibliography ID (e.g. 12 or Smi20) or citation key")
+	rootCmd.Flags().DurationVar(&entryTimeout, FlagEntryTimeout, 0, "Give up on an entry after this long, e.g. 2m (default no limit)")
+	rootCmd.Flags().StringVar(&exportPath, FlagExport, "", "Write the verified record of each entry to this .bib (BibTeX) or .json (CSL-JSON) file")
+	rootCmd.Flags().StringSliceVar(&failOn, FlagFailOn, nil, "Exit with an error when any entry is in one of these summary states, e.g. review,error (states: "+joinStates(failStates)+")")
+	rootCmd.Flags().Var(newOutputFormatValue(&format), FlagFormat, "Output format: text, json, ndjson, html, sarif or junit")
+	rootCmd.Flags().BoolVar(&checkOrphans, FlagOrphans, false, "Cross-check in-text citations against the bibliography")
 	rootCmd.Flags().StringVar(&pipeline, FlagPipeline, "auto", "Analysis pipeline to use")
+	rootCmd.Flags().StringSliceVar(&sources, FlagSources, nil, "Lookup sources to try, in order (default "+strings.Join(lookup.SourceKeys(), ",")+")")
 	rootCmd.Flags().IntVar(&workers, FlagWorkers, analysisrunner.DefaultWorkers, "Number of bibliography workers")
 
 	rootCmd.AddCommand(bibCmd)
+	rootCmd.AddCommand(cacheCmd)
 	rootCmd.AddCommand(doiCmd)
 	rootCmd.AddCommand(entryCmd)
 	rootCmd.AddCommand(listEntriesCmd)
@@ -253,19 +427,102 @@ func init() {
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C cancels in-flight requests instead of waiting for them
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+// writeExport writes the verified records of list to path.
+func writeExport(path string, format export.Format, list []export.Entry) error {
+	var b bytes.Buffer
+	if err := export.Write(&b, format, list); err != nil {
+		return fmt.Errorf("export error: %w", err)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write export error: %w", err)
+	}
+	verified := 0
+	for _, entry := range list {
+		if entry.Record != nil {
+			verified++
+		}
+	}
+	log.Printf("Wrote %d of %d entries with verified records to %s", verified, len(list), path)
+	return nil
+}
+
+// runEntryView returns the view of a finished entry. An entry that could not
+// be extracted or looked up is in the error state.
+func runEntryView(entry analysisrunner.Entry) entryView {
+	if entry.Result == nil {
+		view := entryView{id: entry.ID, originalText: entry.Text, summaryState: summaryStateError}
+		switch {
+		case entry.ExtractionError != nil:
+			view.summaryComment = fmt.Sprintf("extraction error: %v", entry.ExtractionError)
+		case entry.LookupError != nil:
+			view.summaryComment = fmt.Sprintf("analysis error: %v", entry.LookupError)
+		default:
+			view.summaryComment = "analysis error: no lookup result"
+		}
+		return view
+	}
+	return buildEntryView(entry.ID, entry.Result, summaryOutcome{
+		mismatch: entry.Summary.Mismatch,
+		comment:  entry.Summary.Comment,
+		err:      entry.SummaryError,
+		match:    entry.Summary.Match,
+	})
+}
+
 func validateOutputFormat(format outputFormat) error {
 	switch format {
-	case outputFormatText, outputFormatJSON:
+	case outputFormatText, outputFormatJSON, outputFormatNDJSON, outputFormatHTML, outputFormatSARIF, outputFormatJUnit:
 		return nil
 	default:
-		return fmt.Errorf("invalid --format %q (supported: text, json)", format)
+		return fmt.Errorf("invalid --format %q (supported: text, json, ndjson, html, sarif, junit)", format)
+	}
+}
+
+func validateFailOn(states []string) error {
+	for _, state := range states {
+		if !slices.Contains(failStates, summaryState(state)) {
+			return fmt.Errorf("invalid --%s state %q (supported: %s)", FlagFailOn, state, joinStates(failStates))
+		}
+	}
+	return nil
+}
+
+// checkFailOn returns an error if any entry, shown or not, is in one of the
+// given summary states.
+func checkFailOn(views []entryView, states []string) error {
+	counts := map[string]int{}
+	for _, view := range views {
+		if slices.Contains(states, string(view.summaryState)) {
+			counts[string(view.summaryState)]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	found := []string{}
+	for _, state := range states {
+		if counts[state] > 0 {
+			found = append(found, fmt.Sprintf("%s=%d", state, counts[state]))
+		}
+	}
+	return fmt.Errorf("entries in --%s states: %s", FlagFailOn, strings.Join(found, " "))
+}
+
+func joinStates(states []summaryState) string {
+	names := make([]string, len(states))
+	for i, state := range states {
+		names[i] = string(state)
 	}
+	return strings.Join(names, ", ")
 }
 
 type outputFormatValue struct {
@@ -0,0 +1,176 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/version"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	ruleDuplicateEntry = "duplicate-entry"
+	ruleUncitedEntry   = "uncited-entry"
+	ruleMissingEntry   = "missing-entry"
+)
+
+// sarifRule describes one kind of finding. Entries are reported under the
+// rule named for their summary state.
+type sarifRule struct {
+	id          string
+	level       string
+	description string
+}
+
+var sarifRules = []sarifRule{
+	{string(summaryStateRetracted), "error", "The cited work has been retracted or withdrawn."},
+	{string(summaryStateWrongDOI), "error", "The entry's DOI belongs to a different work."},
+	{string(summaryStateReview), "warning", "The entry does not match the metadata found for it, or no metadata was found."},
+	{string(summaryStateError), "warning", "The entry could not be checked."},
+	{string(summaryStateUnknown), "note", "Metadata was found for the entry but not compared."},
+	{ruleDuplicateEntry, "warning", "Several entries cite the same work."},
+	{ruleUncitedEntry, "note", "The entry is not cited in the text."},
+	{ruleMissingEntry, "warning", "The text cites a work with no bibliography entry."},
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                `json:"name"`
+	Version        string                `json:"version"`
+	InformationURI string                `json:"informationUri"`
+	Rules          []sarifRuleDescriptor `json:"rules"`
+}
+
+type sarifRuleDescriptor struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// renderSARIFDocument reports every entry not in the ok state, the duplicate
+// entries and the orphans as SARIF results located in the input file.
+func renderSARIFDocument(doc documentView, views []entryView, carelessHideOK bool, singleEntry bool) (string, error) {
+	driver := sarifDriver{
+		Name:           "bibcheck",
+		Version:        version.String(),
+		InformationURI: "https://github.com/sandialabs/bibcheck",
+	}
+	levels := map[string]string{}
+	for _, rule := range sarifRules {
+		levels[rule.id] = rule.level
+		driver.Rules = append(driver.Rules, sarifRuleDescriptor{
+			ID:                   rule.id,
+			ShortDescription:     sarifMessage{Text: rule.description},
+			DefaultConfiguration: sarifConfiguration{Level: rule.level},
+		})
+	}
+
+	results := []sarifResult{}
+	add := func(ruleID, id, message string) {
+		results = append(results, sarifResult{
+			RuleID:    ruleID,
+			Level:     levels[ruleID],
+			Message:   sarifMessage{Text: message},
+			Locations: doc.sarifLocations(id),
+		})
+	}
+	for _, view := range views {
+		if view.summaryState == summaryStateOK || shouldHideEntry(view, carelessHideOK, singleEntry) {
+			continue
+		}
+		message := fmt.Sprintf("Entry %s: %s", view.id, view.summaryState)
+		if view.summaryComment != "" {
+			message += ": " + view.summaryComment
+		}
+		add(string(view.summaryState), view.id, message)
+	}
+	for _, group := range doc.duplicates {
+		add(ruleDuplicateEntry, group.IDs[0], fmt.Sprintf("Entries %s cite the same work: %s", strings.Join(group.IDs, ", "), strings.Join(group.Reasons, "; ")))
+	}
+	if doc.orphans != nil && doc.orphans.Note == "" {
+		for _, id := range doc.orphans.Uncited {
+			add(ruleUncitedEntry, id, fmt.Sprintf("Entry %s is not cited in the text", id))
+		}
+		for _, cite := range doc.orphans.Unresolved {
+			add(ruleMissingEntry, "", fmt.Sprintf("%s, cited as %s, has no bibliography entry", cite.Ref, cite.Citation))
+		}
+	}
+
+	payload := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	out, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
+
+// sarifLocations locates the entry with the given ID in the input file, at
+// its line when the input is a BibTeX or LaTeX file.
+func (doc documentView) sarifLocations(id string) []sarifLocation {
+	if doc.input == "" {
+		return nil
+	}
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(doc.input)},
+	}}
+	if line := doc.lines[id]; line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+	}
+	return []sarifLocation{location}
+}
@@ -31,7 +31,7 @@ var textractCmd = &cobra.Command{
 			settings.ShirtyBaseURL,
 			shirty.WithModel(settings.ShirtyModel),
 		)
-		resp, err := client.Textract(filePath)
+		resp, err := client.Textract(cmd.Context(), filePath)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -36,3 +36,27 @@ func OpenAIAuditDir(settings Settings) (string, error) {
 	}
 	return filepath.Join(stateHome, "bibcheck", "openai-audit"), nil
 }
+
+func HTTPCacheDir(settings Settings) (string, error) {
+	if dir := strings.TrimSpace(settings.HTTPCacheDir); dir != "" {
+		return dir, nil
+	}
+
+	stateHome, err := StateHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateHome, "bibcheck", "http-cache"), nil
+}
+
+func LLMCacheDir(settings Settings) (string, error) {
+	if dir := strings.TrimSpace(settings.LLMCacheDir); dir != "" {
+		return dir, nil
+	}
+
+	stateHome, err := StateHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateHome, "bibcheck", "llm-cache"), nil
+}
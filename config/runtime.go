@@ -4,21 +4,32 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 const (
+	KeyCassette          = "cassette"
+	KeyCassetteMode      = "cassette_mode"
 	KeyElsevierAPIKey    = "elsevier_api_key"
+	KeyHTTPCacheDir      = "http_cache_dir"
+	KeyHTTPCacheEnable   = "http_cache_enabled"
+	KeyHTTPCacheTTL      = "http_cache_ttl"
+	KeyLLMCacheDir       = "llm_cache_dir"
+	KeyLLMCacheEnable    = "llm_cache_enabled"
 	KeyOpenAIAuditDir    = "openai_audit_dir"
 	KeyOpenAIAuditEnable = "openai_audit_enabled"
 	KeyOpenRouterAPIKey  = "openrouter_api_key"
 	KeyOpenRouterBaseURL = "openrouter_base_url"
+	KeyRetractionWatch   = "retraction_watch_csv"
 	KeyShirtyAPIKey      = "shirty_api_key"
 	KeyShirtyBaseURL     = "shirty_base_url"
 	KeyShirtyModel       = "shirty_model"
 
+	DefaultHTTPCacheTTL = 7 * 24 * time.Hour
+
 	DefaultOpenRouterBaseURL = "https://openrouter.ai/api/v1"
 
 	DefaultShirtyBaseURL = "https://shirty.sandia.gov/api/v1"
@@ -26,11 +37,19 @@ const (
 )
 
 type Settings struct {
+	Cassette          string
+	CassetteMode      string
 	ElsevierAPIKey    string
+	HTTPCacheDir      string
+	HTTPCacheEnable   bool
+	HTTPCacheTTL      time.Duration
+	LLMCacheDir       string
+	LLMCacheEnable    bool
 	OpenAIAuditDir    string
 	OpenAIAuditEnable bool
 	OpenRouterAPIKey  string
 	OpenRouterBaseURL string
+	RetractionWatch   string
 	ShirtyAPIKey      string
 	ShirtyBaseURL     string
 	ShirtyModel       string
@@ -41,6 +60,9 @@ var runtimeConfig = viper.New()
 func init() {
 	runtimeConfig.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	runtimeConfig.AutomaticEnv()
+	runtimeConfig.SetDefault(KeyHTTPCacheEnable, true)
+	runtimeConfig.SetDefault(KeyHTTPCacheTTL, DefaultHTTPCacheTTL)
+	runtimeConfig.SetDefault(KeyLLMCacheEnable, true)
 	runtimeConfig.SetDefault(KeyOpenAIAuditEnable, true)
 	runtimeConfig.SetDefault(KeyOpenRouterBaseURL, DefaultOpenRouterBaseURL)
 	runtimeConfig.SetDefault(KeyShirtyBaseURL, DefaultShirtyBaseURL)
@@ -49,11 +71,19 @@ func init() {
 
 func BindFlags(flags *pflag.FlagSet) error {
 	for key, flagName := range map[string]string{
+		KeyCassette:          "cassette",
+		KeyCassetteMode:      "cassette-mode",
 		KeyElsevierAPIKey:    "elsevier-api-key",
+		KeyHTTPCacheDir:      "http-cache-dir",
+		KeyHTTPCacheEnable:   "http-cache-enabled",
+		KeyHTTPCacheTTL:      "http-cache-ttl",
+		KeyLLMCacheDir:       "llm-cache-dir",
+		KeyLLMCacheEnable:    "llm-cache-enabled",
 		KeyOpenAIAuditDir:    "openai-audit-dir",
 		KeyOpenAIAuditEnable: "openai-audit-enabled",
 		KeyOpenRouterAPIKey:  "openrouter-api-key",
 		KeyOpenRouterBaseURL: "openrouter-base-url",
+		KeyRetractionWatch:   "retraction-watch-csv",
 		KeyShirtyAPIKey:      "shirty-api-key",
 		KeyShirtyBaseURL:     "shirty-base-url",
 		KeyShirtyModel:       "shirty-model",
@@ -64,11 +94,19 @@ func BindFlags(flags *pflag.FlagSet) error {
 	}
 
 	for key, envName := range map[string]string{
+		KeyCassette:          "CASSETTE",
+		KeyCassetteMode:      "CASSETTE_MODE",
 		KeyElsevierAPIKey:    "ELSEVIER_API_KEY",
+		KeyHTTPCacheDir:      "HTTP_CACHE_DIR",
+		KeyHTTPCacheEnable:   "HTTP_CACHE_ENABLED",
+		KeyHTTPCacheTTL:      "HTTP_CACHE_TTL",
+		KeyLLMCacheDir:       "LLM_CACHE_DIR",
+		KeyLLMCacheEnable:    "LLM_CACHE_ENABLED",
 		KeyOpenAIAuditDir:    "OPENAI_AUDIT_DIR",
 		KeyOpenAIAuditEnable: "OPENAI_AUDIT_ENABLED",
 		KeyOpenRouterAPIKey:  "OPENROUTER_API_KEY",
 		KeyOpenRouterBaseURL: "OPENROUTER_BASE_URL",
+		KeyRetractionWatch:   "RETRACTION_WATCH_CSV",
 		KeyShirtyAPIKey:      "SHIRTY_API_KEY",
 		KeyShirtyBaseURL:     "SHIRTY_BASE_URL",
 		KeyShirtyModel:       "SHIRTY_MODEL",
@@ -83,11 +121,19 @@ func BindFlags(flags *pflag.FlagSet) error {
 
 func Runtime() Settings {
 	return Settings{
+		Cassette:          runtimeConfig.GetString(KeyCassette),
+		CassetteMode:      runtimeConfig.GetString(KeyCassetteMode),
 		ElsevierAPIKey:    runtimeConfig.GetString(KeyElsevierAPIKey),
+		HTTPCacheDir:      runtimeConfig.GetString(KeyHTTPCacheDir),
+		HTTPCacheEnable:   runtimeConfig.GetBool(KeyHTTPCacheEnable),
+		HTTPCacheTTL:      runtimeConfig.GetDuration(KeyHTTPCacheTTL),
+		LLMCacheDir:       runtimeConfig.GetString(KeyLLMCacheDir),
+		LLMCacheEnable:    runtimeConfig.GetBool(KeyLLMCacheEnable),
 		OpenAIAuditDir:    runtimeConfig.GetString(KeyOpenAIAuditDir),
 		OpenAIAuditEnable: runtimeConfig.GetBool(KeyOpenAIAuditEnable),
 		OpenRouterAPIKey:  runtimeConfig.GetString(KeyOpenRouterAPIKey),
 		OpenRouterBaseURL: runtimeConfig.GetString(KeyOpenRouterBaseURL),
+		RetractionWatch:   runtimeConfig.GetString(KeyRetractionWatch),
 		ShirtyAPIKey:      runtimeConfig.GetString(KeyShirtyAPIKey),
 		ShirtyBaseURL:     runtimeConfig.GetString(KeyShirtyBaseURL),
 		ShirtyModel:       runtimeConfig.GetString(KeyShirtyModel),
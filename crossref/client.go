@@ -8,6 +8,9 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/sandialabs/bibcheck/cassette"
+	"github.com/sandialabs/bibcheck/httpcache"
 )
 
 const (
@@ -20,6 +23,7 @@ const (
 // concurrent use and should be shared by all work in one process.
 type Client struct {
 	httpClient    *http.Client
+	cache         *httpcache.Cache
 	startInterval time.Duration
 	delay         func(time.Duration)
 	semaphore     chan struct{}
@@ -35,16 +39,31 @@ func WithHTTPClient(client *http.Client) Option {
 	return func(c *Client) { c.httpClient = client }
 }
 
+// WithTransport sends upstream requests through t instead of
+// http.DefaultTransport.
+func WithTransport(t http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Timeout: c.httpClient.Timeout, Transport: cassette.Transport(t)}
+	}
+}
+
+// WithCache replaces the response cache; nil disables caching.
+func WithCache(cache *httpcache.Cache) Option {
+	return func(c *Client) { c.cache = cache }
+}
+
 // WithDelayCallback registers a callback for requests delayed by rate limiting.
 func WithDelayCallback(callback func(time.Duration)) Option {
 	return func(c *Client) { c.delay = callback }
 }
 
 // NewClient returns a Crossref client limited to one request start every 334ms
-// and at most three concurrent upstream requests.
+// and at most three concurrent upstream requests. Responses go through the
+// default HTTP cache.
 func NewClient(options ...Option) *Client {
 	c := &Client{
-		httpClient:    &http.Client{Timeout: defaultTimeout},
+		httpClient:    &http.Client{Timeout: defaultTimeout, Transport: cassette.Transport(nil)},
+		cache:         httpcache.Default(),
 		startInterval: defaultStartInterval,
 		delay: func(delay time.Duration) {
 			log.Printf("Crossref request delayed by rate limit: %s", delay)
@@ -57,8 +76,13 @@ func NewClient(options ...Option) *Client {
 	return c
 }
 
-// Do performs a rate-limited HTTP request.
+// Do performs a rate-limited HTTP request. Cached responses are returned
+// without waiting for the rate limit.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if resp, ok := c.cache.Lookup(req); ok {
+		return resp, nil
+	}
+
 	waitStarted := time.Now()
 	delayed := false
 	select {
@@ -80,7 +104,11 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	if (delayed || rateDelayed) && c.delay != nil {
 		c.delay(time.Since(waitStarted))
 	}
-	return c.httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.cache.Store(req, resp)
 }
 
 func (c *Client) waitForStart(ctx context.Context) (bool, error) {
@@ -30,6 +30,50 @@ type CrossrefWork struct {
 		DateParts [][]int `json:"date-parts"`
 	} `json:"published-print"`
 	ContainerTitle []string `json:"container-title"`
+	// UpdateTo lists the works this work updates, when it is a retraction,
+	// correction or similar notice.
+	UpdateTo []Update `json:"update-to,omitempty"`
+	// UpdatedBy lists the notices that update this work, including those
+	// Crossref imports from Retraction Watch.
+	UpdatedBy []Update              `json:"updated-by,omitempty"`
+	Relation  map[string][]Relation `json:"relation,omitempty"`
+}
+
+// Update is an editorial update relating a notice to the work it updates.
+// DOI is the DOI of the other work: the notice in UpdatedBy, the updated work
+// in UpdateTo.
+type Update struct {
+	DOI     string `json:"DOI"`
+	Type    string `json:"type"`
+	Label   string `json:"label,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Updated struct {
+		DateParts [][]int `json:"date-parts"`
+	} `json:"updated"`
+}
+
+// Date returns the date of the update as YYYY-MM-DD, or as much of it as is
+// known.
+func (u Update) Date() string {
+	if len(u.Updated.DateParts) == 0 {
+		return ""
+	}
+	parts := []string{}
+	for i, x := range u.Updated.DateParts[0] {
+		if i == 0 {
+			parts = append(parts, fmt.Sprintf("%d", x))
+		} else {
+			parts = append(parts, fmt.Sprintf("%02d", x))
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// Relation is a typed link from a work to another object.
+type Relation struct {
+	IDType     string `json:"id-type"`
+	ID         string `json:"id"`
+	AssertedBy string `json:"asserted-by"`
 }
 
 type CrossrefResponse struct {
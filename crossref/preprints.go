@@ -0,0 +1,73 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package crossref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sandialabs/bibcheck/config"
+)
+
+// publishedTypes are the Crossref work types of peer-reviewed versions.
+const publishedTypes = "type:journal-article,type:proceedings-article,type:book-chapter"
+
+// PublishedVersion returns the DOI of the work that the preprint with the
+// given DOI is a preprint of, according to its is-preprint-of relations, or
+// "" if Crossref records none. DOIs unknown to Crossref have no published
+// version.
+func (c *Client) PublishedVersion(ctx context.Context, doi string) (string, error) {
+	return publishedVersion(ctx, doi, c.Do)
+}
+
+func publishedVersion(
+	ctx context.Context,
+	doi string,
+	do func(*http.Request) (*http.Response, error),
+) (string, error) {
+	work, err := getWork(ctx, doi, do)
+	if errors.Is(err, ErrDoesNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	for _, item := range work.Relation["is-preprint-of"] {
+		if item.IDType == "doi" && item.ID != "" {
+			return bareDOI(item.ID), nil
+		}
+	}
+	return "", nil
+}
+
+// SearchPublished queries Crossref for journal articles, proceedings papers
+// and book chapters with the given title by the given author, which may be
+// empty.
+func (c *Client) SearchPublished(ctx context.Context, title, author string, rows int) ([]CrossrefWork, error) {
+	return searchPublished(ctx, title, author, rows, c.Do)
+}
+
+func searchPublished(
+	ctx context.Context,
+	title, author string,
+	rows int,
+	do func(*http.Request) (*http.Response, error),
+) ([]CrossrefWork, error) {
+	params := url.Values{}
+	params.Add("query.bibliographic", title)
+	if author != "" {
+		params.Add("query.author", author)
+	}
+	params.Add("filter", publishedTypes)
+	params.Add("rows", fmt.Sprintf("%d", rows))
+	if config.UserEmail() != "" {
+		params.Add("mailto", config.UserEmail())
+	}
+	var resp CrossrefResponse
+	if err := getJSON(ctx, baseURL+"?"+encodeQuery(params), do, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Message.Items, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package crossref
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPublishedVersion(t *testing.T) {
+	do := fakeDo(map[string]string{
+		"/v1/works/10.2139%2Fssrn.1": `{"status":"ok","message":{
+			"DOI":"10.2139/ssrn.1",
+			"relation":{
+				"is-version-of":[{"id-type":"doi","id":"10.2139/ssrn.0","asserted-by":"subject"}],
+				"is-preprint-of":[{"id-type":"doi","id":"https://doi.org/10.1000/journal.1","asserted-by":"subject"}]}}}`,
+	})
+
+	doi, err := publishedVersion(context.Background(), "doi:10.2139/ssrn.1", do)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doi != "10.1000/journal.1" {
+		t.Fatalf("published version = %q", doi)
+	}
+
+	doi, err = publishedVersion(context.Background(), "10.2139/missing", do)
+	if err != nil || doi != "" {
+		t.Fatalf("unknown DOI: published version = %q, err = %v", doi, err)
+	}
+}
+
+func TestSearchPublishedFiltersTypes(t *testing.T) {
+	do := fakeDo(map[string]string{
+		"/v1/works?filter=" + publishedTypes: `{"status":"ok","message":{"items":[
+			{"DOI":"10.1000/journal.1","title":["A Solver"]}]}}`,
+	})
+
+	works, err := searchPublished(context.Background(), "A solver", "Smith", 5, do)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(works) != 1 || works[0].DOI != "10.1000/journal.1" {
+		t.Fatalf("works = %+v", works)
+	}
+}
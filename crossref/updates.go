@@ -0,0 +1,168 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package crossref
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/internal/wasmhttp"
+)
+
+// ErrDoesNotExist is returned for DOIs Crossref has no work for.
+var ErrDoesNotExist = errors.New("crossref work does not exist")
+
+// maxNotices bounds the notices requested for one work.
+const maxNotices = 20
+
+// updateRelations maps relation types some publishers deposit instead of
+// update metadata to the corresponding update type.
+var updateRelations = map[string]string{
+	"is-retracted-by": "retraction",
+	"is-corrected-by": "correction",
+	"is-withdrawn-by": "withdrawal",
+}
+
+type workResponse struct {
+	Status  string       `json:"status"`
+	Message CrossrefWork `json:"message"`
+}
+
+// Work retrieves the work registered for doi.
+func (c *Client) Work(ctx context.Context, doi string) (*CrossrefWork, error) {
+	return getWork(ctx, doi, c.Do)
+}
+
+// Updates returns the retractions, corrections and other notices Crossref
+// records for the work with the given DOI. They are gathered from the work's
+// updated-by and relation metadata and from notices whose update-to names the
+// work. DOIs unknown to Crossref have no updates.
+func (c *Client) Updates(ctx context.Context, doi string) ([]Update, error) {
+	return workUpdates(ctx, doi, c.Do)
+}
+
+func workUpdates(
+	ctx context.Context,
+	doi string,
+	do func(*http.Request) (*http.Response, error),
+) ([]Update, error) {
+	doi = bareDOI(doi)
+	work, err := getWork(ctx, doi, do)
+	if errors.Is(err, ErrDoesNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	updates := append([]Update(nil), work.UpdatedBy...)
+	for relation, items := range work.Relation {
+		kind, ok := updateRelations[relation]
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			if item.IDType == "doi" {
+				updates = append(updates, Update{DOI: item.ID, Type: kind, Source: "relation"})
+			}
+		}
+	}
+
+	params := url.Values{}
+	params.Add("filter", "updates:"+doi)
+	params.Add("rows", fmt.Sprintf("%d", maxNotices))
+	if config.UserEmail() != "" {
+		params.Add("mailto", config.UserEmail())
+	}
+	var notices CrossrefResponse
+	if err := getJSON(ctx, baseURL+"?"+encodeQuery(params), do, &notices); err != nil {
+		return nil, err
+	}
+	for _, notice := range notices.Message.Items {
+		for _, update := range notice.UpdateTo {
+			if !strings.EqualFold(bareDOI(update.DOI), doi) {
+				continue
+			}
+			update.DOI = notice.DOI
+			updates = append(updates, update)
+		}
+	}
+
+	return dedupeUpdates(updates), nil
+}
+
+func getWork(
+	ctx context.Context,
+	doi string,
+	do func(*http.Request) (*http.Response, error),
+) (*CrossrefWork, error) {
+	endpoint := baseURL + "/" + url.PathEscape(bareDOI(doi))
+	if config.UserEmail() != "" {
+		endpoint += "?" + encodeQuery(url.Values{"mailto": {config.UserEmail()}})
+	}
+	var resp workResponse
+	if err := getJSON(ctx, endpoint, do, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Message, nil
+}
+
+func getJSON(
+	ctx context.Context,
+	endpoint string,
+	do func(*http.Request) (*http.Response, error),
+	v any,
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", config.UserAgent())
+	wasmhttp.ConfigureRequest(req)
+	resp, err := do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrDoesNotExist
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// dedupeUpdates keeps the first update of each type from each notice.
+func dedupeUpdates(updates []Update) []Update {
+	seen := map[string]bool{}
+	out := []Update{}
+	for _, update := range updates {
+		key := strings.ToLower(update.Type + " " + bareDOI(update.DOI))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, update)
+	}
+	return out
+}
+
+func bareDOI(doi string) string {
+	doi = strings.TrimSpace(doi)
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "doi.org/", "doi:"} {
+		if len(doi) >= len(prefix) && strings.EqualFold(doi[:len(prefix)], prefix) {
+			return doi[len(prefix):]
+		}
+	}
+	return doi
+}
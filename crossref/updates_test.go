@@ -0,0 +1,64 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package crossref
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func fakeDo(routes map[string]string) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		key := req.URL.EscapedPath()
+		if filter := req.URL.Query().Get("filter"); filter != "" {
+			key += "?filter=" + filter
+		}
+		body, ok := routes[key]
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusNotFound
+			body = "Resource not found."
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+}
+
+func TestWorkUpdatesCombinesUpdatedByAndNotices(t *testing.T) {
+	do := fakeDo(map[string]string{
+		"/v1/works/10.1000%2Fpaper": `{"status":"ok","message":{
+			"DOI":"10.1000/paper",
+			"updated-by":[{"DOI":"10.1000/notice","type":"retraction","source":"retraction-watch","updated":{"date-parts":[[2023,3,4]]}}],
+			"relation":{"is-corrected-by":[{"id-type":"doi","id":"10.1000/erratum","asserted-by":"object"}]}}}`,
+		"/v1/works?filter=updates:10.1000/paper": `{"status":"ok","message":{"items":[
+			{"DOI":"10.1000/notice","update-to":[{"DOI":"10.1000/PAPER","type":"retraction"}]},
+			{"DOI":"10.1000/other","update-to":[{"DOI":"10.1000/unrelated","type":"correction"}]}]}}`,
+	})
+
+	updates, err := workUpdates(context.Background(), "https://doi.org/10.1000/paper", do)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("updates = %+v, want 2", updates)
+	}
+	if updates[0].DOI != "10.1000/notice" || updates[0].Type != "retraction" || updates[0].Date() != "2023-03-04" {
+		t.Fatalf("retraction = %+v", updates[0])
+	}
+	if updates[1].DOI != "10.1000/erratum" || updates[1].Type != "correction" {
+		t.Fatalf("correction = %+v", updates[1])
+	}
+}
+
+func TestWorkUpdatesUnknownDOI(t *testing.T) {
+	updates, err := workUpdates(context.Background(), "10.1000/missing", fakeDo(nil))
+	if err != nil || len(updates) != 0 {
+		t.Fatalf("updates = %+v, err = %v", updates, err)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package dblp
+
+// https://dblp.org/faq/How+to+use+the+dblp+search+API.html
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sandialabs/bibcheck/cassette"
+	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/httpcache"
+	"github.com/sandialabs/bibcheck/internal/wasmhttp"
+)
+
+const (
+	baseURL        = "https://dblp.org/search/publ/api"
+	defaultTimeout = 30 * time.Second
+)
+
+// Client is a client for the DBLP publication search API.
+type Client struct {
+	httpClient *http.Client
+	cache      *httpcache.Cache
+	baseURL    string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the HTTP client used for upstream requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) { c.httpClient = client }
+}
+
+// WithTransport sends upstream requests through t instead of
+// http.DefaultTransport.
+func WithTransport(t http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Timeout: c.httpClient.Timeout, Transport: cassette.Transport(t)}
+	}
+}
+
+// WithCache replaces the response cache; nil disables caching.
+func WithCache(cache *httpcache.Cache) Option {
+	return func(c *Client) { c.cache = cache }
+}
+
+// WithBaseURL replaces the publication search endpoint.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = baseURL }
+}
+
+// NewClient returns a DBLP client whose responses go through the default
+// HTTP cache.
+func NewClient(options ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{Timeout: defaultTimeout, Transport: cassette.Transport(nil)},
+		cache:      httpcache.Default(),
+		baseURL:    baseURL,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// SearchResponse is the JSON response of the publication search API.
+type SearchResponse struct {
+	Result struct {
+		Hits struct {
+			Total string `json:"@total"`
+			Hit   []Hit  `json:"hit"`
+		} `json:"hits"`
+	} `json:"result"`
+}
+
+type Hit struct {
+	Score string `json:"@score"`
+	ID    string `json:"@id"`
+	Info  Info   `json:"info"`
+}
+
+// Info is a DBLP publication record.
+type Info struct {
+	Authors struct {
+		Author oneOrMany[Author] `json:"author"`
+	} `json:"authors"`
+	Title string            `json:"title"`
+	Venue oneOrMany[string] `json:"venue"`
+	Year  string            `json:"year"`
+	Type  string            `json:"type"`
+	Key   string            `json:"key"`
+	DOI   string            `json:"doi"`
+	EE    oneOrMany[string] `json:"ee"`
+	URL   string            `json:"url"`
+}
+
+type Author struct {
+	PID  string `json:"@pid"`
+	Text string `json:"text"`
+}
+
+// DBLP disambiguates authors with the same name by a numeric suffix.
+var homonymSuffixRe = regexp.MustCompile(`\s+\d{4}$`)
+
+// AuthorNames returns the authors in order, without DBLP's homonym numbers.
+func (i *Info) AuthorNames() []string {
+	names := make([]string, 0, len(i.Authors.Author))
+	for _, author := range i.Authors.Author {
+		names = append(names, homonymSuffixRe.ReplaceAllString(author.Text, ""))
+	}
+	return names
+}
+
+// CleanTitle returns the title without DBLP's trailing period.
+func (i *Info) CleanTitle() string {
+	return strings.TrimSuffix(strings.TrimSpace(i.Title), ".")
+}
+
+// YearInt returns the publication year, or 0 if it is missing.
+func (i *Info) YearInt() int {
+	year, _ := strconv.Atoi(i.Year)
+	return year
+}
+
+// IsPreprint reports whether the record is DBLP's copy of an arXiv preprint.
+func (i *Info) IsPreprint() bool {
+	return i.Type == "Informal and Other Publications" || strings.HasPrefix(i.Key, "journals/corr/")
+}
+
+func (i *Info) ToString() string {
+	s := ""
+	if authors := i.AuthorNames(); len(authors) > 0 {
+		s += strings.Join(authors, ", ") + ". "
+	}
+	if title := i.CleanTitle(); title != "" {
+		s += "\"" + title + "\". "
+	}
+	if len(i.Venue) > 0 {
+		s += strings.Join(i.Venue, ", ") + ", "
+	}
+	if i.Year != "" {
+		s += i.Year + ". "
+	}
+	if i.DOI != "" {
+		s += "doi:" + i.DOI + ". "
+	}
+	return s
+}
+
+// SearchPublications searches DBLP for publications matching query, which
+// is usually a title optionally followed by author names.
+func (c *Client) SearchPublications(ctx context.Context, query string, hits int) (*SearchResponse, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("format", "json")
+	params.Set("h", strconv.Itoa(hits))
+	endpoint := c.baseURL + "?" + params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wasmhttp.FetchURL(endpoint), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", config.UserAgent())
+	wasmhttp.ConfigureRequest(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result SearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &result, nil
+}
+
+// oneOrMany decodes DBLP fields that are a bare value when there is one
+// element and an array otherwise.
+type oneOrMany[T any] []T
+
+func (o *oneOrMany[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var many []T
+		if err := json.Unmarshal(data, &many); err != nil {
+			return err
+		}
+		*o = many
+		return nil
+	}
+	var one T
+	if err := json.Unmarshal(data, &one); err != nil {
+		return err
+	}
+	*o = []T{one}
+	return nil
+}
+
+// do answers req from the cache, or else sends it and caches the response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if resp, ok := c.cache.Lookup(req); ok {
+		return resp, nil
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.cache.Store(req, resp)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package dblp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandialabs/bibcheck/httpcache"
+)
+
+const searchJSON = `{"result": {"hits": {"@total": "2", "hit": [
+	{"@score": "9", "@id": "1", "info": {
+		"authors": {"author": [
+			{"@pid": "1", "text": "Christian R. Trott"},
+			{"@pid": "2", "text": "Luc Berger-Vergiat 0001"}
+		]},
+		"title": "Kokkos 3: Programming Model Extensions for the Exascale Era.",
+		"venue": "IEEE Trans. Parallel Distributed Syst.",
+		"year": "2022",
+		"type": "Journal Articles",
+		"key": "journals/tpds/TrottLABBCEGHHIKLPPSSSWW22",
+		"doi": "10.1109/TPDS.2021.3097283",
+		"ee": ["https://doi.org/10.1109/TPDS.2021.3097283"]
+	}},
+	{"@score": "8", "@id": "2", "info": {
+		"authors": {"author": {"@pid": "1", "text": "Christian R. Trott"}},
+		"title": "A single author paper.",
+		"venue": ["SC", "CoRR"],
+		"year": "2020",
+		"type": "Informal and Other Publications",
+		"key": "journals/corr/abs-2001-00001"
+	}}
+]}}}`
+
+func TestSearchPublicationsDecodesOneOrMany(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		_, _ = w.Write([]byte(searchJSON))
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(WithBaseURL(server.URL)).SearchPublications(context.Background(), "kokkos 3", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "kokkos 3" {
+		t.Fatalf("query = %q", query)
+	}
+	hits := resp.Result.Hits.Hit
+	if len(hits) != 2 {
+		t.Fatalf("len(hits) = %d, want 2", len(hits))
+	}
+
+	first := hits[0].Info
+	if got := strings.Join(first.AuthorNames(), "; "); got != "Christian R. Trott; Luc Berger-Vergiat" {
+		t.Fatalf("authors = %q", got)
+	}
+	if got := first.CleanTitle(); got != "Kokkos 3: Programming Model Extensions for the Exascale Era" {
+		t.Fatalf("title = %q", got)
+	}
+	if first.YearInt() != 2022 || first.IsPreprint() {
+		t.Fatalf("unexpected first hit: %+v", first)
+	}
+
+	second := hits[1].Info
+	if len(second.AuthorNames()) != 1 || len(second.Venue) != 2 || !second.IsPreprint() {
+		t.Fatalf("unexpected second hit: %+v", second)
+	}
+}
+
+func TestSearchPublicationsUsesCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(searchJSON))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithCache(httpcache.New(t.TempDir(), time.Hour)))
+	for range 2 {
+		if _, err := client.SearchPublications(context.Background(), "Kokkos 3", 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("upstream requests = %d, want 1", requests)
+	}
+}
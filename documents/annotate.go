@@ -0,0 +1,135 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	pdfapi "github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Note is a comment to attach to a bibliography entry of a PDF.
+type Note struct {
+	// ID is the entry's bibliography ID, e.g. "12".
+	ID string
+	// Entry is the entry's text, used to find the page it starts on.
+	Entry string
+	// Title is shown as the note's author, e.g. "bibcheck: review".
+	Title string
+	Text  string
+	// Color is the note's color as a hex code, e.g. "#FF0000". Notes are
+	// yellow by default.
+	Color string
+}
+
+const (
+	noteSize   = 18.0
+	noteMargin = 8.0
+	noteGap    = 6.0
+)
+
+// entryLabel matches the label an entry starts with, e.g. "[12]" or "12.".
+var entryLabel = regexp.MustCompile(`^\s*(?:\[[^\]]*\]|\d+\.)\s*`)
+
+// AnnotatePDF returns pdf with a sticky note for each of notes, in the right
+// margin of the bibliography page its entry starts on. The page is found by
+// looking for the entry's text, then its "[ID]" label, on the pages of b; a
+// note whose entry cannot be found goes on the first page of b. Notes on the
+// same page are stacked from the top.
+func AnnotatePDF(pdf []byte, b *Bibliography, notes []Note) ([]byte, error) {
+	dims, err := pdfapi.PageDims(bytes.NewReader(pdf), pdfConfig())
+	if err != nil {
+		return nil, fmt.Errorf("read page dimensions: %w", err)
+	}
+	startPage, endPage := 1, len(dims)
+	if b != nil && b.StartPage >= 1 && b.StartPage <= len(dims) {
+		startPage = b.StartPage
+		endPage = min(max(b.EndPage, startPage), len(dims))
+	}
+
+	texts, err := pageTexts(pdf)
+	if err != nil {
+		return nil, err
+	}
+	pages := map[int]string{}
+	for page := startPage; page <= endPage; page++ {
+		pages[page] = texts[page]
+	}
+
+	annotations := map[int][]model.AnnotationRenderer{}
+	for _, note := range notes {
+		col := color.Yellow
+		if note.Color != "" {
+			if col, err = color.NewSimpleColorForHexCode(note.Color); err != nil {
+				return nil, fmt.Errorf("note for entry %s: %w", note.ID, err)
+			}
+		}
+		page := notePage(pages, startPage, endPage, note)
+		dim := dims[page-1]
+		rect := noteRect(dim, len(annotations[page]))
+		annotations[page] = append(annotations[page], model.NewTextAnnotation(
+			rect, 0, note.Text, "bibcheck-"+note.ID, "", model.AnnNoZoom+model.AnnNoRotate,
+			&col, note.Title, nil, nil, "", "", 0, 0, 0, false, "Comment"))
+	}
+	if len(annotations) == 0 {
+		return pdf, nil
+	}
+
+	var buf bytes.Buffer
+	if err := pdfapi.AddAnnotationsMap(bytes.NewReader(pdf), &buf, annotations, pdfConfig()); err != nil {
+		return nil, fmt.Errorf("add annotations: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// notePage returns the page among pages that note's entry starts on.
+func notePage(pages map[int]string, startPage, endPage int, note Note) int {
+	if start := compactText(entryLabel.ReplaceAllString(note.Entry, "")); start != "" {
+		start = start[:min(len(start), 32)]
+		for page := startPage; page <= endPage; page++ {
+			if strings.Contains(compactText(pages[page]), start) {
+				return page
+			}
+		}
+	}
+	if note.ID != "" {
+		label := "[" + note.ID + "]"
+		for page := startPage; page <= endPage; page++ {
+			if strings.Contains(pages[page], label) {
+				return page
+			}
+		}
+	}
+	return startPage
+}
+
+// compactText lowercases s and drops everything but letters and digits, so
+// that text compares equal however it was broken into lines, hyphenated or
+// spaced when the PDF was typeset.
+func compactText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// noteRect places the i-th note of a page in its right margin, in columns
+// from the top right corner.
+func noteRect(dim types.Dim, i int) types.Rectangle {
+	step := noteSize + noteGap
+	perColumn := max(1, int((dim.Height-2*noteMargin)/step))
+	column, row := i/perColumn, i%perColumn
+	x := dim.Width - noteMargin - noteSize - float64(column)*step
+	y := dim.Height - noteMargin - noteSize - float64(row)*step
+	return *types.NewRectangle(x, y, x+noteSize, y+noteSize)
+}
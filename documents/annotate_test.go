@@ -0,0 +1,65 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package documents
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	pdfapi "github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestAnnotatePDF(t *testing.T) {
+	pdf, err := os.ReadFile("../test/20231113_siefert_pmbs.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &Bibliography{StartPage: 7, EndPage: 8}
+	notes := []Note{
+		{ID: "2", Entry: "[2] 2018. HPL - A Portable Implementation of the High-Performance Linpack Benchmark for Distributed-Memory Computers.", Title: "bibcheck: review", Text: "no metadata found"},
+		// the extracted text drops the "fl" ligature, so this is found by its label
+		{ID: "33", Entry: "Mahesh Rajan, Doug Doerfler, and Simon Hammond. 2015. Trinity Benchmarks on Intel Xeon Phi (Knights Corner).", Title: "bibcheck: error", Text: "lookup failed", Color: "#FF0000"},
+		{ID: "35", Entry: "N Wichmann, C Nuss, P Carrier, R Olson, S Anderson, M Davis, R Baker. 2015. Performance on Trinity.", Title: "bibcheck: review", Text: "year should be 2016"},
+		{ID: "99", Entry: "Nobody. Not in this bibliography.", Title: "bibcheck: review", Text: "not found"},
+	}
+	out, err := AnnotatePDF(pdf, b, notes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	annotations, err := pdfapi.Annotations(bytes.NewReader(out), nil, pdfConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int][]string{7: {"no metadata found", "not found"}, 8: {"lookup failed", "year should be 2016"}}
+	for page, contents := range want {
+		text := annotations[page][model.AnnText]
+		got := map[string]bool{}
+		for _, ann := range text.Map {
+			got[ann.Content()] = true
+		}
+		if len(got) != len(contents) {
+			t.Errorf("page %d notes = %v, want %v", page, got, contents)
+		}
+		for _, c := range contents {
+			if !got[c] {
+				t.Errorf("page %d notes = %v, want %q", page, got, c)
+			}
+		}
+	}
+	if _, ok := annotations[1][model.AnnText]; ok {
+		t.Errorf("page 1 has notes: %v", annotations[1][model.AnnText])
+	}
+}
+
+func TestAnnotatePDFInvalidColor(t *testing.T) {
+	pdf, err := os.ReadFile("../test/20231113_siefert_pmbs.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AnnotatePDF(pdf, nil, []Note{{ID: "1", Color: "red"}}); err == nil {
+		t.Fatal("AnnotatePDF succeeded with an invalid color")
+	}
+}
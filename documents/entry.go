@@ -2,12 +2,20 @@
 // SPDX-License-Identifier: BSD-3-Clause
 package documents
 
+import "context"
+
 type EntryFromRawExtractor interface {
 	// Retrieve bib entry `id` from `b64` base-64 encoded PDF file
-	EntryFromRaw(b64 string, id int) (string, error)
+	EntryFromRaw(ctx context.Context, b64 string, id string) (string, error)
 }
 
 type EntryFromBibliographyExtractor interface {
 	// Retrieve bib entry `id` from a prepared bibliography artifact.
-	EntryFromBibliography(b *Bibliography, id int) (string, error)
+	EntryFromBibliography(ctx context.Context, b *Bibliography, id string) (string, error)
+}
+
+type EntryIDLister interface {
+	// List the identifiers the bibliography uses for its entries, e.g. "1"
+	// or "Smith1997", in bibliography order.
+	BibliographyEntryIDs(ctx context.Context, b *Bibliography) ([]string, error)
 }
@@ -3,6 +3,7 @@
 package documents
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -14,8 +15,8 @@ type Metadata struct {
 }
 
 type MetaExtractor interface {
-	PDFMetadata(content []byte) (*Metadata, error)
-	HTMLMetadata(content []byte) (*Metadata, error)
+	PDFMetadata(ctx context.Context, content []byte) (*Metadata, error)
+	HTMLMetadata(ctx context.Context, content []byte) (*Metadata, error)
 }
 
 func (m *Metadata) ToString() string {
@@ -0,0 +1,369 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package documents
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	pdfapi "github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+type TextExtractor interface {
+	// Retrieve the text of a whole PDF document.
+	DocumentText(ctx context.Context, pdf []byte) (string, error)
+}
+
+// BodyText returns the text of pdf up to the end of the first page of its
+// bibliography b, read by extractor or, if extractor is nil, by PDFText. Any
+// bibliography entries on that page are left for the caller to trim.
+func BodyText(ctx context.Context, pdf []byte, b *Bibliography, extractor TextExtractor) (string, error) {
+	body := pdf
+	if b != nil && b.StartPage > 1 {
+		var err error
+		if body, err = PDFSlicePages(pdf, 1, b.StartPage); err != nil {
+			return "", fmt.Errorf("slice body pages 1-%d error: %w", b.StartPage, err)
+		}
+	}
+	if extractor != nil {
+		return extractor.DocumentText(ctx, body)
+	}
+	return PDFText(body)
+}
+
+// PDFText extracts the text of pdf without any service, by reading the
+// text-showing operators of each page's content stream. It reads documents
+// typeset with simple fonts, such as most LaTeX output, well enough to find
+// citations, but it does not map glyphs of composite fonts back to text.
+func PDFText(pdf []byte) (string, error) {
+	pages, err := pageTexts(pdf)
+	if err != nil {
+		return "", err
+	}
+
+	numbers := make([]int, 0, len(pages))
+	for page := range pages {
+		numbers = append(numbers, page)
+	}
+	slices.Sort(numbers)
+	texts := make([]string, len(numbers))
+	for i, page := range numbers {
+		texts[i] = pages[page]
+	}
+	return strings.Join(texts, "\n\n"), nil
+}
+
+// pageTexts returns the text of each page of pdf, by page number.
+func pageTexts(pdf []byte) (map[int]string, error) {
+	pages := map[int]string{}
+	digest := func(r io.Reader, page int) error {
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		pages[page] = contentText(content)
+		return nil
+	}
+	if err := pdfapi.ExtractContent(bytes.NewReader(pdf), nil, digest, pdfConfig()); err != nil {
+		return nil, fmt.Errorf("extract pdf content: %w", err)
+	}
+	return pages, nil
+}
+
+// contentText returns the text shown by a content stream, with a line break
+// wherever the text moves to a new line.
+func contentText(content []byte) string {
+	var b strings.Builder
+	var operands []any
+	lineY := 0.0
+	newline := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+	}
+	space := func() {
+		if s := b.String(); s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+			b.WriteString(" ")
+		}
+	}
+
+	lex := &contentLexer{data: content}
+	for {
+		token, ok := lex.next()
+		if !ok {
+			break
+		}
+		op, isOp := token.(contentOperator)
+		if !isOp {
+			operands = append(operands, token)
+			continue
+		}
+		switch op {
+		case "Tj":
+			writeShown(&b, operands)
+		case "'", "\"":
+			newline()
+			writeShown(&b, operands)
+		case "TJ":
+			if len(operands) > 0 {
+				if array, ok := operands[len(operands)-1].([]any); ok {
+					for _, item := range array {
+						switch v := item.(type) {
+						case []byte:
+							b.WriteString(decodeShown(v))
+						case float64:
+							// a large negative adjustment is a word gap
+							if v < -200 {
+								space()
+							}
+						}
+					}
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if dy, ok := operands[len(operands)-1].(float64); ok && dy != 0 {
+					newline()
+				} else {
+					space()
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				if y, ok := operands[len(operands)-1].(float64); ok {
+					if y != lineY {
+						newline()
+					} else {
+						space()
+					}
+					lineY = y
+				}
+			}
+		case "T*":
+			newline()
+		}
+		operands = operands[:0]
+	}
+	return b.String()
+}
+
+func writeShown(b *strings.Builder, operands []any) {
+	if len(operands) == 0 {
+		return
+	}
+	if s, ok := operands[len(operands)-1].([]byte); ok {
+		b.WriteString(decodeShown(s))
+	}
+}
+
+// decodeShown maps the bytes of a shown string to text, assuming a
+// Latin-1-like encoding, and expands the ligature slots of TeX's OT1 fonts.
+func decodeShown(s []byte) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch {
+		case c == 0x0b:
+			b.WriteString("ff")
+		case c == 0x0c:
+			b.WriteString("fi")
+		case c == 0x0d:
+			b.WriteString("fl")
+		case c == 0x0e:
+			b.WriteString("ffi")
+		case c == 0x0f:
+			b.WriteString("ffl")
+		case c < 0x20 || c == 0x7f:
+			// glyph codes of fonts without a usable encoding
+		default:
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
+
+// contentOperator is an operator of a content stream, e.g. "Tj".
+type contentOperator string
+
+// contentLexer splits a content stream into operands (numbers, strings,
+// names and arrays) and operators.
+type contentLexer struct {
+	data []byte
+	pos  int
+}
+
+func (l *contentLexer) next() (any, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, false
+	}
+	switch c := l.data[l.pos]; c {
+	case '(':
+		l.pos++
+		return l.literal(), true
+	case '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return contentOperator("<<"), true
+		}
+		l.pos++
+		return l.hex(), true
+	case '>':
+		l.pos++
+		if l.pos < len(l.data) && l.data[l.pos] == '>' {
+			l.pos++
+		}
+		return contentOperator(">>"), true
+	case '[':
+		l.pos++
+		array := []any{}
+		for {
+			l.skipSpace()
+			if l.pos >= len(l.data) {
+				return array, true
+			}
+			if l.data[l.pos] == ']' {
+				l.pos++
+				return array, true
+			}
+			item, ok := l.next()
+			if !ok {
+				return array, true
+			}
+			array = append(array, item)
+		}
+	case ']', '{', '}':
+		l.pos++
+		return contentOperator(string(c)), true
+	case '/':
+		start := l.pos
+		l.pos++
+		l.regular()
+		return string(l.data[start:l.pos]), true
+	}
+
+	start := l.pos
+	l.regular()
+	if l.pos == start {
+		// an unexpected delimiter
+		l.pos++
+		return contentOperator(string(l.data[start])), true
+	}
+	word := string(l.data[start:l.pos])
+	if n, err := strconv.ParseFloat(word, 64); err == nil {
+		return n, true
+	}
+	if word == "ID" {
+		l.skipInlineImage()
+	}
+	return contentOperator(word), true
+}
+
+func (l *contentLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch l.data[l.pos] {
+		case ' ', '\t', '\r', '\n', '\f', 0:
+			l.pos++
+		case '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *contentLexer) regular() {
+	for l.pos < len(l.data) && !strings.ContainsRune(" \t\r\n\f\x00()<>[]{}/%", rune(l.data[l.pos])) {
+		l.pos++
+	}
+}
+
+func (l *contentLexer) literal() []byte {
+	var s []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return s
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				// a line continuation
+				if e == '\r' && l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					n := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						n = n*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(n)
+				} else {
+					c = e
+				}
+			}
+		}
+		s = append(s, c)
+	}
+	return s
+}
+
+func (l *contentLexer) hex() []byte {
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; strings.IndexByte("0123456789abcdefABCDEF", c) >= 0 {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	s := make([]byte, len(digits)/2)
+	for i := range s {
+		n, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		s[i] = byte(n)
+	}
+	return s
+}
+
+// skipInlineImage skips the binary data of an inline image, up to its EI.
+func (l *contentLexer) skipInlineImage() {
+	if end := bytes.Index(l.data[l.pos:], []byte("EI")); end >= 0 {
+		l.pos += end + 2
+	} else {
+		l.pos = len(l.data)
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package documents
+
+import "testing"
+
+func TestContentText(t *testing.T) {
+	content := []byte(`BT /F1 10 Tf 72 720 Td (As a Top 500 ) Tj [(\[)-333(17)-333(\])] TJ
+0 -12 Td [(class computer, see )-500 <5B325D>] TJ
+T* (\(Smith, 2020\)\0140nal) Tj ET
+BT 1 0 0 1 72 600 Tm <4869> Tj 1 0 0 1 90 600 Tm (there) Tj ET`)
+	want := "As a Top 500 [ 17 ]\nclass computer, see [2]\n(Smith, 2020)fi0nal\nHi there"
+	if got := contentText(content); got != want {
+		t.Fatalf("contentText = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package doi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/internal/wasmhttp"
+)
+
+// CSLContentType asks doi.org for the registered metadata of a DOI as
+// CSL-JSON, which Crossref, DataCite and mEDRA all provide.
+const CSLContentType = "application/vnd.citationstyles.csl+json"
+
+// CSL is the subset of a CSL-JSON item bibcheck compares against entries.
+type CSL struct {
+	DOI            string    `json:"DOI"`
+	Type           string    `json:"type"`
+	Title          cslString `json:"title"`
+	ContainerTitle cslString `json:"container-title"`
+	Publisher      string    `json:"publisher"`
+	Author         []CSLName `json:"author"`
+	Issued         struct {
+		DateParts [][]any `json:"date-parts"`
+	} `json:"issued"`
+	URL string `json:"URL"`
+}
+
+// CSLName is a CSL-JSON name. Organizations have only Literal.
+type CSLName struct {
+	Given   string `json:"given"`
+	Family  string `json:"family"`
+	Literal string `json:"literal"`
+}
+
+func (n CSLName) String() string {
+	if n.Literal != "" {
+		return n.Literal
+	}
+	return strings.TrimSpace(n.Given + " " + n.Family)
+}
+
+// cslString accepts a CSL-JSON variable given as a string or, as some
+// registration agencies do, as a list of strings.
+type cslString string
+
+func (s *cslString) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = cslString(str)
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	if len(list) > 0 {
+		*s = cslString(list[0])
+	}
+	return nil
+}
+
+// Authors returns the display names of the authors.
+func (c *CSL) Authors() []string {
+	names := make([]string, 0, len(c.Author))
+	for _, author := range c.Author {
+		if name := author.String(); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Year returns the year the work was issued, or 0 if unknown.
+func (c *CSL) Year() int {
+	if len(c.Issued.DateParts) == 0 || len(c.Issued.DateParts[0]) == 0 {
+		return 0
+	}
+	// agencies give date parts as numbers or numeric strings
+	var year int
+	switch v := c.Issued.DateParts[0][0].(type) {
+	case float64:
+		year = int(v)
+	case string:
+		fmt.Sscanf(v, "%d", &year)
+	}
+	return year
+}
+
+func (c *CSL) ToString() string {
+	s := ""
+	if authors := c.Authors(); len(authors) > 0 {
+		s += strings.Join(authors, ", ") + ", "
+	}
+	if c.Title != "" {
+		s += "\"" + string(c.Title) + "\", "
+	}
+	if c.ContainerTitle != "" {
+		s += string(c.ContainerTitle) + ", "
+	} else if c.Publisher != "" {
+		s += c.Publisher + ", "
+	}
+	if year := c.Year(); year != 0 {
+		s += fmt.Sprintf("%d. ", year)
+	}
+	if c.DOI != "" {
+		s += "doi:" + c.DOI + ". "
+	}
+	return s
+}
+
+// GetCSL is like Client.GetCSL with the default client.
+func GetCSL(ctx context.Context, doi string) (*CSL, error) {
+	return NewClient().GetCSL(ctx, doi)
+}
+
+// GetCSL retrieves the metadata registered for doi through content
+// negotiation. It returns DoesNotExistError for unregistered DOIs.
+func (c *Client) GetCSL(ctx context.Context, doi string) (*CSL, error) {
+	doi = strings.TrimPrefix(doi, "https://doi.org/")
+	doi = strings.TrimPrefix(doi, "http://doi.org/")
+	doi = strings.TrimPrefix(doi, "doi.org/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://doi.org/"+doi, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", CSLContentType)
+	req.Header.Set("User-Agent", config.UserAgent())
+	wasmhttp.ConfigureRequest(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, DoesNotExistError
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("doi.org returned %s", resp.Status)
+	case !strings.Contains(resp.Header.Get("Content-Type"), "json"):
+		// agencies without content negotiation redirect to a landing page
+		return nil, fmt.Errorf("no CSL-JSON metadata for %s (got %s)", doi, resp.Header.Get("Content-Type"))
+	}
+
+	var csl CSL
+	if err := json.NewDecoder(resp.Body).Decode(&csl); err != nil {
+		return nil, fmt.Errorf("failed to parse CSL-JSON: %w", err)
+	}
+	return &csl, nil
+}
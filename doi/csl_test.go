@@ -0,0 +1,32 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package doi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeCSL(t *testing.T) {
+	for name, data := range map[string]string{
+		"crossref": `{"DOI":"10.1016/j.parco.2018.05.006","type":"article-journal",
+			"title":"Hardware topology management in MPI applications through hierarchical communicators",
+			"container-title":"Parallel Computing",
+			"author":[{"given":"Brice","family":"Goglin"},{"literal":"Inria"}],
+			"issued":{"date-parts":[[2018,9]]}}`,
+		"datacite": `{"DOI":"10.1016/j.parco.2018.05.006","type":"article-journal",
+			"title":["Hardware topology management in MPI applications through hierarchical communicators"],
+			"container-title":["Parallel Computing"],
+			"author":[{"given":"Brice","family":"Goglin"},{"literal":"Inria"}],
+			"issued":{"date-parts":[["2018"]]}}`,
+	} {
+		var csl CSL
+		if err := json.Unmarshal([]byte(data), &csl); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		want := `Brice Goglin, Inria, "Hardware topology management in MPI applications through hierarchical communicators", Parallel Computing, 2018. doi:10.1016/j.parco.2018.05.006. `
+		if got := csl.ToString(); got != want {
+			t.Errorf("%s: ToString() = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -3,6 +3,7 @@
 package doi
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/httpcache"
 	"github.com/sandialabs/bibcheck/internal/wasmhttp"
 )
 
@@ -39,31 +41,70 @@ type ValueData struct {
 	Value  interface{} `json:"value"`
 }
 
+// Client resolves DOIs through doi.org.
+type Client struct {
+	httpClient *http.Client
+	transport  http.RoundTripper
+	cache      *httpcache.Cache
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTransport sends requests the cache cannot answer through t instead of
+// http.DefaultTransport.
+func WithTransport(t http.RoundTripper) Option {
+	return func(c *Client) { c.transport = t }
+}
+
+// WithCache replaces the response cache; nil disables caching.
+func WithCache(cache *httpcache.Cache) Option {
+	return func(c *Client) { c.cache = cache }
+}
+
+// NewClient returns a client whose responses go through the default HTTP
+// cache. The package-level functions use a client with no options.
+func NewClient(options ...Option) *Client {
+	c := &Client{cache: httpcache.Default()}
+	for _, option := range options {
+		option(c)
+	}
+	// answered from the cache when possible
+	c.httpClient = c.cache.Client(30*time.Second, c.transport)
+	return c
+}
+
 // ResolveDOI resolves a DOI and returns all record elements
-func ResolveDOI(doi string) (*DOIResponse, error) {
-	return resolveDOIWithParams(doi, nil)
+func (c *Client) ResolveDOI(ctx context.Context, doi string) (*DOIResponse, error) {
+	return c.resolve(ctx, doi, nil)
+}
+
+// ResolveDOI resolves a DOI with the default client and returns all record
+// elements
+func ResolveDOI(ctx context.Context, doi string) (*DOIResponse, error) {
+	return NewClient().ResolveDOI(ctx, doi)
 }
 
 // ResolveDOIByType resolves a DOI and returns only elements of specified types
-func ResolveDOIByType(doi string, types ...string) (*DOIResponse, error) {
+func ResolveDOIByType(ctx context.Context, doi string, types ...string) (*DOIResponse, error) {
 	params := url.Values{}
 	for _, t := range types {
 		params.Add("type", t)
 	}
-	return resolveDOIWithParams(doi, params)
+	return NewClient().resolve(ctx, doi, params)
 }
 
 // ResolveDOIByIndex resolves a DOI and returns only elements at specified indexes
-func ResolveDOIByIndex(doi string, indexes ...int) (*DOIResponse, error) {
+func ResolveDOIByIndex(ctx context.Context, doi string, indexes ...int) (*DOIResponse, error) {
 	params := url.Values{}
 	for _, idx := range indexes {
 		params.Add("index", fmt.Sprintf("%d", idx))
 	}
-	return resolveDOIWithParams(doi, params)
+	return NewClient().resolve(ctx, doi, params)
 }
 
 // ResolveDOIWithOptions resolves a DOI with custom options
-func ResolveDOIWithOptions(doi string, options DOIOptions) (*DOIResponse, error) {
+func ResolveDOIWithOptions(ctx context.Context, doi string, options DOIOptions) (*DOIResponse, error) {
 	params := url.Values{}
 
 	if options.Pretty {
@@ -85,7 +126,7 @@ func ResolveDOIWithOptions(doi string, options DOIOptions) (*DOIResponse, error)
 		params.Add("index", fmt.Sprintf("%d", idx))
 	}
 
-	return resolveDOIWithParams(doi, params)
+	return NewClient().resolve(ctx, doi, params)
 }
 
 // DOIOptions represents optional parameters for DOI resolution
@@ -102,8 +143,8 @@ var (
 	DoesNotExistError = errors.New("doi does not exist")
 )
 
-// resolveDOIWithParams performs the actual HTTP request to resolve a DOI
-func resolveDOIWithParams(doi string, params url.Values) (*DOIResponse, error) {
+// resolve performs the actual HTTP request to resolve a DOI
+func (c *Client) resolve(ctx context.Context, doi string, params url.Values) (*DOIResponse, error) {
 	// Clean the DOI (remove any leading "https://doi.org/" if present)
 	doi = strings.TrimPrefix(doi, "https://doi.org/")
 	doi = strings.TrimPrefix(doi, "http://doi.org/")
@@ -115,13 +156,8 @@ func resolveDOIWithParams(doi string, params url.Values) (*DOIResponse, error) {
 		baseURL += "?" + params.Encode()
 	}
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
-
 	// Create request
-	req, err := http.NewRequest("GET", baseURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -131,7 +167,7 @@ func resolveDOIWithParams(doi string, params url.Values) (*DOIResponse, error) {
 	wasmhttp.ConfigureRequest(req)
 
 	// Execute request
-	resp, err := client.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
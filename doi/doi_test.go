@@ -1,13 +1,14 @@
 package doi
 
 import (
+	"context"
 	"fmt"
 	"testing"
 )
 
 func TestDoi(t *testing.T) {
 
-	record, err := ResolveDOI("https://doi.org/10.1016/j.parco.2018.05.006")
+	record, err := ResolveDOI(context.Background(), "https://doi.org/10.1016/j.parco.2018.05.006")
 	if err != nil {
 		t.Fatalf("ResolveDOI error: %v", err)
 	}
@@ -0,0 +1,228 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package duplicates finds bibliography entries that cite the same work,
+// such as an arXiv preprint and its published version, or one paper
+// formatted two ways by different .bib files.
+package duplicates
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/lookup"
+	"github.com/sandialabs/bibcheck/match"
+	"github.com/sandialabs/bibcheck/retraction"
+)
+
+const (
+	// TitleThreshold is the minimum title score for two entries with the
+	// same authors to be the same work. It is stricter than a match, since
+	// series like "Part I" and "Part II" differ in a character or two.
+	TitleThreshold = 0.95
+	// TextThreshold is the minimum share of words two entries without
+	// metadata must have in common to be the same work.
+	TextThreshold = 0.8
+)
+
+var (
+	arxivDOIRe     = regexp.MustCompile(`^10\.48550/arxiv\.(.+)$`)
+	arxivVersionRe = regexp.MustCompile(`v\d+$`)
+)
+
+// Entry is a bibliography entry with what is known of the work it cites.
+type Entry struct {
+	ID   string
+	Text string
+	// DOIs and ArxivIDs are from the entry text and the records matched
+	// for it.
+	DOIs     []string
+	ArxivIDs []string
+	// Title and Authors are from the first record matched for the entry.
+	Title   string
+	Authors []string
+}
+
+// Group is a set of entries that cite the same work.
+type Group struct {
+	// IDs are in bibliography order.
+	IDs []string
+	// Reasons say why the entries were grouped, e.g. "same DOI 10.1/x".
+	Reasons []string
+}
+
+// FromResult describes the entry with the given ID from its lookup result.
+func FromResult(id string, r *lookup.Result) Entry {
+	e := Entry{ID: id, Text: r.Text}
+	e.addDOI(entries.ExtractDOI(r.Text))
+	e.addArxiv(entries.ExtractArxiv(r.Text))
+	for _, record := range r.Records() {
+		e.addDOI(record.DOI)
+		if record.Source == "arXiv" {
+			e.addArxiv(record.ID)
+		}
+		if e.Title == "" && record.Title != "" {
+			e.Title = record.Title
+			e.Authors = record.Authors
+		}
+	}
+	return e
+}
+
+func (e *Entry) addDOI(doi string) {
+	doi = retraction.NormalizeDOI(doi)
+	if m := arxivDOIRe.FindStringSubmatch(doi); m != nil {
+		// arXiv registers DOIs for its preprints
+		e.addArxiv(m[1])
+	}
+	if doi != "" && !slices.Contains(e.DOIs, doi) {
+		e.DOIs = append(e.DOIs, doi)
+	}
+}
+
+func (e *Entry) addArxiv(id string) {
+	id = normalizeArxiv(id)
+	if id != "" && !slices.Contains(e.ArxivIDs, id) {
+		e.ArxivIDs = append(e.ArxivIDs, id)
+	}
+}
+
+// normalizeArxiv returns an arXiv identifier or URL as a bare, unversioned
+// identifier, so every version of a preprint compares equal.
+func normalizeArxiv(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for _, prefix := range []string{"https://arxiv.org/abs/", "http://arxiv.org/abs/", "https://arxiv.org/pdf/", "arxiv:"} {
+		id = strings.TrimPrefix(id, prefix)
+	}
+	id = strings.TrimSuffix(id, ".pdf")
+	return arxivVersionRe.ReplaceAllString(id, "")
+}
+
+// Find returns the groups of entries that cite the same work, in the order
+// of their first entry. Entries are grouped when they share a DOI or arXiv
+// identifier, when one's matched title and authors appear in the other, or,
+// for entries without metadata, when their texts are nearly identical.
+func Find(list []Entry) []Group {
+	parent := make([]int, len(list))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+
+	reasons := map[int][]string{}
+	for i := range list {
+		for j := i + 1; j < len(list); j++ {
+			reason := sameWork(list[i], list[j])
+			if reason == "" {
+				continue
+			}
+			ri, rj := root(i), root(j)
+			if ri != rj {
+				parent[rj] = ri
+				reasons[ri] = append(reasons[ri], reasons[rj]...)
+				delete(reasons, rj)
+			}
+			if !slices.Contains(reasons[ri], reason) {
+				reasons[ri] = append(reasons[ri], reason)
+			}
+		}
+	}
+
+	groups := []Group{}
+	index := map[int]int{}
+	for i, entry := range list {
+		r := root(i)
+		if _, ok := reasons[r]; !ok {
+			continue
+		}
+		g, ok := index[r]
+		if !ok {
+			g = len(groups)
+			index[r] = g
+			groups = append(groups, Group{Reasons: reasons[r]})
+		}
+		groups[g].IDs = append(groups[g].IDs, entry.ID)
+	}
+	return groups
+}
+
+// sameWork returns why a and b cite the same work, or "" if they do not
+// appear to. When both titles are known they must be equal; otherwise the
+// known title must appear in the other entry.
+func sameWork(a, b Entry) string {
+	for _, doi := range a.DOIs {
+		if slices.Contains(b.DOIs, doi) {
+			return "same DOI " + doi
+		}
+	}
+	for _, id := range a.ArxivIDs {
+		if slices.Contains(b.ArxivIDs, id) {
+			return "same arXiv ID " + id
+		}
+	}
+	switch {
+	case a.Title != "" && b.Title != "":
+		// windows of a longer title would hide the "II" of a series
+		if match.Normalize(a.Title) == match.Normalize(b.Title) && titleIn(a, b.Text) {
+			return fmt.Sprintf("same title and authors: %q", a.Title)
+		}
+	case a.Title != "" && titleIn(a, b.Text):
+		return fmt.Sprintf("same title and authors: %q", a.Title)
+	case b.Title != "" && titleIn(b, a.Text):
+		return fmt.Sprintf("same title and authors: %q", b.Title)
+	}
+	if a.Title == "" && b.Title == "" && textSimilarity(a.Text, b.Text) >= TextThreshold {
+		return "near-identical text"
+	}
+	return ""
+}
+
+// titleIn reports whether the title and authors matched for e appear in text.
+func titleIn(e Entry, text string) bool {
+	m := match.Compare(match.Citation{Text: text}, match.Work{Title: e.Title, Authors: e.Authors})
+	for _, f := range m.Fields {
+		switch f.Name {
+		case match.FieldTitle:
+			if f.Score < TitleThreshold {
+				return false
+			}
+		case match.FieldAuthors:
+			if f.Verdict == match.VerdictMismatch {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// textSimilarity is the share of distinct words two texts have in common.
+func textSimilarity(a, b string) float64 {
+	wordsA, wordsB := words(a), words(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func words(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range match.Tokens(s) {
+		set[w] = true
+	}
+	return set
+}
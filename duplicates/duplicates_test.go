@@ -0,0 +1,74 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package duplicates
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sandialabs/bibcheck/doi"
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/lookup"
+)
+
+func TestFind(t *testing.T) {
+	list := []Entry{
+		{ID: "1", Text: "B. Goglin et al. Hardware topology management in MPI applications through hierarchical communicators. Parallel Computing 76, 2018. doi:10.1016/J.PARCO.2018.05.006"},
+		{ID: "2", Text: "L. Lamport. LaTeX: A Document Preparation System. Addison-Wesley, 1994."},
+		{ID: "3", Text: "Goglin, B., Jeannot, E., Mansouri, F., Mercier, G. Hardware topology management... https://doi.org/10.1016/j.parco.2018.05.006"},
+		{ID: "4", Text: "A. Vaswani et al. Attention is all you need. arXiv:1706.03762v5, 2017.", ArxivIDs: []string{"1706.03762"}},
+		{ID: "5", Text: "Vaswani, A. et al. Attention is all you need. In NeurIPS, 2017.",
+			Title: "Attention Is All You Need", Authors: []string{"Ashish Vaswani", "Noam Shazeer"}},
+		{ID: "6", Text: "Vaswani, A. Attention is all you need. https://arxiv.org/abs/1706.03762"},
+		{ID: "7", Text: "Leslie Lamport, LaTeX: a document preparation system, Addison-Wesley 1994"},
+	}
+	for i := range list {
+		if list[i].DOIs == nil {
+			list[i].addDOI(entries.ExtractDOI(list[i].Text))
+		}
+		list[i].addArxiv(entries.ExtractArxiv(list[i].Text))
+	}
+
+	got := Find(list)
+	want := []Group{
+		{IDs: []string{"1", "3"}, Reasons: []string{"same DOI 10.1016/j.parco.2018.05.006"}},
+		{IDs: []string{"2", "7"}, Reasons: []string{"near-identical text"}},
+		{IDs: []string{"4", "5", "6"}, Reasons: []string{`same title and authors: "Attention Is All You Need"`, "same arXiv ID 1706.03762"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Find = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestFindKeepsSeriesApart(t *testing.T) {
+	list := []Entry{
+		{ID: "1", Text: "J. Smith. Sparse solvers, part I. SIAM J. Sci. Comput., 2019.", Title: "Sparse solvers, part I", Authors: []string{"John Smith"}},
+		{ID: "2", Text: "J. Smith. Sparse solvers, part II: applications. SIAM J. Sci. Comput., 2020.", Title: "Sparse solvers, part II: applications", Authors: []string{"John Smith"}},
+	}
+	if got := Find(list); len(got) != 0 {
+		t.Fatalf("Find = %+v, want no groups", got)
+	}
+}
+
+func TestFromResultUsesMatchedRecords(t *testing.T) {
+	work := &doi.CSL{}
+	if err := json.Unmarshal([]byte(`{"DOI":"10.48550/arXiv.1706.03762","title":"Attention Is All You Need","author":[{"given":"Ashish","family":"Vaswani"}]}`), work); err != nil {
+		t.Fatal(err)
+	}
+	r := &lookup.Result{Text: "A. Vaswani et al. Attention is all you need. https://doi.org/10.48550/arXiv.1706.03762"}
+	r.DOIOrg.ID = "10.48550/arXiv.1706.03762"
+	r.DOIOrg.Found = true
+	r.DOIOrg.Work = work
+
+	e := FromResult("4", r)
+	if !reflect.DeepEqual(e.DOIs, []string{"10.48550/arxiv.1706.03762"}) {
+		t.Fatalf("DOIs = %v", e.DOIs)
+	}
+	if !reflect.DeepEqual(e.ArxivIDs, []string{"1706.03762"}) {
+		t.Fatalf("ArxivIDs = %v", e.ArxivIDs)
+	}
+	if e.Title != "Attention Is All You Need" || !reflect.DeepEqual(e.Authors, []string{"Ashish Vaswani"}) {
+		t.Fatalf("Title, Authors = %q, %v", e.Title, e.Authors)
+	}
+}
@@ -1,6 +1,7 @@
 package elsevier
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -78,13 +79,14 @@ func (q *Query) toString() string {
 // ArticleMetadataRaw searches performs an Article Metadata query in ScienceDirect
 //
 // query: https://dev.elsevier.com/sd_article_meta_tips.html
-func (c *Client) ArticleMetadataRaw(query string, params *ArticleMetadataParams) (*ArticleMetadataResponse, error) {
+func (c *Client) ArticleMetadataRaw(ctx context.Context, query string, params *ArticleMetadataParams) (*ArticleMetadataResponse, error) {
 	endpoint := fmt.Sprintf("%s/content/metadata/article", c.baseUrl)
 
 	// Build query parameters
 	queryParams := url.Values{}
+	// the key goes in the X-ELS-APIKey header only, so that it is not
+	// written to the HTTP cache with the request URL
 	queryParams.Set("query", query)
-	queryParams.Set("apiKey", c.apiKey)
 
 	if params != nil {
 		if params.View != "" {
@@ -106,7 +108,7 @@ func (c *Client) ArticleMetadataRaw(query string, params *ArticleMetadataParams)
 
 	// Create request
 	reqURL := fmt.Sprintf("%s?%s", endpoint, queryParams.Encode())
-	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -118,8 +120,7 @@ func (c *Client) ArticleMetadataRaw(query string, params *ArticleMetadataParams)
 	wasmhttp.ConfigureRequest(req)
 
 	// Execute request
-	client := &http.Client{Timeout: c.timeout}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http client error: %w", err)
 	}
@@ -144,6 +145,6 @@ func (c *Client) ArticleMetadataRaw(query string, params *ArticleMetadataParams)
 // ArticleMetadata searches for articles in ScienceDirect
 //
 // query: https://dev.elsevier.com/sd_article_meta_tips.html
-func (c *Client) ArticleMetadata(query *Query, params *ArticleMetadataParams) (*ArticleMetadataResponse, error) {
-	return c.ArticleMetadataRaw(query.toString(), params)
+func (c *Client) ArticleMetadata(ctx context.Context, query *Query, params *ArticleMetadataParams) (*ArticleMetadataResponse, error) {
+	return c.ArticleMetadataRaw(ctx, query.toString(), params)
 }
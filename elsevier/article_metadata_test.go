@@ -1,6 +1,7 @@
 package elsevier
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -79,7 +80,7 @@ func TestArticleMetadata(t *testing.T) {
 
 	client := NewClient(apiKey, WithTimeout(10*time.Second))
 
-	_, err := client.ArticleMetadata(&Query{
+	_, err := client.ArticleMetadata(context.Background(), &Query{
 		Authors: []string{"IDO, NOTEXIST"},
 	}, nil)
 	if err != nil {
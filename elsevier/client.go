@@ -3,13 +3,18 @@
 package elsevier
 
 import (
+	"net/http"
 	"time"
+
+	"github.com/sandialabs/bibcheck/cassette"
+	"github.com/sandialabs/bibcheck/httpcache"
 )
 
 type Client struct {
 	apiKey  string
 	baseUrl string
 	timeout time.Duration
+	cache   *httpcache.Cache
 }
 
 type ClientOpt func(*Client)
@@ -19,6 +24,7 @@ func NewClient(apiKey string, options ...ClientOpt) *Client {
 		apiKey:  apiKey,
 		baseUrl: "https://api.elsevier.com",
 		timeout: 10 * time.Second,
+		cache:   httpcache.Default(),
 	}
 	for _, o := range options {
 		o(c)
@@ -31,3 +37,15 @@ func WithTimeout(t time.Duration) ClientOpt {
 		c.timeout = t
 	}
 }
+
+// WithCache replaces the response cache; nil disables caching. Only article
+// metadata is cached, since searches are PUT requests.
+func WithCache(cache *httpcache.Cache) ClientOpt {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+func (c *Client) httpClient() *http.Client {
+	return &http.Client{Timeout: c.timeout, Transport: c.cache.Transport(cassette.Transport(nil))}
+}
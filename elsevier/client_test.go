@@ -0,0 +1,35 @@
+package elsevier
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sandialabs/bibcheck/httpcache"
+)
+
+func TestArticleMetadataUsesCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-ELS-APIKey") != "key" || r.URL.Query().Has("apiKey") {
+			t.Errorf("unexpected API key in request: header %q, query %q", r.Header.Get("X-ELS-APIKey"), r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithCache(httpcache.New(t.TempDir(), time.Hour)))
+	client.baseUrl = server.URL
+	for range 2 {
+		if _, err := client.ArticleMetadataRaw(context.Background(), "title(Kokkos)", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("upstream requests = %d, want 1", requests)
+	}
+}
@@ -2,6 +2,7 @@ package elsevier
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -89,7 +90,7 @@ func (sr *SearchResult) ToString() string {
 // Search searches for articles in ScienceDirect API v2
 //
 // query: https://dev.elsevier.com/sd_article_meta_tips.html
-func (c *Client) Search(query *SearchQuery) (*SearchResponse, error) {
+func (c *Client) Search(ctx context.Context, query *SearchQuery) (*SearchResponse, error) {
 	endpoint := fmt.Sprintf("%s/content/search/sciencedirect", c.baseUrl)
 
 	// authors field limited to 250 characters. Trim to 2
@@ -113,7 +114,7 @@ func (c *Client) Search(query *SearchQuery) (*SearchResponse, error) {
 
 	// Create request
 	reqURL := fmt.Sprintf("%s?%s", endpoint, queryParams.Encode())
-	req, err := http.NewRequest(http.MethodPut, reqURL, bytes.NewReader(queryData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, reqURL, bytes.NewReader(queryData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -125,8 +126,7 @@ func (c *Client) Search(query *SearchQuery) (*SearchResponse, error) {
 	wasmhttp.ConfigureRequest(req)
 
 	// Execute request
-	client := &http.Client{Timeout: c.timeout}
-	resp, err := client.Do(req)
+	resp, err := c.httpClient().Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http client error: %w", err)
 	}
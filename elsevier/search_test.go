@@ -1,6 +1,7 @@
 package elsevier
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
@@ -16,7 +17,7 @@ func TestSearch(t *testing.T) {
 
 	client := NewClient(apiKey, WithTimeout(10*time.Second))
 
-	_, err := client.Search(&SearchQuery{
+	_, err := client.Search(context.Background(), &SearchQuery{
 		Authors: "IDO, NOTEXIST",
 	})
 	if err != nil {
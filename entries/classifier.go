@@ -2,6 +2,8 @@
 // SPDX-License-Identifier: BSD-3-Clause
 package entries
 
+import "context"
+
 const (
 	KindBook                  string = "book"
 	KindScientificPublication string = "scientific_publication"
@@ -11,5 +13,5 @@ const (
 )
 
 type Classifier interface {
-	Classify(text string) (string, error)
+	Classify(ctx context.Context, text string) (string, error)
 }
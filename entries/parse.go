@@ -2,6 +2,8 @@
 // SPDX-License-Identifier: BSD-3-Clause
 package entries
 
+import "context"
+
 type Software struct {
 	Name        string   `json:"name"`
 	Developers  []string `json:"developers"`
@@ -20,10 +22,10 @@ type Authors struct {
 }
 
 type Parser interface {
-	ParseURL(entry string) (string, error)
-	ParseOnline(entry string) (*Online, error)
+	ParseURL(ctx context.Context, entry string) (string, error)
+	ParseOnline(ctx context.Context, entry string) (*Online, error)
 
-	ParseAuthors(entry string) (*Authors, error)
-	ParseTitle(entry string) (string, error)
-	ParsePub(entry string) (string, error)
+	ParseAuthors(ctx context.Context, entry string) (*Authors, error)
+	ParseTitle(ctx context.Context, entry string) (string, error)
+	ParsePub(ctx context.Context, entry string) (string, error)
 }
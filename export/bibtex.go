@@ -0,0 +1,120 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var bibtexTypes = map[kind]string{
+	kindMisc:        "misc",
+	kindArticle:     "article",
+	kindProceedings: "inproceedings",
+	kindPreprint:    "misc",
+	kindReport:      "techreport",
+}
+
+// bibtexSpecial escapes the characters BibTeX and LaTeX treat specially.
+var bibtexSpecial = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// WriteBibTeX writes a BibTeX entry for each entry with a record, keyed by
+// the entry's ID, and the original text of the others as comments.
+func WriteBibTeX(w io.Writer, list []Entry) error {
+	bw := bufio.NewWriter(w)
+	for i, entry := range list {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		if entry.Record == nil {
+			fmt.Fprintf(bw, "%% %s: no matching record; original text:\n", entry.ID)
+			for _, line := range strings.Split(strings.TrimSpace(entry.Text), "\n") {
+				fmt.Fprintf(bw, "%% %s\n", line)
+			}
+			continue
+		}
+		writeBibTeXEntry(bw, entry)
+	}
+	return bw.Flush()
+}
+
+func writeBibTeXEntry(w io.Writer, entry Entry) {
+	record := entry.Record
+	k := kindOf(record)
+
+	fmt.Fprintf(w, "%% %s: from %s\n", entry.ID, record.Source)
+	fmt.Fprintf(w, "@%s{%s,\n", bibtexTypes[k], bibtexKey(entry.ID))
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(w, "  %s = {%s},\n", name, value)
+		}
+	}
+
+	authors := make([]string, len(record.Authors))
+	for i, name := range record.Authors {
+		given, family := splitName(name)
+		authors[i] = bibtexSpecial.Replace(family)
+		if given != "" {
+			authors[i] += ", " + bibtexSpecial.Replace(given)
+		}
+	}
+	field("author", strings.Join(authors, " and "))
+	if record.Title != "" {
+		// the inner braces keep styles from changing the title's case
+		field("title", "{"+bibtexSpecial.Replace(record.Title)+"}")
+	}
+	venue := bibtexSpecial.Replace(record.Venue)
+	switch k {
+	case kindArticle:
+		field("journal", venue)
+	case kindProceedings:
+		field("booktitle", venue)
+	case kindPreprint:
+		field("eprint", arxivID(record))
+		field("archiveprefix", "arXiv")
+		field("note", venue)
+	case kindReport:
+		// OSTI records do not name the issuing institution
+		field("note", "OSTI ID "+record.ID)
+	}
+	if record.Year != 0 {
+		field("year", fmt.Sprintf("%d", record.Year))
+	}
+	field("doi", bibtexVerbatim(record.DOI))
+	field("url", bibtexVerbatim(record.URL))
+	fmt.Fprintln(w, "}")
+}
+
+// bibtexKey returns id with the characters BibTeX does not allow in keys
+// removed.
+func bibtexKey(id string) string {
+	key := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(" \t\n,{}()=\"#%'\\~", r) {
+			return -1
+		}
+		return r
+	}, id)
+	if key == "" {
+		return "entry"
+	}
+	return key
+}
+
+// bibtexVerbatim drops the braces that would unbalance a DOI or URL, which
+// styles print verbatim.
+func bibtexVerbatim(s string) string {
+	return strings.NewReplacer("{", "", "}", "").Replace(s)
+}
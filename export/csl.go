@@ -0,0 +1,88 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package export
+
+import (
+	"encoding/json"
+	"io"
+)
+
+var cslTypes = map[kind]string{
+	kindMisc:        "document",
+	kindArticle:     "article-journal",
+	kindProceedings: "paper-conference",
+	kindPreprint:    "article",
+	kindReport:      "report",
+}
+
+type cslName struct {
+	Given  string `json:"given,omitempty"`
+	Family string `json:"family,omitempty"`
+}
+
+type cslDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+type cslItem struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title,omitempty"`
+	Author         []cslName `json:"author,omitempty"`
+	ContainerTitle string    `json:"container-title,omitempty"`
+	Publisher      string    `json:"publisher,omitempty"`
+	Number         string    `json:"number,omitempty"`
+	Issued         *cslDate  `json:"issued,omitempty"`
+	DOI            string    `json:"DOI,omitempty"`
+	URL            string    `json:"URL,omitempty"`
+	// Note holds the original text of entries without a record.
+	Note string `json:"note,omitempty"`
+}
+
+// WriteCSLJSON writes a CSL-JSON item for each entry, with the ID as its id.
+// Entries without a record are written as documents whose note is the
+// original text.
+func WriteCSLJSON(w io.Writer, list []Entry) error {
+	items := make([]cslItem, 0, len(list))
+	for _, entry := range list {
+		items = append(items, toCSLItem(entry))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(items)
+}
+
+func toCSLItem(entry Entry) cslItem {
+	record := entry.Record
+	if record == nil {
+		return cslItem{ID: entry.ID, Type: cslTypes[kindMisc], Note: entry.Text}
+	}
+
+	k := kindOf(record)
+	item := cslItem{
+		ID:    entry.ID,
+		Type:  cslTypes[k],
+		Title: record.Title,
+		DOI:   record.DOI,
+		URL:   record.URL,
+	}
+	for _, name := range record.Authors {
+		given, family := splitName(name)
+		item.Author = append(item.Author, cslName{Given: given, Family: family})
+	}
+	switch k {
+	case kindArticle, kindProceedings:
+		item.ContainerTitle = record.Venue
+	case kindPreprint:
+		item.Publisher = "arXiv"
+		item.Number = arxivID(record)
+	case kindReport:
+		item.Publisher = "OSTI"
+		item.Number = record.ID
+	}
+	if record.Year != 0 {
+		item.Issued = &cslDate{DateParts: [][]int{{record.Year}}}
+	}
+	return item
+}
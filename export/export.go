@@ -0,0 +1,143 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package export writes the records bibliography entries were verified
+// against as BibTeX or CSL-JSON, so authors can replace hand-typed references
+// with clean ones.
+package export
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/sandialabs/bibcheck/lookup"
+	"github.com/sandialabs/bibcheck/match"
+)
+
+type Format string
+
+const (
+	FormatBibTeX  Format = "bibtex"
+	FormatCSLJSON Format = "csl-json"
+)
+
+// Entry is a bibliography entry with the record it was verified against.
+type Entry struct {
+	ID string
+	// Text is the entry as cited.
+	Text string
+	// Record is nil when no source matched the entry.
+	Record *lookup.Record
+}
+
+// kind is the type of a work, as far as a record tells.
+type kind int
+
+const (
+	kindMisc kind = iota
+	kindArticle
+	kindProceedings
+	kindPreprint
+	kindReport
+)
+
+var proceedingsRe = regexp.MustCompile(`(?i)\b(proc\.|proceedings|conference|conf\.|workshop|symposium)`)
+
+// FromResult describes the entry with the given ID from its lookup result.
+// The record is the one the entry compared best against, unless even that
+// comparison is a mismatch.
+func FromResult(id string, r *lookup.Result) Entry {
+	e := Entry{ID: id, Text: r.Text}
+	m := r.Match()
+	if m == nil || m.Verdict == match.VerdictMismatch {
+		return e
+	}
+	for _, record := range r.Records() {
+		if record.Source == m.Source {
+			e.Record = &record
+			break
+		}
+	}
+	return e
+}
+
+// FormatFor returns the export format for a file name: BibTeX for .bib and
+// CSL-JSON for .json.
+func FormatFor(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".bib":
+		return FormatBibTeX, nil
+	case ".json":
+		return FormatCSLJSON, nil
+	default:
+		return "", fmt.Errorf("cannot tell the export format of %q (use a .bib or .json file)", path)
+	}
+}
+
+// Write writes list to w in the given format.
+func Write(w io.Writer, format Format, list []Entry) error {
+	switch format {
+	case FormatBibTeX:
+		return WriteBibTeX(w, list)
+	case FormatCSLJSON:
+		return WriteCSLJSON(w, list)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// kindOf guesses the type of the work a record describes: arXiv records are
+// preprints, OSTI records without a conference are reports, and other
+// records with a venue are conference papers or journal articles by the
+// venue's name.
+func kindOf(record *lookup.Record) kind {
+	switch {
+	case record.Source == "arXiv":
+		return kindPreprint
+	case record.Venue != "" && proceedingsRe.MatchString(record.Venue):
+		return kindProceedings
+	case record.Source == "OSTI":
+		return kindReport
+	case record.Venue != "" && record.Source != "Online":
+		return kindArticle
+	default:
+		return kindMisc
+	}
+}
+
+// splitName splits a "Given Family" or "Family, Given" name. Lower-case
+// particles such as "van der" stay with the family name.
+func splitName(name string) (given, family string) {
+	if f, g, ok := strings.Cut(name, ","); ok {
+		return strings.TrimSpace(g), strings.TrimSpace(f)
+	}
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return "", strings.Join(words, " ")
+	}
+	start := len(words) - 1
+	for start > 1 && startsLower(words[start-1]) {
+		start--
+	}
+	return strings.Join(words[:start], " "), strings.Join(words[start:], " ")
+}
+
+func startsLower(word string) bool {
+	for _, r := range word {
+		return unicode.IsLower(r)
+	}
+	return false
+}
+
+// arxivID returns the bare identifier of an arXiv record, e.g. "2001.00001v2".
+func arxivID(record *lookup.Record) string {
+	id := record.ID
+	for _, prefix := range []string{"https://arxiv.org/abs/", "http://arxiv.org/abs/"} {
+		id = strings.TrimPrefix(id, prefix)
+	}
+	return id
+}
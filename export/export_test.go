@@ -0,0 +1,133 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sandialabs/bibcheck/crossref"
+	"github.com/sandialabs/bibcheck/lookup"
+)
+
+var exportList = []Entry{
+	{ID: "goglin18", Record: &lookup.Record{
+		Source:  "Crossref",
+		Title:   "Hardware topology management in MPI applications through hierarchical communicators",
+		Authors: []string{"Brice Goglin", "Guillaume van der Mercier"},
+		Venue:   "Parallel Computing",
+		Year:    2018,
+		DOI:     "10.1016/j.parco.2018.05.006",
+		URL:     "https://doi.org/10.1016/j.parco.2018.05.006",
+	}},
+	{ID: "2", Record: &lookup.Record{
+		Source:  "arXiv",
+		ID:      "http://arxiv.org/abs/1706.03762v5",
+		Title:   "Attention Is All You Need",
+		Authors: []string{"Ashish Vaswani"},
+		Year:    2017,
+		URL:     "http://arxiv.org/abs/1706.03762v5",
+	}},
+	{ID: "3", Text: "A. Nobody. A 50% made-up paper.\nNowhere, 2020."},
+}
+
+func TestWriteBibTeX(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteBibTeX(&b, exportList); err != nil {
+		t.Fatal(err)
+	}
+	want := `% goglin18: from Crossref
+@article{goglin18,
+  author = {Goglin, Brice and van der Mercier, Guillaume},
+  title = {{Hardware topology management in MPI applications through hierarchical communicators}},
+  journal = {Parallel Computing},
+  year = {2018},
+  doi = {10.1016/j.parco.2018.05.006},
+  url = {https://doi.org/10.1016/j.parco.2018.05.006},
+}
+
+% 2: from arXiv
+@misc{2,
+  author = {Vaswani, Ashish},
+  title = {{Attention Is All You Need}},
+  eprint = {1706.03762v5},
+  archiveprefix = {arXiv},
+  year = {2017},
+  url = {http://arxiv.org/abs/1706.03762v5},
+}
+
+% 3: no matching record; original text:
+% A. Nobody. A 50% made-up paper.
+% Nowhere, 2020.
+`
+	if b.String() != want {
+		t.Fatalf("WriteBibTeX =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteCSLJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteCSLJSON(&b, exportList); err != nil {
+		t.Fatal(err)
+	}
+	var items []map[string]any
+	if err := json.Unmarshal(b.Bytes(), &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("items = %v", items)
+	}
+	if items[0]["type"] != "article-journal" || items[0]["container-title"] != "Parallel Computing" || items[0]["DOI"] != "10.1016/j.parco.2018.05.006" {
+		t.Fatalf("article = %v", items[0])
+	}
+	authors := items[0]["author"].([]any)
+	if second := authors[1].(map[string]any); second["family"] != "van der Mercier" || second["given"] != "Guillaume" {
+		t.Fatalf("author = %v", second)
+	}
+	if items[1]["type"] != "article" || items[1]["number"] != "1706.03762v5" || items[1]["publisher"] != "arXiv" {
+		t.Fatalf("preprint = %v", items[1])
+	}
+	if items[2]["type"] != "document" || !strings.Contains(items[2]["note"].(string), "made-up paper") {
+		t.Fatalf("unmatched = %v", items[2])
+	}
+}
+
+func TestEscapesBibTeX(t *testing.T) {
+	var b bytes.Buffer
+	list := []Entry{{ID: "a key,1", Record: &lookup.Record{Source: "DBLP", Title: "R&D at 100% {scale}", Venue: "Proc. of the Workshop on C_1"}}}
+	if err := WriteBibTeX(&b, list); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"@inproceedings{akey1,", `title = {{R\&D at 100\% \{scale\}}}`, `booktitle = {Proc. of the Workshop on C\_1}`} {
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("WriteBibTeX = %s, want %s", b.String(), want)
+		}
+	}
+}
+
+func TestFromResultSkipsMismatch(t *testing.T) {
+	r := &lookup.Result{Text: "J. Smith. A fast solver. J. Comput. Phys., 2021."}
+	r.Crossref.Status = lookup.SearchStatusDone
+	r.Crossref.Work = &crossref.CrossrefWork{DOI: "10.1000/other", Title: []string{"Unrelated results on graph coloring"}}
+	if e := FromResult("1", r); e.Record != nil {
+		t.Fatalf("record = %+v, want none", e.Record)
+	}
+
+	r.Crossref.Work = &crossref.CrossrefWork{DOI: "10.1000/jcp.1", Title: []string{"A fast solver"}}
+	if e := FromResult("1", r); e.Record == nil || e.Record.DOI != "10.1000/jcp.1" {
+		t.Fatalf("record = %+v", e.Record)
+	}
+}
+
+func TestFormatFor(t *testing.T) {
+	for path, want := range map[string]Format{"refs.bib": FormatBibTeX, "out/REFS.JSON": FormatCSLJSON} {
+		if got, err := FormatFor(path); err != nil || got != want {
+			t.Errorf("FormatFor(%q) = %q, %v", path, got, err)
+		}
+	}
+	if _, err := FormatFor("refs.txt"); err == nil {
+		t.Error("FormatFor(refs.txt) succeeded")
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package httpcache keeps metadata responses on disk so that re-running
+// bibcheck on a revised document does not query every upstream service again.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sandialabs/bibcheck/cassette"
+)
+
+// maxBodyBytes bounds the size of a cached response body. Larger responses
+// are passed through without being stored.
+const maxBodyBytes = 32 << 20
+
+// cacheable lists the status codes stored by the cache. Besides successful
+// responses this includes definitive "not found" answers, which bibcheck
+// relies on to report that a DOI or record does not exist.
+var cacheable = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusNoContent:            true,
+	http.StatusMovedPermanently:     true,
+	http.StatusNotFound:             true,
+	http.StatusGone:                 true,
+}
+
+// Cache is an on-disk HTTP response cache. A nil *Cache is valid and caches
+// nothing.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+type record struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// Stats describes the contents of a cache directory.
+type Stats struct {
+	Dir     string
+	Entries int
+	Expired int
+	Bytes   int64
+}
+
+// New returns a cache stored in dir whose entries expire after ttl. A ttl of
+// zero or less keeps entries until they are pruned or cleared.
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// Dir returns the directory the cache is stored in.
+func (c *Cache) Dir() string {
+	if c == nil {
+		return ""
+	}
+	return c.dir
+}
+
+// Key returns the cache key for req. Requests that differ only in the order
+// of query parameters, the case of the scheme or host, a default port, or a
+// fragment share a key.
+func Key(req *http.Request) string {
+	u := *req.URL
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		u.Host = u.Hostname()
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = u.Query().Encode()
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	sum := sha256.Sum256([]byte(strings.ToUpper(req.Method) + " " + u.String() + "\n" + req.Header.Get("Accept")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Lookup returns the stored response for req if there is one that has not
+// expired.
+func (c *Cache) Lookup(req *http.Request) (*http.Response, bool) {
+	if c == nil || !storable(req) {
+		return nil, false
+	}
+
+	path := c.path(Key(req))
+	info, err := os.Stat(path)
+	if err != nil || c.expired(info) {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var rec record
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, false
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header,
+		Body:          io.NopCloser(bytes.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, true
+}
+
+// Store saves resp as the response to req when it is cacheable and returns a
+// response whose body can still be read by the caller.
+func (c *Cache) Store(req *http.Request, resp *http.Response) (*http.Response, error) {
+	if c == nil || !storable(req) || !cacheable[resp.StatusCode] ||
+		strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxBodyBytes {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	rec := record{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   body,
+	}
+	// a cache that cannot be written to must not fail the request
+	_ = c.write(Key(req), rec)
+	return resp, nil
+}
+
+func (c *Cache) write(key string, rec record) error {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(payload); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// Transport returns a RoundTripper that answers requests from the cache and
+// stores the responses base returns. A nil base uses http.DefaultTransport;
+// a nil cache returns base unchanged.
+func (c *Cache) Transport(base http.RoundTripper) http.RoundTripper {
+	if c == nil {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{cache: c, base: base}
+}
+
+// NewClient returns an HTTP client with the given timeout whose requests go
+// through the Default cache.
+func NewClient(timeout time.Duration) *http.Client {
+	return Default().Client(timeout, nil)
+}
+
+// Client returns an HTTP client with the given timeout whose requests go
+// through the cache, and are sent with base when the cache cannot answer
+// them; a nil base uses http.DefaultTransport.
+func (c *Cache) Client(timeout time.Duration, base http.RoundTripper) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: c.Transport(cassette.Transport(base)),
+	}
+}
+
+type transport struct {
+	cache *Cache
+	base  http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if resp, ok := t.cache.Lookup(req); ok {
+		return resp, nil
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	return t.cache.Store(req, resp)
+}
+
+// Stats counts the cached responses.
+func (c *Cache) Stats() (Stats, error) {
+	stats := Stats{Dir: c.dir}
+	err := c.walk(func(path string, info fs.FileInfo) error {
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if c.expired(info) {
+			stats.Expired++
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// Prune removes expired responses and returns how many were removed.
+func (c *Cache) Prune() (int, error) {
+	removed := 0
+	err := c.walk(func(path string, info fs.FileInfo) error {
+		if !c.expired(info) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+// Clear removes every cached response.
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("clear http cache: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) walk(fn func(string, fs.FileInfo) error) error {
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(path, info)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read http cache: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+func (c *Cache) expired(info fs.FileInfo) bool {
+	return c.ttl > 0 && c.now().Sub(info.ModTime()) > c.ttl
+}
+
+// storable reports whether req may be answered from the cache. Requests
+// carrying credentials are never cached.
+func storable(req *http.Request) bool {
+	return req.Method == http.MethodGet &&
+		req.Header.Get("Authorization") == "" &&
+		req.Header.Get("Cookie") == "" &&
+		!strings.Contains(strings.ToLower(req.Header.Get("Cache-Control")), "no-store")
+}
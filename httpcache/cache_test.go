@@ -0,0 +1,218 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type countingTransport struct {
+	calls  int
+	status int
+	header http.Header
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	status := t.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	header := t.header
+	if header == nil {
+		header = http.Header{"Content-Type": {"application/json"}}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader("body " + req.URL.String())),
+		Request:    req,
+	}, nil
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestTransportAnswersRepeatedRequestsFromDisk(t *testing.T) {
+	upstream := &countingTransport{}
+	cache := New(t.TempDir(), time.Hour)
+	client := &http.Client{Transport: cache.Transport(upstream)}
+
+	first := get(t, client, "https://api.example.org/works?b=2&a=1")
+	second := get(t, client, "https://API.example.org:443/works?a=1&b=2#frag")
+	if upstream.calls != 1 {
+		t.Fatalf("upstream calls = %d, want 1", upstream.calls)
+	}
+	if first != second {
+		t.Fatalf("cached body = %q, want %q", second, first)
+	}
+
+	// a fresh cache over the same directory sees the stored response
+	client = &http.Client{Transport: New(cache.Dir(), time.Hour).Transport(upstream)}
+	get(t, client, "https://api.example.org/works?a=1&b=2")
+	if upstream.calls != 1 {
+		t.Fatalf("upstream calls = %d after reopening, want 1", upstream.calls)
+	}
+}
+
+func TestTransportRefetchesExpiredResponses(t *testing.T) {
+	upstream := &countingTransport{}
+	cache := New(t.TempDir(), time.Hour)
+	client := &http.Client{Transport: cache.Transport(upstream)}
+
+	get(t, client, "https://api.example.org/works/1")
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	get(t, client, "https://api.example.org/works/1")
+	if upstream.calls != 2 {
+		t.Fatalf("upstream calls = %d, want 2", upstream.calls)
+	}
+}
+
+func TestTransportSkipsUncacheableRequests(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream *countingTransport
+		request  func() *http.Request
+	}{
+		{
+			name:     "server error",
+			upstream: &countingTransport{status: http.StatusServiceUnavailable},
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "https://api.example.org/works", nil)
+				return req
+			},
+		},
+		{
+			name:     "post",
+			upstream: &countingTransport{},
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, "https://api.example.org/works", strings.NewReader("{}"))
+				return req
+			},
+		},
+		{
+			name:     "credentials",
+			upstream: &countingTransport{},
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "https://api.example.org/works", nil)
+				req.Header.Set("Authorization", "Bearer secret")
+				return req
+			},
+		},
+		{
+			name:     "no-store response",
+			upstream: &countingTransport{header: http.Header{"Cache-Control": {"no-store"}}},
+			request: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, "https://api.example.org/works", nil)
+				return req
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := New(t.TempDir(), time.Hour).Transport(tt.upstream)
+			for range 2 {
+				resp, err := transport.RoundTrip(tt.request())
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+			}
+			if tt.upstream.calls != 2 {
+				t.Fatalf("upstream calls = %d, want 2", tt.upstream.calls)
+			}
+		})
+	}
+}
+
+func TestTransportCachesNotFound(t *testing.T) {
+	upstream := &countingTransport{status: http.StatusNotFound}
+	client := &http.Client{Transport: New(t.TempDir(), time.Hour).Transport(upstream)}
+
+	for range 2 {
+		resp, err := client.Get("https://doi.org/api/handles/10.1000/missing")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("status = %d, want 404", resp.StatusCode)
+		}
+	}
+	if upstream.calls != 1 {
+		t.Fatalf("upstream calls = %d, want 1", upstream.calls)
+	}
+}
+
+func TestKeyIncludesAccept(t *testing.T) {
+	a, _ := http.NewRequest(http.MethodGet, "https://doi.org/10.1000/x", nil)
+	b, _ := http.NewRequest(http.MethodGet, "https://doi.org/10.1000/x", nil)
+	b.Header.Set("Accept", "application/vnd.citationstyles.csl+json")
+	if Key(a) == Key(b) {
+		t.Fatal("requests with different Accept headers share a key")
+	}
+}
+
+func TestStatsPruneClear(t *testing.T) {
+	cache := New(t.TempDir(), time.Hour)
+	client := &http.Client{Transport: cache.Transport(&countingTransport{})}
+	get(t, client, "https://api.example.org/works/1")
+	get(t, client, "https://api.example.org/works/2")
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 2 || stats.Expired != 0 || stats.Bytes == 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	removed, err := cache.Prune()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 0 {
+		t.Fatalf("pruned %d fresh responses", removed)
+	}
+
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if removed, err = cache.Prune(); err != nil || removed != 2 {
+		t.Fatalf("Prune() = %d, %v, want 2, nil", removed, err)
+	}
+
+	get(t, client, "https://api.example.org/works/3")
+	if err := cache.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if stats, err = cache.Stats(); err != nil || stats.Entries != 0 {
+		t.Fatalf("Stats() after Clear = %+v, %v", stats, err)
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	var cache *Cache
+	upstream := &countingTransport{}
+	if cache.Transport(upstream) != upstream {
+		t.Fatal("nil cache wrapped the transport")
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.org/works", nil)
+	if _, ok := cache.Lookup(req); ok {
+		t.Fatal("nil cache returned a response")
+	}
+}
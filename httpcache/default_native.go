@@ -0,0 +1,22 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+//go:build !js || !wasm
+
+package httpcache
+
+import "github.com/sandialabs/bibcheck/config"
+
+// Default returns the cache configured by the runtime settings, or nil when
+// caching is disabled or its directory cannot be resolved.
+func Default() *Cache {
+	settings := config.Runtime()
+	// a cassette must see every request to record or replay it
+	if !settings.HTTPCacheEnable || settings.Cassette != "" {
+		return nil
+	}
+	dir, err := config.HTTPCacheDir(settings)
+	if err != nil {
+		return nil
+	}
+	return New(dir, settings.HTTPCacheTTL)
+}
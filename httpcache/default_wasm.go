@@ -0,0 +1,10 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+//go:build js && wasm
+
+package httpcache
+
+// Default returns nil: the browser has no state directory to cache into.
+func Default() *Cache {
+	return nil
+}
@@ -0,0 +1,386 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/web/workflow"
+)
+
+// Request headers that carry the API keys for one analysis, overriding the
+// server's own.
+const (
+	shirtyAPIKeyHeader     = "X-Shirty-API-Key"
+	openRouterAPIKeyHeader = "X-OpenRouter-API-Key"
+)
+
+// jobPhaseQueued is the phase of a job no worker has started yet.
+const jobPhaseQueued = "Queued"
+
+// jobEventsKeepAlive is how often an idle event stream gets a comment, so
+// that proxies do not close it.
+const jobEventsKeepAlive = 15 * time.Second
+
+// errJobQueueFull is returned when a job is submitted to a full queue.
+var errJobQueueFull = errors.New("analysis queue is full")
+
+// analyzeFunc runs an analysis the way workflow.AnalyzePDFWithOptions and
+// workflow.AnalyzeBibTeX do.
+type analyzeFunc func(ctx context.Context, rt *workflow.Runtime, data []byte, options workflow.Options, progress workflow.Progress) workflow.State
+
+// analyzeConfig configures the analysis API.
+type analyzeConfig struct {
+	// keys are used for analyses whose request carries none.
+	keys     workflow.Keys
+	maxBytes int64
+	workers  int
+	// queue bounds the jobs waiting for a worker.
+	queue   int
+	timeout time.Duration
+	// retain is how long a finished job can still be read.
+	retain time.Duration
+	// transport carries the requests of the jobs; nil uses
+	// http.DefaultTransport.
+	transport http.RoundTripper
+}
+
+// job is one document submitted to POST /api/analyze.
+type job struct {
+	id      string
+	kind    string
+	analyze analyzeFunc
+	rt      *workflow.Runtime
+	data    []byte
+	options workflow.Options
+
+	// state, finished and changed are guarded by the queue's mu. changed is
+	// closed, and replaced, whenever state changes.
+	state    workflow.State
+	finished time.Time
+	changed  chan struct{}
+}
+
+// update sets the state of j and wakes those watching it. The queue's mu must
+// be held.
+func (j *job) update(state workflow.State) {
+	j.state = state
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// jobQueue runs analysis jobs on a fixed number of workers, keeping each job
+// until retain after it finishes.
+type jobQueue struct {
+	cfg           analyzeConfig
+	analyzePDF    analyzeFunc
+	analyzeBibTeX analyzeFunc
+	now           func() time.Time
+	pending       chan *job
+
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+// newJobQueue returns a queue whose workers are already waiting for jobs.
+func newJobQueue(cfg analyzeConfig) *jobQueue {
+	q := &jobQueue{
+		cfg:           cfg,
+		analyzePDF:    workflow.AnalyzePDFWithOptions,
+		analyzeBibTeX: workflow.AnalyzeBibTeX,
+		now:           time.Now,
+		pending:       make(chan *job, max(cfg.queue, 0)),
+		jobs:          map[string]*job{},
+	}
+	for range max(cfg.workers, 1) {
+		go q.work()
+	}
+	return q
+}
+
+func (q *jobQueue) work() {
+	for j := range q.pending {
+		q.run(j)
+	}
+}
+
+func (q *jobQueue) run(j *job) {
+	ctx, cancel := context.WithTimeout(context.Background(), q.cfg.timeout)
+	defer cancel()
+
+	start := q.now()
+	log.Printf("analysis job started id=%q kind=%s bytes=%d", j.id, j.kind, len(j.data))
+	state := j.analyze(ctx, j.rt, j.data, j.options, func(state workflow.State) {
+		q.mu.Lock()
+		j.update(state)
+		q.mu.Unlock()
+	})
+
+	q.mu.Lock()
+	j.finished = q.now()
+	j.update(state)
+	// the document and keys are no longer needed
+	j.data, j.rt = nil, nil
+	q.mu.Unlock()
+	log.Printf("analysis job finished id=%q phase=%q entries=%d duration=%s error=%q",
+		j.id, state.Phase, state.Total, j.finished.Sub(start).Round(time.Millisecond), state.Error)
+}
+
+// submit queues j under a new ID, or returns errJobQueueFull.
+func (q *jobQueue) submit(j *job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	j.id = rand.Text()
+	j.state = workflow.State{Provider: j.rt.Kind, Phase: jobPhaseQueued}
+	j.changed = make(chan struct{})
+	select {
+	case q.pending <- j:
+		q.jobs[j.id] = j
+		return nil
+	default:
+		return errJobQueueFull
+	}
+}
+
+// state returns the current state of the job with id.
+func (q *jobQueue) state(id string) (workflow.State, bool) {
+	state, _, _, ok := q.watch(id)
+	return state, ok
+}
+
+// watch returns the current state of the job with id, whether the job has
+// finished, and a channel that is closed when the state changes.
+func (q *jobQueue) watch(id string) (workflow.State, <-chan struct{}, bool, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	j, ok := q.jobs[id]
+	if !ok {
+		return workflow.State{}, nil, false, false
+	}
+	return j.state, j.changed, !j.finished.IsZero(), true
+}
+
+// prune drops the jobs that finished more than retain ago. q.mu must be held.
+func (q *jobQueue) prune() {
+	now := q.now()
+	for id, j := range q.jobs {
+		if !j.finished.IsZero() && now.Sub(j.finished) > q.cfg.retain {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// runtimeOptions returns the options of a job's runtime. Jobs skip the
+// on-disk HTTP cache, which would answer requests the transport refuses with
+// responses stored by other runs.
+func (q *jobQueue) runtimeOptions() []workflow.RuntimeOpt {
+	return []workflow.RuntimeOpt{workflow.WithTransport(q.cfg.transport), workflow.WithoutHTTPCache()}
+}
+
+// analyzeHandler queues the PDF or BibTeX database in the request body for
+// analysis and answers with the ID of the job. The entry parameter selects a
+// single entry, as the CLI's --entry does, and orphans=true cross-checks the
+// in-text citations, as --orphans does.
+func analyzeHandler(q *jobQueue) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		data, tooLarge, err := readLimited(r.Body, q.cfg.maxBytes)
+		if err != nil {
+			http.Error(w, "read request body failed", http.StatusBadRequest)
+			return
+		}
+		if tooLarge {
+			http.Error(w, fmt.Sprintf("document exceeds %d bytes", q.cfg.maxBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			http.Error(w, "missing document in request body", http.StatusBadRequest)
+			return
+		}
+
+		keys := q.cfg.keys
+		if key := strings.TrimSpace(r.Header.Get(shirtyAPIKeyHeader)); key != "" {
+			keys.ShirtyAPIKey = key
+		}
+		if key := strings.TrimSpace(r.Header.Get(openRouterAPIKeyHeader)); key != "" {
+			keys.OpenRouterAPIKey = key
+		}
+		j := &job{
+			data:    data,
+			options: workflow.Options{Entry: r.URL.Query().Get("entry"), Orphans: r.URL.Query().Get("orphans") == "true"},
+		}
+		if isPDF(data) {
+			j.kind, j.analyze = "pdf", q.analyzePDF
+			if j.rt, err = workflow.NewRuntime(keys, q.runtimeOptions()...); err != nil {
+				http.Error(w, fmt.Sprintf("pdf analysis needs an API key: %v", err), http.StatusBadRequest)
+				return
+			}
+		} else {
+			// BibTeX entries are parsed without an LLM
+			j.kind, j.analyze = "bibtex", q.analyzeBibTeX
+			j.rt = workflow.NewBibTeXRuntime(keys, q.runtimeOptions()...)
+		}
+
+		if err := q.submit(j); err != nil {
+			log.Printf("analysis job refused kind=%s bytes=%d: %v %s", j.kind, len(data), err, clientAddressLogFields(r))
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		log.Printf("analysis job queued id=%q kind=%s bytes=%d %s", j.id, j.kind, len(data), clientAddressLogFields(r))
+
+		location := "/api/jobs/" + j.id
+		w.Header().Set("Location", location)
+		writeJSON(w, http.StatusAccepted, struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		}{j.id, location})
+	})
+}
+
+// jobHandler answers with the current workflow.State of the job in the path.
+// Its Phase is "Queued" until a worker starts the job, and "Done" or "Error"
+// once the job has finished.
+func jobHandler(q *jobQueue) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		state, ok := q.state(r.PathValue("id"))
+		if !ok {
+			http.Error(w, "job not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, state)
+	})
+}
+
+// jobEventsHandler streams the workflow.State of the job in the path as
+// Server-Sent Events: a "state" event whenever it changes, coalescing changes
+// that come faster than the client reads, and a final "done" event with the
+// state of the finished job, after which the stream ends.
+func jobEventsHandler(q *jobQueue) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		id := r.PathValue("id")
+		state, changed, finished, ok := q.watch(id)
+		if !ok {
+			http.Error(w, "job not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-store")
+		// keep nginx from buffering the stream
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		stream := http.NewResponseController(w)
+		keepAlive := time.NewTicker(jobEventsKeepAlive)
+		defer keepAlive.Stop()
+
+		for seq := 1; ; seq++ {
+			event := "state"
+			if finished {
+				event = "done"
+			}
+			if err := writeEvent(w, seq, event, state); err != nil {
+				log.Printf("analysis job events stopped id=%q: %v", id, err)
+				return
+			}
+			if err := stream.Flush(); err != nil || finished {
+				return
+			}
+		wait:
+			for {
+				select {
+				case <-changed:
+					break wait
+				case <-keepAlive.C:
+					if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+						return
+					}
+					if err := stream.Flush(); err != nil {
+						return
+					}
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if state, changed, finished, ok = q.watch(id); !ok {
+				return
+			}
+		}
+	})
+}
+
+// writeEvent writes value as a Server-Sent Event named event with id seq.
+func writeEvent(w io.Writer, seq int, event string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", seq, event, data)
+	return err
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, "encode response failed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		log.Printf("write json response failed: %v", err)
+	}
+}
+
+// analysisFetchPolicy returns the policy for the connections of analysis
+// jobs, which fetch the URLs cited in the submitted documents: that of
+// /api/fetch, also allowing the Shirty API, which may be on an intranet.
+func analysisFetchPolicy(options Options) (*fetchPolicy, error) {
+	baseURL := options.AnalyzeKeys.ShirtyBaseURL
+	if baseURL == "" {
+		baseURL = config.DefaultShirtyBaseURL
+	}
+	allow := options.FetchAllow
+	if u, err := url.Parse(baseURL); err == nil && u.Hostname() != "" {
+		allow = append(slices.Clone(allow), u.Hostname())
+	}
+	return newFetchPolicy(allow, options.FetchDeny)
+}
+
+// isPDF reports whether data starts with a PDF header, which readers accept
+// anywhere in the first kilobyte.
+func isPDF(data []byte) bool {
+	return bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-"))
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -11,13 +13,36 @@ import (
 	"github.com/sandialabs/bibcheck/version"
 )
 
-func fetchHandler(maxBytes int64) http.Handler {
-	return fetchHandlerWithTimeout(maxBytes, fetchUpstreamTimeout)
+// fetchError is a failure of /api/fetch itself, reported with status.
+type fetchError struct {
+	status  int
+	message string
 }
 
-func fetchHandlerWithTimeout(maxBytes int64, timeout time.Duration) http.Handler {
+func (e *fetchError) Error() string {
+	return e.message
+}
+
+// fetchConfig configures the /api/fetch handler.
+type fetchConfig struct {
+	maxBytes int64
+	timeout  time.Duration
+	policy   *fetchPolicy
+	// cache, if set, keeps upstream responses for as long as their
+	// Cache-Control allows, or for cacheTTL if it says nothing.
+	cache    *fetchCache
+	cacheTTL time.Duration
+	// hosts, if set, caps the concurrent upstream requests to each host.
+	hosts *hostLimiter
+}
+
+// fetchHandler proxies GET requests for the url parameter. The X-Cache
+// header tells whether a response came from the cache.
+func fetchHandler(cfg fetchConfig) http.Handler {
+	maxBytes, timeout, cache := cfg.maxBytes, cfg.timeout, cfg.cache
 	client := &http.Client{
-		Timeout: timeout,
+		Transport: cfg.policy.transport(),
+		Timeout:   timeout,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if err := validateFetchURL(req.URL); err != nil {
 				return err
@@ -26,6 +51,50 @@ func fetchHandlerWithTimeout(maxBytes int64, timeout time.Duration) http.Handler
 		},
 	}
 
+	fetchUpstream := func(req *http.Request) (*fetchEntry, error) {
+		wait, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		release, err := cfg.hosts.acquire(wait, req.URL.Hostname())
+		if err != nil {
+			log.Printf("proxy GET gave up waiting for host url=%q: %v", req.URL.String(), err)
+			return nil, &fetchError{http.StatusTooManyRequests, fmt.Sprintf("too many concurrent requests to %s", req.URL.Hostname())}
+		}
+		defer release()
+
+		resp, err := client.Do(req)
+		if err != nil {
+			log.Printf("proxy GET failed url=%q: %v", req.URL.String(), err)
+			if errors.Is(err, errFetchForbidden) {
+				return nil, &fetchError{http.StatusForbidden, "url host is not allowed"}
+			}
+			if isTimeoutError(err) {
+				return nil, &fetchError{http.StatusGatewayTimeout, fmt.Sprintf("upstream request timed out after %s", timeout)}
+			}
+			return nil, &fetchError{http.StatusBadGateway, "upstream request failed"}
+		}
+		defer resp.Body.Close()
+
+		body, tooLarge, err := readLimited(resp.Body, maxBytes)
+		if err != nil {
+			log.Printf("proxy GET read failed url=%q: %v", req.URL.String(), err)
+			if isTimeoutError(err) {
+				return nil, &fetchError{http.StatusGatewayTimeout, fmt.Sprintf("upstream response timed out after %s", timeout)}
+			}
+			return nil, &fetchError{http.StatusBadGateway, "read upstream response failed"}
+		}
+		if tooLarge {
+			return nil, &fetchError{http.StatusRequestEntityTooLarge, fmt.Sprintf("upstream response exceeds %d bytes", maxBytes)}
+		}
+
+		entry := &fetchEntry{Status: resp.StatusCode, ContentType: resp.Header.Get("Content-Type"), Body: body}
+		if entry.ContentType == "" && len(body) > 0 {
+			entry.ContentType = http.DetectContentType(body)
+		}
+		now := time.Now()
+		entry.Expires = now.Add(freshFor(resp.StatusCode, resp.Header, cfg.cacheTTL, now))
+		return entry, nil
+	}
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set(wasmhttp.FetchResultHeader, wasmhttp.FetchResultProxyError)
 
@@ -50,9 +119,23 @@ func fetchHandlerWithTimeout(maxBytes int64, timeout time.Duration) http.Handler
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		// check the host before the cache too, so that a response cached
+		// before the host was denied, or resolved to an internal address,
+		// is not served
+		if _, err := cfg.policy.addrs(r.Context(), targetURL.Hostname()); err != nil {
+			log.Printf("proxy GET refused url=%q: %v", targetURL.String(), err)
+			if errors.Is(err, errFetchForbidden) {
+				http.Error(w, "url host is not allowed", http.StatusForbidden)
+			} else {
+				http.Error(w, "upstream request failed", http.StatusBadGateway)
+			}
+			return
+		}
 
 		log.Printf("proxy GET url=%q %s", targetURL.String(), clientAddressLogFields(r))
-		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, targetURL.String(), nil)
+		// the upstream fetch may be shared with other requests, so it
+		// outlives this one
+		req, err := http.NewRequestWithContext(context.WithoutCancel(r.Context()), http.MethodGet, targetURL.String(), nil)
 		if err != nil {
 			http.Error(w, "create upstream request failed", http.StatusInternalServerError)
 			return
@@ -63,46 +146,48 @@ func fetchHandlerWithTimeout(maxBytes int64, timeout time.Duration) http.Handler
 		}
 		req.Header.Set("User-Agent", userAgent)
 
-		resp, err := client.Do(req)
+		entry, hit, err := cache.fetch(r.Context(), fetchCacheKey(targetURL), func() (*fetchEntry, error) {
+			return fetchUpstream(req)
+		})
 		if err != nil {
-			log.Printf("proxy GET failed url=%q: %v", targetURL.String(), err)
-			if isTimeoutError(err) {
-				http.Error(w, fmt.Sprintf("upstream request timed out after %s", timeout), http.StatusGatewayTimeout)
-				return
+			var fetchErr *fetchError
+			if errors.As(err, &fetchErr) {
+				http.Error(w, fetchErr.message, fetchErr.status)
+			} else {
+				http.Error(w, "upstream request failed", http.StatusBadGateway)
 			}
-			http.Error(w, "upstream request failed", http.StatusBadGateway)
 			return
 		}
-		defer resp.Body.Close()
 
-		body, tooLarge, err := readLimited(resp.Body, maxBytes)
-		if err != nil {
-			log.Printf("proxy GET read failed url=%q: %v", targetURL.String(), err)
-			if isTimeoutError(err) {
-				http.Error(w, fmt.Sprintf("upstream response timed out after %s", timeout), http.StatusGatewayTimeout)
-				return
-			}
-			http.Error(w, "read upstream response failed", http.StatusBadGateway)
-			return
-		}
-		if tooLarge {
-			http.Error(w, fmt.Sprintf("upstream response exceeds %d bytes", maxBytes), http.StatusRequestEntityTooLarge)
-			return
+		if entry.ContentType != "" {
+			w.Header().Set("Content-Type", entry.ContentType)
 		}
-
-		if contentType := resp.Header.Get("Content-Type"); contentType != "" {
-			w.Header().Set("Content-Type", contentType)
-		} else if len(body) > 0 {
-			w.Header().Set("Content-Type", http.DetectContentType(body))
+		if cache != nil {
+			if hit {
+				w.Header().Set(wasmhttp.FetchCacheHeader, wasmhttp.FetchCacheHit)
+			} else {
+				w.Header().Set(wasmhttp.FetchCacheHeader, wasmhttp.FetchCacheMiss)
+			}
 		}
 		w.Header().Set(wasmhttp.FetchResultHeader, wasmhttp.FetchResultUpstream)
-		w.WriteHeader(resp.StatusCode)
-		if _, err := w.Write(body); err != nil {
+		w.WriteHeader(entry.Status)
+		if _, err := w.Write(entry.Body); err != nil {
 			log.Printf("write proxied response failed: %v", err)
 		}
 	})
 }
 
+// proxyErrorHandler marks the responses of next as failures of /api/fetch
+// itself unless next says they came from upstream, so that requests refused
+// before the fetch handler runs, e.g. by the rate limiter, are not taken for
+// upstream errors.
+func proxyErrorHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(wasmhttp.FetchResultHeader, wasmhttp.FetchResultProxyError)
+		next.ServeHTTP(w, r)
+	})
+}
+
 func defaultUserAgent() string {
 	return "bibcheck / " + version.String() + " github.com/sandialabs/bibcheck"
 }
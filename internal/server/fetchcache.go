@@ -0,0 +1,401 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package server
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fetchCacheable lists the upstream status codes /api/fetch caches: successful
+// responses and definitive "not found" answers.
+var fetchCacheable = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusNoContent:            true,
+	http.StatusMovedPermanently:     true,
+	http.StatusNotFound:             true,
+	http.StatusGone:                 true,
+}
+
+// fetchEntry is an upstream response as /api/fetch returns it.
+type fetchEntry struct {
+	Status      int       `json:"status"`
+	ContentType string    `json:"content_type,omitempty"`
+	Body        []byte    `json:"body"`
+	Expires     time.Time `json:"expires"`
+}
+
+func (e *fetchEntry) size() int64 {
+	return int64(len(e.Body) + len(e.ContentType))
+}
+
+// fetchCall is an upstream fetch that concurrent requests for the same URL
+// wait on.
+type fetchCall struct {
+	done  chan struct{}
+	entry *fetchEntry
+	err   error
+}
+
+// fetchCache keeps upstream responses in memory and, optionally, on disk,
+// evicting the least recently used when either is full. Concurrent requests
+// for a URL that is not cached share one upstream fetch. A nil *fetchCache
+// neither caches nor shares fetches.
+type fetchCache struct {
+	mu     sync.Mutex
+	memory *lru
+	disk   *lru
+	dir    string
+	now    func() time.Time
+	calls  map[string]*fetchCall
+}
+
+// fetchCacheKey returns the cache key for u. URLs that differ only in the
+// order of query parameters, the case of the scheme or host, a default port,
+// or a fragment share a key.
+func fetchCacheKey(u *url.URL) string {
+	normalized := *u
+	normalized.Scheme = strings.ToLower(u.Scheme)
+	normalized.Host = strings.ToLower(u.Host)
+	if port := normalized.Port(); (normalized.Scheme == "http" && port == "80") || (normalized.Scheme == "https" && port == "443") {
+		normalized.Host = normalized.Hostname()
+	}
+	if normalized.Path == "" {
+		normalized.Path = "/"
+	}
+	normalized.RawQuery = u.Query().Encode()
+	normalized.Fragment = ""
+	normalized.RawFragment = ""
+	sum := sha256.Sum256([]byte(normalized.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// newFetchCache returns a cache holding up to memoryBytes of responses in
+// memory and, when dir is set, up to diskBytes in dir. Responses already in
+// dir are kept. A memoryBytes or diskBytes below one disables that tier.
+func newFetchCache(memoryBytes int64, dir string, diskBytes int64) (*fetchCache, error) {
+	c := &fetchCache{now: time.Now, calls: map[string]*fetchCall{}}
+	if memoryBytes > 0 {
+		c.memory = newLRU(memoryBytes, nil)
+	}
+	if dir != "" && diskBytes > 0 {
+		c.dir = dir
+		c.disk = newLRU(diskBytes, func(key string) {
+			_ = os.Remove(c.path(key))
+		})
+		if err := c.loadDisk(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// fetch returns the cached response for key, reporting it as a hit, or the
+// response of fetch, which runs once for all concurrent callers with the same
+// key and whose response is cached while it is fresh. A caller whose ctx is
+// done stops waiting without stopping the fetch.
+func (c *fetchCache) fetch(ctx context.Context, key string, fetch func() (*fetchEntry, error)) (*fetchEntry, bool, error) {
+	if c == nil {
+		entry, err := fetch()
+		return entry, false, err
+	}
+	if entry, ok := c.lookup(key); ok {
+		return entry, true, nil
+	}
+
+	c.mu.Lock()
+	call, ok := c.calls[key]
+	if !ok {
+		call = &fetchCall{done: make(chan struct{})}
+		c.calls[key] = call
+		go func() {
+			call.entry, call.err = fetch()
+			if call.err == nil {
+				c.store(key, call.entry)
+			}
+			c.mu.Lock()
+			delete(c.calls, key)
+			c.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.entry, false, call.err
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	}
+}
+
+// lookup returns the fresh response stored for key, from memory or else from
+// disk, in which case it is also kept in memory.
+func (c *fetchCache) lookup(key string) (*fetchEntry, bool) {
+	now := c.now()
+	c.mu.Lock()
+	if c.memory != nil {
+		if entry, ok := c.memory.get(key); ok {
+			if now.Before(entry.Expires) {
+				c.mu.Unlock()
+				return entry, true
+			}
+			c.memory.remove(key)
+		}
+	}
+	_, onDisk := c.disk.get(key)
+	c.mu.Unlock()
+	if !onDisk {
+		return nil, false
+	}
+
+	entry, err := c.readDisk(key)
+	if err != nil || !now.Before(entry.Expires) {
+		c.mu.Lock()
+		c.disk.remove(key)
+		c.mu.Unlock()
+		_ = os.Remove(c.path(key))
+		return nil, false
+	}
+	// keep the order of use across restarts
+	_ = os.Chtimes(c.path(key), now, now)
+	if c.memory != nil {
+		c.mu.Lock()
+		c.memory.add(key, entry.size(), entry)
+		c.mu.Unlock()
+	}
+	return entry, true
+}
+
+// store caches entry under key if it is still fresh.
+func (c *fetchCache) store(key string, entry *fetchEntry) {
+	if !c.now().Before(entry.Expires) {
+		return
+	}
+	c.mu.Lock()
+	if c.memory != nil {
+		c.memory.add(key, entry.size(), entry)
+	}
+	c.mu.Unlock()
+	if c.disk == nil || entry.size() > c.disk.maxBytes {
+		return
+	}
+	// a cache that cannot be written to must not fail the request
+	if err := c.writeDisk(key, entry); err != nil {
+		return
+	}
+	info, err := os.Stat(c.path(key))
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	c.disk.add(key, info.Size(), nil)
+	c.mu.Unlock()
+}
+
+func (c *fetchCache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+func (c *fetchCache) readDisk(key string) (*fetchEntry, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, err
+	}
+	var entry fetchEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *fetchCache) writeDisk(key string, entry *fetchEntry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(payload); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// loadDisk indexes the responses already in the cache directory, least
+// recently used first, evicting the oldest beyond the size limit.
+func (c *fetchCache) loadDisk() error {
+	type file struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+	var files []file
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, file{strings.TrimSuffix(d.Name(), ".json"), info.Size(), info.ModTime()})
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("read fetch cache: %w", err)
+	}
+	slices.SortFunc(files, func(a, b file) int { return a.modTime.Compare(b.modTime) })
+	for _, f := range files {
+		c.disk.add(f.key, f.size, nil)
+	}
+	return nil
+}
+
+// freshFor returns how long an upstream response with status and header may
+// be served from the cache: the shared or plain max-age less the response's
+// Age, or else the time until it Expires, or else ttl. It is zero for
+// responses that must not be cached.
+func freshFor(status int, header http.Header, ttl time.Duration, now time.Time) time.Duration {
+	if !fetchCacheable[status] {
+		return 0
+	}
+	directives := map[string]string{}
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+		}
+	}
+	for _, name := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := directives[name]; ok {
+			return 0
+		}
+	}
+	for _, name := range []string{"s-maxage", "max-age"} {
+		arg, ok := directives[name]
+		if !ok {
+			continue
+		}
+		seconds, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return 0
+		}
+		age, _ := strconv.ParseInt(header.Get("Age"), 10, 64)
+		return max(0, time.Duration(seconds-age)*time.Second)
+	}
+	if expires := header.Get("Expires"); expires != "" {
+		at, err := http.ParseTime(expires)
+		if err != nil {
+			return 0
+		}
+		return max(0, at.Sub(now))
+	}
+	return ttl
+}
+
+// lru tracks entries by size, most recently used first, and evicts the least
+// recently used when they exceed maxBytes.
+type lru struct {
+	maxBytes int64
+	bytes    int64
+	order    *list.List
+	items    map[string]*list.Element
+	evicted  func(key string)
+}
+
+type lruItem struct {
+	key   string
+	size  int64
+	entry *fetchEntry
+}
+
+func newLRU(maxBytes int64, evicted func(key string)) *lru {
+	return &lru{maxBytes: maxBytes, order: list.New(), items: map[string]*list.Element{}, evicted: evicted}
+}
+
+// get returns the entry for key, marking it as the most recently used. A nil
+// *lru holds nothing.
+func (l *lru) get(key string) (*fetchEntry, bool) {
+	if l == nil {
+		return nil, false
+	}
+	element, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(element)
+	return element.Value.(*lruItem).entry, true
+}
+
+// add stores entry as the most recently used, unless it alone exceeds
+// maxBytes.
+func (l *lru) add(key string, size int64, entry *fetchEntry) {
+	if size > l.maxBytes {
+		return
+	}
+	if element, ok := l.items[key]; ok {
+		item := element.Value.(*lruItem)
+		l.bytes += size - item.size
+		item.size, item.entry = size, entry
+		l.order.MoveToFront(element)
+	} else {
+		l.items[key] = l.order.PushFront(&lruItem{key: key, size: size, entry: entry})
+		l.bytes += size
+	}
+	for l.bytes > l.maxBytes {
+		oldest := l.order.Back().Value.(*lruItem)
+		l.remove(oldest.key)
+		if l.evicted != nil {
+			l.evicted(oldest.key)
+		}
+	}
+}
+
+func (l *lru) remove(key string) {
+	if l == nil {
+		return
+	}
+	element, ok := l.items[key]
+	if !ok {
+		return
+	}
+	l.order.Remove(element)
+	delete(l.items, key)
+	l.bytes -= element.Value.(*lruItem).size
+}
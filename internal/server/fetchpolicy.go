@@ -0,0 +1,222 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// errFetchForbidden is returned when /api/fetch refuses to connect to a host.
+var errFetchForbidden = errors.New("fetch target not allowed")
+
+// nonPublicPrefixes are the special-purpose ranges, beyond those netip.Addr
+// reports as loopback, private, link-local or multicast, that do not reach
+// public internet hosts.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("fec0::/10"),
+}
+
+// fetchPolicy decides which hosts /api/fetch may connect to. Public
+// addresses are allowed unless denied; other addresses only when allowed.
+// Hosts are checked when they are dialed, after DNS resolution, so the
+// check covers redirects and a name that resolves differently later.
+type fetchPolicy struct {
+	allow hostList
+	deny  hostList
+	// lookup resolves a host name to its addresses.
+	lookup func(ctx context.Context, host string) ([]netip.Addr, error)
+}
+
+// hostList matches hosts by name, by address, or by address range. A name
+// starting with "." matches the subdomains of the rest of it.
+type hostList struct {
+	names    []string
+	prefixes []netip.Prefix
+}
+
+// newFetchPolicy returns the policy for the allow and deny lists of Options.
+func newFetchPolicy(allow, deny []string) (*fetchPolicy, error) {
+	p := &fetchPolicy{lookup: func(ctx context.Context, host string) ([]netip.Addr, error) {
+		return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	}}
+	var err error
+	if p.allow, err = parseHostList(allow); err != nil {
+		return nil, fmt.Errorf("fetch allow list: %w", err)
+	}
+	if p.deny, err = parseHostList(deny); err != nil {
+		return nil, fmt.Errorf("fetch deny list: %w", err)
+	}
+	return p, nil
+}
+
+func parseHostList(entries []string) (hostList, error) {
+	var list hostList
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(entry), "."))
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return hostList{}, fmt.Errorf("invalid address range %q", entry)
+			}
+			list.prefixes = append(list.prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(strings.Trim(entry, "[]")); err == nil {
+			list.prefixes = append(list.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		if strings.ContainsAny(entry, ":[] ") {
+			return hostList{}, fmt.Errorf("invalid host %q", entry)
+		}
+		list.names = append(list.names, entry)
+	}
+	return list, nil
+}
+
+func (l hostList) matchesName(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, name := range l.names {
+		if host == name || (strings.HasPrefix(name, ".") && strings.HasSuffix(host, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l hostList) matchesAddr(addr netip.Addr) bool {
+	for _, prefix := range l.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+	teredoPrefix    = netip.MustParsePrefix("2001::/32")
+)
+
+// isPublicAddr reports whether addr is a unicast address of the public
+// internet. 6to4 and Teredo addresses must also embed a public IPv4 address,
+// since they are relayed to it.
+func isPublicAddr(addr netip.Addr) bool {
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	if addr.Is4() && addr == netip.AddrFrom4([4]byte{255, 255, 255, 255}) {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	if v4, ok := embeddedIPv4(addr); ok {
+		return isPublicAddr(v4)
+	}
+	return true
+}
+
+// embeddedIPv4 returns the IPv4 address a 6to4 or Teredo address reaches.
+func embeddedIPv4(addr netip.Addr) (netip.Addr, bool) {
+	b := addr.As16()
+	switch {
+	case sixToFourPrefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[2:6])), true
+	case teredoPrefix.Contains(addr):
+		// the client address is stored with its bits inverted
+		return netip.AddrFrom4([4]byte{^b[12], ^b[13], ^b[14], ^b[15]}), true
+	}
+	return netip.Addr{}, false
+}
+
+// addrs returns the addresses of host that the policy allows, or an error
+// wrapping errFetchForbidden if there are none.
+func (p *fetchPolicy) addrs(ctx context.Context, host string) ([]netip.Addr, error) {
+	if p.deny.matchesName(host) {
+		return nil, fmt.Errorf("%w: host %s is denied", errFetchForbidden, host)
+	}
+	var resolved []netip.Addr
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		resolved = []netip.Addr{addr}
+	} else {
+		if resolved, err = p.lookup(ctx, host); err != nil {
+			return nil, err
+		}
+	}
+
+	allowedName := p.allow.matchesName(host)
+	var allowed []netip.Addr
+	for _, addr := range resolved {
+		addr = addr.Unmap()
+		if p.deny.matchesAddr(addr) {
+			continue
+		}
+		if isPublicAddr(addr) || allowedName || p.allow.matchesAddr(addr) {
+			allowed = append(allowed, addr)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, fmt.Errorf("%w: host %s has no allowed public address", errFetchForbidden, host)
+	}
+	return allowed, nil
+}
+
+// dialContext connects to the first address of the host in address that the
+// policy allows. It dials the checked address rather than the name, so the
+// name cannot resolve to another address in between.
+func (p *fetchPolicy) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		addrs, err := p.addrs(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var dialErr error
+		for _, addr := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			dialErr = err
+		}
+		return nil, dialErr
+	}
+}
+
+// transport returns an HTTP transport that only connects where the policy
+// allows. It connects directly, without any proxy from the environment,
+// since the policy could not check the hosts a proxy connects to.
+func (p *fetchPolicy) transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = p.dialContext(&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+	return transport
+}
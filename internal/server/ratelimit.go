@@ -0,0 +1,192 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bucketIdleTimeout is how long a client's bucket is kept after it refills.
+const bucketIdleTimeout = 10 * time.Minute
+
+// rateLimiter gives each client a token bucket holding up to burst requests
+// that refills at rate requests per second.
+type rateLimiter struct {
+	rate    float64
+	burst   float64
+	trusted []netip.Prefix
+	now     func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter keyed by client address, taken from
+// X-Forwarded-For when the request comes from one of trustedProxies.
+func newRateLimiter(rate float64, burst int, trustedProxies []netip.Prefix) *rateLimiter {
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		trusted: trustedProxies,
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// allow takes a token from the bucket of client, or reports how long until
+// one is available.
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops the buckets of clients that have been idle long enough to refill.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	for client, b := range l.buckets {
+		if now.Sub(b.last) > refill+bucketIdleTimeout {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// handler answers requests beyond the client's rate with 429 Too Many
+// Requests and a Retry-After header.
+func (l *rateLimiter) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := clientIP(r, l.trusted)
+		ok, wait := l.allow(client)
+		if ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		seconds := int(math.Ceil(wait.Seconds()))
+		log.Printf("rate limited path=%q client=%q retry_after=%ds %s", r.URL.Path, client, seconds, clientAddressLogFields(r))
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		http.Error(w, fmt.Sprintf("too many requests from %s", client), http.StatusTooManyRequests)
+	})
+}
+
+// clientIP returns the address of the client that sent r. When r comes from
+// a trusted proxy, that is the last address in X-Forwarded-For not itself a
+// trusted proxy, since earlier entries can be set by the client.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	remote := remoteIP(r)
+	addr, err := netip.ParseAddr(remote)
+	if err != nil || !containsAddr(trustedProxies, addr) {
+		return remote
+	}
+	var forwarded []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(value, ",")...)
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			break
+		}
+		hop = hop.Unmap()
+		if !containsAddr(trustedProxies, hop) {
+			return hop.String()
+		}
+	}
+	return remote
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostLimiter caps the number of concurrent upstream requests to each host.
+type hostLimiter struct {
+	limit int
+
+	mu    sync.Mutex
+	hosts map[string]*hostSlots
+}
+
+// hostSlots are the slots of one host, kept while any request holds or
+// waits for one.
+type hostSlots struct {
+	slots chan struct{}
+	users int
+}
+
+func newHostLimiter(limit int) *hostLimiter {
+	return &hostLimiter{limit: limit, hosts: map[string]*hostSlots{}}
+}
+
+// acquire waits for a free slot for host until ctx is done, and returns the
+// function that frees it. A nil *hostLimiter never waits.
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	host = strings.ToLower(host)
+	l.mu.Lock()
+	h, ok := l.hosts[host]
+	if !ok {
+		h = &hostSlots{slots: make(chan struct{}, l.limit)}
+		l.hosts[host] = h
+	}
+	h.users++
+	l.mu.Unlock()
+
+	leave := func() {
+		l.mu.Lock()
+		if h.users--; h.users == 0 {
+			delete(l.hosts, host)
+		}
+		l.mu.Unlock()
+	}
+	select {
+	case h.slots <- struct{}{}:
+		return func() {
+			<-h.slots
+			leave()
+		}, nil
+	case <-ctx.Done():
+		leave()
+		return nil, ctx.Err()
+	}
+}
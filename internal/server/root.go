@@ -24,15 +24,18 @@ func staticFileName(urlPath string) (string, bool) {
 	return name, true
 }
 
-func clientAddressLogFields(r *http.Request) string {
-	remoteIP := r.RemoteAddr
+// remoteIP returns the address of the peer that sent r, without its port.
+func remoteIP(r *http.Request) string {
 	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
-		remoteIP = host
+		return host
 	}
+	return r.RemoteAddr
+}
 
+func clientAddressLogFields(r *http.Request) string {
 	return fmt.Sprintf("remote_addr=%q remote_ip=%q x_forwarded_for=%q x_real_ip=%q forwarded=%q",
 		r.RemoteAddr,
-		remoteIP,
+		remoteIP(r),
 		r.Header.Get("X-Forwarded-For"),
 		r.Header.Get("X-Real-IP"),
 		r.Header.Get("Forwarded"),
@@ -16,12 +16,29 @@ import (
 	"path"
 	"strings"
 	"time"
+
+	"github.com/sandialabs/bibcheck/web/workflow"
 )
 
 const (
 	DefaultAddr          = "localhost:8080"
 	DefaultWebDir        = "web/static"
 	DefaultFetchMaxBytes = 25 * 1024 * 1024
+
+	DefaultFetchCacheBytes     = 256 * 1024 * 1024
+	DefaultFetchCacheDiskBytes = 2 * 1024 * 1024 * 1024
+	DefaultFetchCacheTTL       = 24 * time.Hour
+
+	DefaultFetchRate            = 2.0
+	DefaultFetchBurst           = 60
+	DefaultFetchHostConcurrency = 4
+
+	DefaultAnalyzeQueue     = 16
+	DefaultAnalyzeMaxBytes  = 50 * 1024 * 1024
+	DefaultAnalyzeTimeout   = 30 * time.Minute
+	DefaultAnalyzeRetention = time.Hour
+	DefaultAnalyzeRate      = 1.0
+	DefaultAnalyzeBurst     = 30
 )
 
 const fetchUpstreamTimeout = 15 * time.Second
@@ -32,6 +49,54 @@ type Options struct {
 	Addr          string
 	WebDir        string
 	FetchMaxBytes int64
+	// FetchAllow lists hosts, addresses and CIDR ranges /api/fetch may
+	// reach even though they are not public, e.g. an intranet server. A
+	// name starting with "." also matches its subdomains.
+	FetchAllow []string
+	// FetchDeny lists hosts, addresses and CIDR ranges /api/fetch must not
+	// reach, even when they are public.
+	FetchDeny []string
+	// FetchCacheBytes bounds the upstream responses /api/fetch keeps in
+	// memory; a negative value disables the cache.
+	FetchCacheBytes int64
+	// FetchCacheDir, if set, keeps up to FetchCacheDiskBytes of upstream
+	// responses on disk as well, across restarts.
+	FetchCacheDir       string
+	FetchCacheDiskBytes int64
+	// FetchCacheTTL is how long responses whose Cache-Control and Expires
+	// headers say nothing are cached.
+	FetchCacheTTL time.Duration
+	// FetchRate is how many /api/fetch requests per second each client may
+	// make on average, in bursts of up to FetchBurst; a negative rate
+	// removes the limit.
+	FetchRate  float64
+	FetchBurst int
+	// FetchHostConcurrency caps the concurrent upstream requests to each
+	// host; a negative value removes the cap.
+	FetchHostConcurrency int
+	// TrustedProxies lists the addresses and CIDR ranges of reverse proxies
+	// whose X-Forwarded-For header identifies the client.
+	TrustedProxies []string
+	// AnalyzeWorkers is how many POST /api/analyze jobs run at once, with
+	// up to AnalyzeQueue more waiting. The analysis API is only served when
+	// it is positive.
+	AnalyzeWorkers int
+	AnalyzeQueue   int
+	// AnalyzeMaxBytes bounds the documents POST /api/analyze accepts.
+	AnalyzeMaxBytes int64
+	// AnalyzeTimeout bounds how long a job runs, and AnalyzeRetention how
+	// long its result is kept after it finishes.
+	AnalyzeTimeout   time.Duration
+	AnalyzeRetention time.Duration
+	// AnalyzeRate is how many analysis API requests per second each client
+	// may make on average, in bursts of up to AnalyzeBurst; a negative rate
+	// removes the limit.
+	AnalyzeRate  float64
+	AnalyzeBurst int
+	// AnalyzeKeys are the API keys for jobs whose request carries none.
+	// Anyone who can reach the server may spend them, so without them PDF
+	// requests must bring their own.
+	AnalyzeKeys workflow.Keys
 }
 
 func Handler(options Options) (http.Handler, error) {
@@ -39,7 +104,63 @@ func Handler(options Options) (http.Handler, error) {
 	if options.FetchMaxBytes < 1 {
 		return nil, fmt.Errorf("fetch-max-bytes must be positive")
 	}
-	return serveMux(options.WebDir, options.FetchMaxBytes), nil
+	policy, err := newFetchPolicy(options.FetchAllow, options.FetchDeny)
+	if err != nil {
+		return nil, err
+	}
+	trusted, err := parseHostList(options.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("trusted proxies: %w", err)
+	}
+	if len(trusted.names) > 0 {
+		return nil, fmt.Errorf("trusted proxies: %q is not an address or CIDR range", trusted.names[0])
+	}
+	var cache *fetchCache
+	if options.FetchCacheBytes > 0 || options.FetchCacheDir != "" {
+		if cache, err = newFetchCache(options.FetchCacheBytes, options.FetchCacheDir, options.FetchCacheDiskBytes); err != nil {
+			return nil, err
+		}
+	}
+	cfg := fetchConfig{
+		maxBytes: options.FetchMaxBytes,
+		timeout:  fetchUpstreamTimeout,
+		policy:   policy,
+		cache:    cache,
+		cacheTTL: options.FetchCacheTTL,
+	}
+	if options.FetchHostConcurrency > 0 {
+		cfg.hosts = newHostLimiter(options.FetchHostConcurrency)
+	}
+	fetch := fetchHandler(cfg)
+	if options.FetchRate > 0 {
+		fetch = proxyErrorHandler(newRateLimiter(options.FetchRate, options.FetchBurst, trusted.prefixes).handler(fetch))
+	}
+	var jobs *jobQueue
+	var limiter *rateLimiter
+	if options.AnalyzeWorkers > 0 {
+		if options.AnalyzeMaxBytes < 1 {
+			return nil, fmt.Errorf("analyze-max-bytes must be positive")
+		}
+		// analysis jobs run in this process and must not reach hosts that
+		// /api/fetch may not
+		jobPolicy, err := analysisFetchPolicy(options)
+		if err != nil {
+			return nil, err
+		}
+		jobs = newJobQueue(analyzeConfig{
+			keys:      options.AnalyzeKeys,
+			maxBytes:  options.AnalyzeMaxBytes,
+			workers:   options.AnalyzeWorkers,
+			queue:     options.AnalyzeQueue,
+			timeout:   options.AnalyzeTimeout,
+			retain:    options.AnalyzeRetention,
+			transport: jobPolicy.transport(),
+		})
+		if options.AnalyzeRate > 0 {
+			limiter = newRateLimiter(options.AnalyzeRate, options.AnalyzeBurst, trusted.prefixes)
+		}
+	}
+	return serveMux(options.WebDir, fetch, jobs, limiter), nil
 }
 
 func Run(options Options) error {
@@ -62,13 +183,60 @@ func (o Options) withDefaults() Options {
 	if o.FetchMaxBytes == 0 {
 		o.FetchMaxBytes = DefaultFetchMaxBytes
 	}
+	if o.FetchCacheBytes == 0 {
+		o.FetchCacheBytes = DefaultFetchCacheBytes
+	}
+	if o.FetchCacheDiskBytes == 0 {
+		o.FetchCacheDiskBytes = DefaultFetchCacheDiskBytes
+	}
+	if o.FetchCacheTTL == 0 {
+		o.FetchCacheTTL = DefaultFetchCacheTTL
+	}
+	if o.FetchRate == 0 {
+		o.FetchRate = DefaultFetchRate
+	}
+	if o.FetchBurst == 0 {
+		o.FetchBurst = DefaultFetchBurst
+	}
+	if o.FetchHostConcurrency == 0 {
+		o.FetchHostConcurrency = DefaultFetchHostConcurrency
+	}
+	if o.AnalyzeQueue == 0 {
+		o.AnalyzeQueue = DefaultAnalyzeQueue
+	}
+	if o.AnalyzeMaxBytes == 0 {
+		o.AnalyzeMaxBytes = DefaultAnalyzeMaxBytes
+	}
+	if o.AnalyzeTimeout == 0 {
+		o.AnalyzeTimeout = DefaultAnalyzeTimeout
+	}
+	if o.AnalyzeRetention == 0 {
+		o.AnalyzeRetention = DefaultAnalyzeRetention
+	}
+	if o.AnalyzeRate == 0 {
+		o.AnalyzeRate = DefaultAnalyzeRate
+	}
+	if o.AnalyzeBurst == 0 {
+		o.AnalyzeBurst = DefaultAnalyzeBurst
+	}
 	return o
 }
 
-func serveMux(staticDir string, maxBytes int64) http.Handler {
+// serveMux routes the API and the static files in staticDir. The analysis API
+// is only served when jobs is set, and is rate limited by limiter if set.
+func serveMux(staticDir string, fetch http.Handler, jobs *jobQueue, limiter *rateLimiter) http.Handler {
 	mux := http.NewServeMux()
 	mux.Handle(livenessPath, livenessHandler())
-	mux.Handle("/api/fetch", fetchHandler(maxBytes))
+	mux.Handle("/api/fetch", fetch)
+	if jobs != nil {
+		limit := func(h http.Handler) http.Handler { return h }
+		if limiter != nil {
+			limit = limiter.handler
+		}
+		mux.Handle("/api/analyze", limit(analyzeHandler(jobs)))
+		mux.Handle("/api/jobs/{id}", limit(jobHandler(jobs)))
+		mux.Handle("/api/jobs/{id}/events", limit(jobEventsHandler(jobs)))
+	}
 	mux.Handle("/", wasmBundleLogHandler(versionedFileServer(http.Dir(staticDir))))
 	return mux
 }
@@ -3,23 +3,36 @@
 package server
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/sandialabs/bibcheck/doi"
 	"github.com/sandialabs/bibcheck/internal/wasmhttp"
+	"github.com/sandialabs/bibcheck/web/workflow"
 )
 
 func TestServeMuxLiveness(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, livenessPath, nil)
 	resp := httptest.NewRecorder()
 
-	serveMux(t.TempDir(), 1024).ServeHTTP(resp, req)
+	serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), nil, nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusOK {
 		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
@@ -36,7 +49,7 @@ func TestServeMuxLivenessSupportsHead(t *testing.T) {
 	req := httptest.NewRequest(http.MethodHead, livenessPath, nil)
 	resp := httptest.NewRecorder()
 
-	serveMux(t.TempDir(), 1024).ServeHTTP(resp, req)
+	serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), nil, nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusOK {
 		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
@@ -50,7 +63,7 @@ func TestServeMuxLivenessRejectsOtherMethods(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, livenessPath, nil)
 	resp := httptest.NewRecorder()
 
-	serveMux(t.TempDir(), 1024).ServeHTTP(resp, req)
+	serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), nil, nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusMethodNotAllowed {
 		t.Fatalf("expected status %d, got %d: %s", http.StatusMethodNotAllowed, resp.Code, resp.Body.String())
@@ -73,7 +86,7 @@ func TestFetchHandlerFetchesUpstreamResponse(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(upstream.URL), nil)
 	resp := httptest.NewRecorder()
 
-	fetchHandler(1024).ServeHTTP(resp, req)
+	newTestFetchHandler(1024, loopbackFetchPolicy(t), nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusOK {
 		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
@@ -104,7 +117,7 @@ func TestFetchHandlerForwardsUserAgent(t *testing.T) {
 	req.Header.Set("User-Agent", userAgent)
 	resp := httptest.NewRecorder()
 
-	fetchHandler(1024).ServeHTTP(resp, req)
+	newTestFetchHandler(1024, loopbackFetchPolicy(t), nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusNoContent {
 		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, resp.Code, resp.Body.String())
@@ -124,7 +137,7 @@ func TestFetchHandlerUsesDefaultUserAgentWhenMissing(t *testing.T) {
 	req.Header.Del("User-Agent")
 	resp := httptest.NewRecorder()
 
-	fetchHandler(1024).ServeHTTP(resp, req)
+	newTestFetchHandler(1024, loopbackFetchPolicy(t), nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusNoContent {
 		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, resp.Code, resp.Body.String())
@@ -142,7 +155,7 @@ func TestFetchHandlerPreservesUpstreamError(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(upstream.URL), nil)
 	resp := httptest.NewRecorder()
 
-	fetchHandler(1024).ServeHTTP(resp, req)
+	newTestFetchHandler(1024, loopbackFetchPolicy(t), nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusBadGateway {
 		t.Fatalf("expected status %d, got %d", http.StatusBadGateway, resp.Code)
@@ -164,7 +177,7 @@ func TestFetchHandlerMarksUpstreamTimeoutAsProxyError(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(upstream.URL), nil)
 	resp := httptest.NewRecorder()
 
-	fetchHandlerWithTimeout(1024, 10*time.Millisecond).ServeHTTP(resp, req)
+	fetchHandler(fetchConfig{maxBytes: 1024, timeout: 10 * time.Millisecond, policy: loopbackFetchPolicy(t)}).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusGatewayTimeout {
 		t.Fatalf("expected status %d, got %d: %s", http.StatusGatewayTimeout, resp.Code, resp.Body.String())
@@ -188,7 +201,7 @@ func TestFetchHandlerRejectsOversizedUpstreamResponse(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(upstream.URL), nil)
 	resp := httptest.NewRecorder()
 
-	fetchHandler(3).ServeHTTP(resp, req)
+	newTestFetchHandler(3, loopbackFetchPolicy(t), nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusRequestEntityTooLarge {
 		t.Fatalf("expected status %d, got %d", http.StatusRequestEntityTooLarge, resp.Code)
@@ -202,7 +215,7 @@ func TestFetchHandlerRejectsUnsupportedURL(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape("file:///etc/passwd"), nil)
 	resp := httptest.NewRecorder()
 
-	fetchHandler(1024).ServeHTTP(resp, req)
+	newTestFetchHandler(1024, loopbackFetchPolicy(t), nil).ServeHTTP(resp, req)
 
 	if resp.Code != http.StatusBadRequest {
 		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, resp.Code)
@@ -212,6 +225,859 @@ func TestFetchHandlerRejectsUnsupportedURL(t *testing.T) {
 	}
 }
 
+func TestFetchHandlerRefusesLoopbackByDefault(t *testing.T) {
+	var hit atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit.Store(true)
+	}))
+	defer upstream.Close()
+
+	resp := serveFetch(t, fetchPolicyFor(t, nil, nil, nil), upstream.URL)
+
+	assertFetchForbidden(t, resp)
+	if hit.Load() {
+		t.Fatal("expected upstream not to be contacted")
+	}
+}
+
+func TestFetchHandlerRefusesMetadataAddress(t *testing.T) {
+	resp := serveFetch(t, fetchPolicyFor(t, nil, nil, nil), "http://169.254.169.254/latest/meta-data/")
+
+	assertFetchForbidden(t, resp)
+}
+
+func TestFetchHandlerRefusesNameResolvingToLoopback(t *testing.T) {
+	var hit atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit.Store(true)
+	}))
+	defer upstream.Close()
+	policy := fetchPolicyFor(t, nil, nil, map[string]string{"rebind.example": "127.0.0.1"})
+
+	resp := serveFetch(t, policy, "http://rebind.example:"+upstreamPort(upstream))
+
+	assertFetchForbidden(t, resp)
+	if hit.Load() {
+		t.Fatal("expected upstream not to be contacted")
+	}
+}
+
+func TestFetchHandlerAllowsAllowListedHost(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("intranet"))
+	}))
+	defer upstream.Close()
+	policy := fetchPolicyFor(t, []string{".intranet.example"}, nil, map[string]string{"wiki.intranet.example": "127.0.0.1"})
+
+	resp := serveFetch(t, policy, "http://wiki.intranet.example:"+upstreamPort(upstream))
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
+	}
+	if got := resp.Body.String(); got != "intranet" {
+		t.Fatalf("unexpected body: %q", got)
+	}
+}
+
+func TestFetchHandlerRechecksRedirects(t *testing.T) {
+	var internalHit atomic.Bool
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHit.Store(true)
+	}))
+	defer internal.Close()
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer redirector.Close()
+	policy := fetchPolicyFor(t, []string{"redirector.example"}, nil, map[string]string{"redirector.example": "127.0.0.1"})
+
+	resp := serveFetch(t, policy, "http://redirector.example:"+upstreamPort(redirector))
+
+	assertFetchForbidden(t, resp)
+	if internalHit.Load() {
+		t.Fatal("expected redirect target not to be contacted")
+	}
+}
+
+func TestFetchHandlerHonorsDenyList(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer upstream.Close()
+	policy := fetchPolicyFor(t, []string{"127.0.0.0/8"}, []string{"blocked.example"}, map[string]string{"blocked.example": "127.0.0.1"})
+
+	assertFetchForbidden(t, serveFetch(t, policy, "http://blocked.example:"+upstreamPort(upstream)))
+	if resp := serveFetch(t, policy, upstream.URL); resp.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNoContent, resp.Code, resp.Body.String())
+	}
+}
+
+func TestFetchPolicyDeniesPublicRange(t *testing.T) {
+	policy := fetchPolicyFor(t, nil, []string{"93.184.216.0/24"}, map[string]string{"example.com": "93.184.216.34", "example.org": "93.184.215.14"})
+
+	if _, err := policy.addrs(context.Background(), "example.com"); !errors.Is(err, errFetchForbidden) {
+		t.Fatalf("expected denied range to be refused, got %v", err)
+	}
+	if addrs, err := policy.addrs(context.Background(), "example.org"); err != nil || len(addrs) != 1 {
+		t.Fatalf("expected public address to be allowed, got %v, %v", addrs, err)
+	}
+}
+
+func TestNewFetchPolicyRejectsInvalidEntries(t *testing.T) {
+	for _, entry := range []string{"10.0.0.0/33", "bad host", "example.com:8080"} {
+		if _, err := newFetchPolicy([]string{entry}, nil); err == nil {
+			t.Errorf("expected %q to be rejected", entry)
+		}
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":                        true,
+		"2606:4700::6810:84e5":                 true,
+		"127.0.0.1":                            false,
+		"10.1.2.3":                             false,
+		"172.16.0.1":                           false,
+		"192.168.1.1":                          false,
+		"169.254.169.254":                      false,
+		"100.64.0.1":                           false,
+		"0.0.0.0":                              false,
+		"255.255.255.255":                      false,
+		"::1":                                  false,
+		"fe80::1":                              false,
+		"fd00::1":                              false,
+		"ff02::1":                              false,
+		"64:ff9b::a00:1":                       false,
+		"2002:5db8:d822::1":                    true,
+		"2002:7f00:1::1":                       false,
+		"2002:a9fe:a9fe::1":                    false,
+		"2001:0:4136:e378:8000:63bf:a2b6:25dd": true,
+		"2001:0:4136:e378:8000:63bf:80ff:fffe": false,
+	} {
+		if got := isPublicAddr(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestFetchHandlerCachesResponses(t *testing.T) {
+	var calls atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<title>cached</title>"))
+	}))
+	defer upstream.Close()
+	handler := newTestFetchHandler(1024, loopbackFetchPolicy(t), newTestFetchCache(t, ""))
+
+	for i, want := range []string{wasmhttp.FetchCacheMiss, wasmhttp.FetchCacheHit} {
+		req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(upstream.URL+"/record?b=2&a=1"), nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		if got := resp.Header().Get(wasmhttp.FetchCacheHeader); got != want {
+			t.Fatalf("request %d: expected %s %q, got %q", i, wasmhttp.FetchCacheHeader, want, got)
+		}
+		if got := resp.Body.String(); got != "<title>cached</title>" {
+			t.Fatalf("request %d: unexpected body: %q", i, got)
+		}
+		if got := resp.Header().Get("Content-Type"); got != "text/html" {
+			t.Fatalf("request %d: expected content type text/html, got %q", i, got)
+		}
+		if got := resp.Header().Get(wasmhttp.FetchResultHeader); got != wasmhttp.FetchResultUpstream {
+			t.Fatalf("request %d: expected fetch result %q, got %q", i, wasmhttp.FetchResultUpstream, got)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 upstream request, got %d", got)
+	}
+}
+
+func TestFetchHandlerChecksPolicyBeforeCache(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("cached"))
+	}))
+	defer upstream.Close()
+	dir := t.TempDir()
+	target := "/api/fetch?url=" + url.QueryEscape(upstream.URL+"/record")
+
+	resp := httptest.NewRecorder()
+	newTestFetchHandler(1024, loopbackFetchPolicy(t), newTestFetchCache(t, dir)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, target, nil))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
+	}
+
+	// after a restart with the host denied, the response on disk is refused
+	denied := fetchPolicyFor(t, []string{"127.0.0.0/8", "::1"}, []string{"127.0.0.1"}, nil)
+	resp = httptest.NewRecorder()
+	newTestFetchHandler(1024, denied, newTestFetchCache(t, dir)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, target, nil))
+	if resp.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusForbidden, resp.Code, resp.Body.String())
+	}
+	if got := resp.Header().Get(wasmhttp.FetchCacheHeader); got != "" {
+		t.Fatalf("expected no %s header, got %q", wasmhttp.FetchCacheHeader, got)
+	}
+}
+
+func TestFetchHandlerDoesNotCacheNoStore(t *testing.T) {
+	var calls atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write([]byte("fresh"))
+	}))
+	defer upstream.Close()
+	handler := newTestFetchHandler(1024, loopbackFetchPolicy(t), newTestFetchCache(t, ""))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(upstream.URL), nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		if got := resp.Header().Get(wasmhttp.FetchCacheHeader); got != wasmhttp.FetchCacheMiss {
+			t.Fatalf("request %d: expected %s %q, got %q", i, wasmhttp.FetchCacheHeader, wasmhttp.FetchCacheMiss, got)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 upstream requests, got %d", got)
+	}
+}
+
+func TestFetchHandlerCoalescesConcurrentRequests(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte("shared"))
+	}))
+	defer upstream.Close()
+	handler := newTestFetchHandler(1024, loopbackFetchPolicy(t), newTestFetchCache(t, ""))
+
+	const requests = 8
+	bodies := make(chan string, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(upstream.URL), nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			bodies <- resp.Body.String()
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(bodies)
+
+	for body := range bodies {
+		if body != "shared" {
+			t.Fatalf("unexpected body: %q", body)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected 1 upstream request, got %d", got)
+	}
+}
+
+func TestFetchCacheKeepsResponsesOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	key := fetchCacheKey(&url.URL{Scheme: "https", Host: "www.osti.gov", Path: "/biblio/1504115"})
+	entry := &fetchEntry{Status: http.StatusOK, ContentType: "text/html", Body: []byte("record"), Expires: time.Now().Add(time.Hour)}
+	newTestFetchCache(t, dir).store(key, entry)
+
+	reopened := newTestFetchCache(t, dir)
+	got, ok := reopened.lookup(key)
+	if !ok || string(got.Body) != "record" || got.ContentType != "text/html" {
+		t.Fatalf("expected stored response after reopening, got %+v, %v", got, ok)
+	}
+
+	reopened = newTestFetchCache(t, dir)
+	reopened.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, ok := reopened.lookup(key); ok {
+		t.Fatal("expected expired response to be refetched")
+	}
+}
+
+func TestFetchCacheKeyNormalizesURL(t *testing.T) {
+	a, _ := url.Parse("https://WWW.osti.gov:443/biblio/1?b=2&a=1#top")
+	b, _ := url.Parse("https://www.osti.gov/biblio/1?a=1&b=2")
+	if fetchCacheKey(a) != fetchCacheKey(b) {
+		t.Fatalf("expected %s and %s to share a key", a, b)
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	var evicted []string
+	cache := newLRU(10, func(key string) { evicted = append(evicted, key) })
+	cache.add("a", 4, nil)
+	cache.add("b", 4, nil)
+	cache.get("a")
+	cache.add("c", 4, nil)
+	cache.add("huge", 11, nil)
+
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Fatalf("expected b to be evicted, got %v", evicted)
+	}
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true, "huge": false} {
+		if _, ok := cache.items[key]; ok != want {
+			t.Errorf("expected %s cached=%v", key, want)
+		}
+	}
+}
+
+func TestFreshFor(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		status int
+		header http.Header
+		want   time.Duration
+	}{
+		{http.StatusOK, http.Header{}, time.Hour},
+		{http.StatusNotFound, http.Header{}, time.Hour},
+		{http.StatusInternalServerError, http.Header{}, 0},
+		{http.StatusOK, http.Header{"Cache-Control": {"public, max-age=600"}, "Age": {"100"}}, 500 * time.Second},
+		{http.StatusOK, http.Header{"Cache-Control": {"max-age=600, s-maxage=60"}}, time.Minute},
+		{http.StatusOK, http.Header{"Cache-Control": {"no-cache"}}, 0},
+		{http.StatusOK, http.Header{"Cache-Control": {"private, max-age=600"}}, 0},
+		{http.StatusOK, http.Header{"Expires": {"Wed, 01 Jan 2025 00:10:00 GMT"}}, 10 * time.Minute},
+		{http.StatusOK, http.Header{"Expires": {"0"}}, 0},
+	} {
+		if got := freshFor(tc.status, tc.header, time.Hour, now); got != tc.want {
+			t.Errorf("freshFor(%d, %v) = %s, want %s", tc.status, tc.header, got, tc.want)
+		}
+	}
+}
+
+func TestRateLimiterAnswersTooManyRequests(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(1, 2, nil)
+	limiter.now = func() time.Time { return now }
+	handler := proxyErrorHandler(limiter.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/fetch?url=https%3A%2F%2Fwww.osti.gov%2F", nil)
+		req.RemoteAddr = remoteAddr
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp := serve("192.0.2.7:5000"); resp.Code != http.StatusNoContent {
+			t.Fatalf("request %d: expected status %d, got %d", i, http.StatusNoContent, resp.Code)
+		}
+	}
+	resp := serve("192.0.2.7:5001")
+	if resp.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, resp.Code)
+	}
+	if got := resp.Header().Get("Retry-After"); got != "1" {
+		t.Fatalf("expected Retry-After 1, got %q", got)
+	}
+	if got := resp.Header().Get(wasmhttp.FetchResultHeader); got != wasmhttp.FetchResultProxyError {
+		t.Fatalf("expected fetch result %q, got %q", wasmhttp.FetchResultProxyError, got)
+	}
+	if !strings.Contains(resp.Body.String(), "too many requests from 192.0.2.7") {
+		t.Fatalf("unexpected body: %q", resp.Body.String())
+	}
+
+	if resp := serve("192.0.2.8:5000"); resp.Code != http.StatusNoContent {
+		t.Fatalf("expected another client to be served, got %d", resp.Code)
+	}
+	now = now.Add(time.Second)
+	if resp := serve("192.0.2.7:5000"); resp.Code != http.StatusNoContent {
+		t.Fatalf("expected a refilled bucket to be served, got %d", resp.Code)
+	}
+}
+
+func TestClientIPHonorsOnlyTrustedProxies(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	for _, tc := range []struct {
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{"192.0.2.7:5000", nil, "192.0.2.7"},
+		{"192.0.2.7:5000", []string{"198.51.100.1"}, "192.0.2.7"},
+		{"10.0.0.2:5000", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"10.0.0.2:5000", []string{"203.0.113.66, 198.51.100.1, 10.0.0.3"}, "198.51.100.1"},
+		{"10.0.0.2:5000", []string{"203.0.113.66", "198.51.100.1"}, "198.51.100.1"},
+		{"10.0.0.2:5000", []string{"10.0.0.3"}, "10.0.0.2"},
+		{"10.0.0.2:5000", []string{"not-an-address"}, "10.0.0.2"},
+		{"[::ffff:10.0.0.2]:5000", []string{"198.51.100.1"}, "198.51.100.1"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/fetch", nil)
+		req.RemoteAddr = tc.remoteAddr
+		for _, value := range tc.forwarded {
+			req.Header.Add("X-Forwarded-For", value)
+		}
+		if got := clientIP(req, trusted); got != tc.want {
+			t.Errorf("clientIP(%s, %v) = %s, want %s", tc.remoteAddr, tc.forwarded, got, tc.want)
+		}
+	}
+}
+
+func TestHostLimiterCapsConcurrentRequests(t *testing.T) {
+	limiter := newHostLimiter(1)
+	release, err := limiter.acquire(context.Background(), "www.osti.gov")
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx, "WWW.osti.gov"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a second request to the host to wait, got %v", err)
+	}
+	other, err := limiter.acquire(context.Background(), "arxiv.org")
+	if err != nil {
+		t.Fatalf("acquire another host: %v", err)
+	}
+	other()
+
+	release()
+	release, err = limiter.acquire(context.Background(), "www.osti.gov")
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	release()
+	if len(limiter.hosts) != 0 {
+		t.Fatalf("expected idle hosts to be dropped, got %v", limiter.hosts)
+	}
+}
+
+func TestFetchHandlerLimitsConcurrentRequestsPerHost(t *testing.T) {
+	var active, peak atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer upstream.Close()
+	handler := fetchHandler(fetchConfig{
+		maxBytes: 1024,
+		timeout:  5 * time.Second,
+		policy:   loopbackFetchPolicy(t),
+		hosts:    newHostLimiter(2),
+	})
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 6)
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(fmt.Sprintf("%s/%d", upstream.URL, i)), nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			codes <- resp.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	for code := range codes {
+		if code != http.StatusNoContent {
+			t.Fatalf("expected status %d, got %d", http.StatusNoContent, code)
+		}
+	}
+	if got := peak.Load(); got > 2 {
+		t.Fatalf("expected at most 2 concurrent upstream requests, got %d", got)
+	}
+}
+
+func TestHandlerRejectsNamedTrustedProxy(t *testing.T) {
+	if _, err := Handler(Options{WebDir: t.TempDir(), TrustedProxies: []string{"proxy.example"}}); err == nil {
+		t.Fatal("expected a trusted proxy name to be rejected")
+	}
+}
+
+func TestAnalyzeHandlerRunsJob(t *testing.T) {
+	jobs := newTestJobQueue(t, 1, 1, func(_ context.Context, rt *workflow.Runtime, data []byte, options workflow.Options, progress workflow.Progress) workflow.State {
+		progress(workflow.State{Provider: rt.Kind, Phase: "Processing entries", Total: 1})
+		return workflow.State{Provider: rt.Kind, Phase: "Done", Total: 1, Completed: 1,
+			Entries: []workflow.EntryState{{ID: options.Entry, Text: string(data)}}}
+	})
+	handler := serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), jobs, nil)
+
+	const bib = "@book{knuth84, title={The TeXbook}}"
+	resp := postAnalyze(t, handler, "/api/analyze?entry=knuth84", bib, nil)
+	if resp.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, resp.Code, resp.Body.String())
+	}
+	var submitted struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &submitted); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if submitted.ID == "" || submitted.URL != "/api/jobs/"+submitted.ID {
+		t.Fatalf("unexpected response: %+v", submitted)
+	}
+	if got := resp.Header().Get("Location"); got != submitted.URL {
+		t.Fatalf("expected Location %q, got %q", submitted.URL, got)
+	}
+
+	state := waitForJob(t, handler, submitted.URL)
+	if state.Phase != "Done" || state.Provider != workflow.ProviderNone {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if len(state.Entries) != 1 || state.Entries[0].ID != "knuth84" || state.Entries[0].Text != bib {
+		t.Fatalf("unexpected entries: %+v", state.Entries)
+	}
+}
+
+func TestAnalyzeHandlerChecksOrphansOnlyWhenAsked(t *testing.T) {
+	jobs := newTestJobQueue(t, 1, 2, func(_ context.Context, rt *workflow.Runtime, _ []byte, options workflow.Options, _ workflow.Progress) workflow.State {
+		state := workflow.State{Provider: rt.Kind, Phase: "Done"}
+		if options.Orphans {
+			state.Orphans = &workflow.OrphansView{}
+		}
+		return state
+	})
+	handler := serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), jobs, nil)
+
+	for target, want := range map[string]bool{
+		"/api/analyze":              false,
+		"/api/analyze?orphans=true": true,
+	} {
+		resp := postAnalyze(t, handler, target, "@misc{a}", nil)
+		if resp.Code != http.StatusAccepted {
+			t.Fatalf("%s: expected status %d, got %d: %s", target, http.StatusAccepted, resp.Code, resp.Body.String())
+		}
+		if state := waitForJob(t, handler, resp.Header().Get("Location")); (state.Orphans != nil) != want {
+			t.Fatalf("%s: expected orphans %v, got %+v", target, want, state)
+		}
+	}
+}
+
+func TestAnalyzeHandlerUsesRequestKeysForPDF(t *testing.T) {
+	jobs := newTestJobQueue(t, 1, 1, func(_ context.Context, rt *workflow.Runtime, _ []byte, _ workflow.Options, _ workflow.Progress) workflow.State {
+		return workflow.State{Provider: rt.Kind, Phase: "Done"}
+	})
+	handler := serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), jobs, nil)
+
+	resp := postAnalyze(t, handler, "/api/analyze", "%PDF-1.7\n", nil)
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d without a key, got %d: %s", http.StatusBadRequest, resp.Code, resp.Body.String())
+	}
+
+	resp = postAnalyze(t, handler, "/api/analyze", "%PDF-1.7\n", map[string]string{openRouterAPIKeyHeader: "key"})
+	if resp.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, resp.Code, resp.Body.String())
+	}
+	state := waitForJob(t, handler, resp.Header().Get("Location"))
+	if state.Provider != workflow.ProviderOpenRouter {
+		t.Fatalf("expected provider %q, got %q", workflow.ProviderOpenRouter, state.Provider)
+	}
+}
+
+func TestAnalyzeHandlerRunsJobsThroughFetchPolicy(t *testing.T) {
+	var calls atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer upstream.Close()
+	policy, err := analysisFetchPolicy(Options{})
+	if err != nil {
+		t.Fatalf("analysis fetch policy: %v", err)
+	}
+	jobs := newTestJobQueue(t, 1, 1, func(ctx context.Context, rt *workflow.Runtime, _ []byte, _ workflow.Options, _ workflow.Progress) workflow.State {
+		client := &http.Client{Transport: rt.Transport}
+		resp, err := client.Get(upstream.URL)
+		if err != nil {
+			return workflow.State{Phase: "Error", Error: err.Error()}
+		}
+		resp.Body.Close()
+		return workflow.State{Phase: "Done"}
+	})
+	jobs.cfg.transport = policy.transport()
+	handler := serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), jobs, nil)
+
+	resp := postAnalyze(t, handler, "/api/analyze", "@misc{a}", nil)
+	if resp.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, resp.Code, resp.Body.String())
+	}
+	state := waitForJob(t, handler, resp.Header().Get("Location"))
+	if state.Phase != "Error" || !strings.Contains(state.Error, errFetchForbidden.Error()) {
+		t.Fatalf("expected the loopback request to be refused, got %+v", state)
+	}
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("expected no upstream request, got %d", got)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestAnalyzeHandlerJobsSkipHTTPCache(t *testing.T) {
+	t.Setenv("HTTP_CACHE_ENABLED", "true")
+	t.Setenv("HTTP_CACHE_DIR", t.TempDir())
+	// a CLI run, outside the fetch policy, leaves doi.org's answer on disk
+	seed := doi.NewClient(doi.WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"responseCode": 1, "handle": "10.1000/cached"}`)),
+			Request:    req,
+		}, nil
+	})))
+	if _, err := seed.ResolveDOI(context.Background(), "10.1000/cached"); err != nil {
+		t.Fatalf("seed cache: %v", err)
+	}
+
+	policy, err := analysisFetchPolicy(Options{FetchDeny: []string{".org", ".gov"}})
+	if err != nil {
+		t.Fatalf("analysis fetch policy: %v", err)
+	}
+	jobs := newTestJobQueue(t, 1, 1, workflow.AnalyzeBibTeX)
+	jobs.cfg.transport = policy.transport()
+	handler := serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), jobs, nil)
+
+	resp := postAnalyze(t, handler, "/api/analyze", "@misc{cached, title = {Cached}, doi = {10.1000/cached}}", nil)
+	if resp.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, resp.Code, resp.Body.String())
+	}
+	state := waitForJob(t, handler, resp.Header().Get("Location"))
+	if state.Phase != "Done" || len(state.Entries) != 1 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	i := slices.IndexFunc(state.Entries[0].LookupCards, func(card workflow.LookupCard) bool { return card.Name == "DOI" })
+	if i < 0 {
+		t.Fatalf("no DOI lookup in %+v", state.Entries[0].LookupCards)
+	}
+	if card := state.Entries[0].LookupCards[i]; !strings.Contains(card.Detail, errFetchForbidden.Error()) {
+		t.Fatalf("expected the denied DOI lookup to fail, got %+v", card)
+	}
+}
+
+func TestAnalyzeHandlerRefusesJobsBeyondQueue(t *testing.T) {
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	jobs := newTestJobQueue(t, 1, 1, func(_ context.Context, rt *workflow.Runtime, _ []byte, _ workflow.Options, _ workflow.Progress) workflow.State {
+		started <- struct{}{}
+		<-release
+		return workflow.State{Provider: rt.Kind, Phase: "Done"}
+	})
+	defer close(release)
+	handler := serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), jobs, nil)
+
+	if resp := postAnalyze(t, handler, "/api/analyze", "@misc{a}", nil); resp.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, resp.Code, resp.Body.String())
+	}
+	<-started
+	queued := postAnalyze(t, handler, "/api/analyze", "@misc{b}", nil)
+	if queued.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, queued.Code, queued.Body.String())
+	}
+	resp := postAnalyze(t, handler, "/api/analyze", "@misc{c}", nil)
+	if resp.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusServiceUnavailable, resp.Code, resp.Body.String())
+	}
+	if resp.Header().Get("Retry-After") == "" {
+		t.Fatal("expected Retry-After header")
+	}
+
+	if state := getJob(t, handler, queued.Header().Get("Location")); state.Phase != jobPhaseQueued {
+		t.Fatalf("expected phase %q, got %q", jobPhaseQueued, state.Phase)
+	}
+}
+
+func TestJobHandlerForgetsFinishedJobs(t *testing.T) {
+	jobs := newTestJobQueue(t, 1, 1, func(_ context.Context, rt *workflow.Runtime, _ []byte, _ workflow.Options, _ workflow.Progress) workflow.State {
+		return workflow.State{Provider: rt.Kind, Phase: "Done"}
+	})
+	var elapsed atomic.Int64
+	start := time.Now()
+	jobs.now = func() time.Time { return start.Add(time.Duration(elapsed.Load())) }
+	handler := serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), jobs, nil)
+
+	location := postAnalyze(t, handler, "/api/analyze", "@misc{a}", nil).Header().Get("Location")
+	waitForJob(t, handler, location)
+	elapsed.Store(int64(jobs.cfg.retain + time.Second))
+
+	req := httptest.NewRequest(http.MethodGet, location, nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	if resp.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNotFound, resp.Code, resp.Body.String())
+	}
+}
+
+func TestAnalyzeHandlerRejectsOtherMethods(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/analyze", nil)
+	resp := httptest.NewRecorder()
+	serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), newTestJobQueue(t, 1, 1, nil), nil).ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusMethodNotAllowed, resp.Code, resp.Body.String())
+	}
+	if got := resp.Header().Get("Allow"); got != http.MethodPost {
+		t.Fatalf("expected Allow POST, got %q", got)
+	}
+}
+
+func TestJobEventsStreamStateUntilDone(t *testing.T) {
+	step := make(chan struct{})
+	jobs := newTestJobQueue(t, 1, 1, func(_ context.Context, rt *workflow.Runtime, _ []byte, _ workflow.Options, progress workflow.Progress) workflow.State {
+		<-step
+		progress(workflow.State{Provider: rt.Kind, Phase: "Processing entries", Total: 2, Completed: 1})
+		<-step
+		return workflow.State{Provider: rt.Kind, Phase: "Done", Total: 2, Completed: 2}
+	})
+	server := httptest.NewServer(serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), jobs, nil))
+	defer server.Close()
+
+	location := postAnalyze(t, server.Config.Handler, "/api/analyze", "@misc{a}", nil).Header().Get("Location")
+	resp, err := http.Get(server.URL + location + "/events")
+	if err != nil {
+		t.Fatalf("get events: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("expected Content-Type text/event-stream, got %q", got)
+	}
+
+	events := bufio.NewScanner(resp.Body)
+	next := func() (string, workflow.State) {
+		t.Helper()
+		var event string
+		var state workflow.State
+		for events.Scan() {
+			line := events.Text()
+			switch {
+			case line == "" && event != "":
+				return event, state
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &state); err != nil {
+					t.Fatalf("decode event data: %v", err)
+				}
+			}
+		}
+		t.Fatalf("event stream ended: %v", events.Err())
+		return "", state
+	}
+
+	if event, state := next(); event != "state" || state.Phase != jobPhaseQueued {
+		t.Fatalf("expected a state event in phase %q, got %q in phase %q", jobPhaseQueued, event, state.Phase)
+	}
+	step <- struct{}{}
+	for {
+		event, state := next()
+		if event == "state" && state.Phase == "Processing entries" {
+			if state.Completed != 1 {
+				t.Fatalf("expected 1 completed entry, got %d", state.Completed)
+			}
+			break
+		}
+		if event != "state" {
+			t.Fatalf("expected a state event, got %q", event)
+		}
+	}
+	step <- struct{}{}
+	for {
+		event, state := next()
+		if event == "done" {
+			if state.Phase != "Done" || state.Completed != 2 {
+				t.Fatalf("unexpected final state: %+v", state)
+			}
+			break
+		}
+	}
+	if events.Scan() {
+		t.Fatalf("expected the stream to end after done, got %q", events.Text())
+	}
+}
+
+func TestJobEventsUnknownJob(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/missing/events", nil)
+	resp := httptest.NewRecorder()
+	serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), newTestJobQueue(t, 1, 1, nil), nil).ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusNotFound, resp.Code, resp.Body.String())
+	}
+}
+
+func TestHandlerServesAnalysisAPIOnlyWhenEnabled(t *testing.T) {
+	for _, tc := range []struct {
+		workers int
+		want    int
+	}{
+		{0, http.StatusNotFound},
+		{1, http.StatusMethodNotAllowed},
+	} {
+		handler, err := Handler(Options{WebDir: t.TempDir(), AnalyzeWorkers: tc.workers})
+		if err != nil {
+			t.Fatalf("handler: %v", err)
+		}
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/analyze", nil))
+		if resp.Code != tc.want {
+			t.Errorf("workers %d: expected status %d, got %d: %s", tc.workers, tc.want, resp.Code, resp.Body.String())
+		}
+	}
+}
+
+func TestAnalysisAPIIsRateLimited(t *testing.T) {
+	limiter := newRateLimiter(1, 2, nil)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	handler := serveMux(t.TempDir(), newTestFetchHandler(1024, loopbackFetchPolicy(t), nil), newTestJobQueue(t, 1, 1, nil), limiter)
+
+	// the endpoints share each client's bucket
+	for _, tc := range []struct {
+		path string
+		want int
+	}{
+		{"/api/analyze", http.StatusMethodNotAllowed},
+		{"/api/jobs/missing", http.StatusNotFound},
+		{"/api/jobs/missing/events", http.StatusTooManyRequests},
+	} {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if resp.Code != tc.want {
+			t.Fatalf("%s: expected status %d, got %d: %s", tc.path, tc.want, resp.Code, resp.Body.String())
+		}
+		if got := resp.Header().Get(wasmhttp.FetchResultHeader); got != "" {
+			t.Fatalf("%s: expected no %s header, got %q", tc.path, wasmhttp.FetchResultHeader, got)
+		}
+	}
+}
+
+func TestAnalysisFetchPolicyAllowsShirtyHost(t *testing.T) {
+	policy, err := analysisFetchPolicy(Options{
+		FetchAllow:  []string{"intranet.example"},
+		AnalyzeKeys: workflow.Keys{ShirtyBaseURL: "https://shirty.example/api/v1"},
+	})
+	if err != nil {
+		t.Fatalf("analysis fetch policy: %v", err)
+	}
+	for _, host := range []string{"intranet.example", "shirty.example"} {
+		if !policy.allow.matchesName(host) {
+			t.Errorf("expected %s to be allowed", host)
+		}
+	}
+}
+
 func TestVersionedFileServerDoesNotStoreIndex(t *testing.T) {
 	dir := staticTestDir(t)
 	writeStaticFile(t, dir, "index.html", `<script src="/wasm_exec.js"></script><script>fetch("/app.wasm")</script>`)
@@ -386,6 +1252,130 @@ func TestCompressedFileServerDoesNotServeOrphanedCompressedFile(t *testing.T) {
 	}
 }
 
+// loopbackFetchPolicy allows the loopback addresses httptest servers listen on.
+// newTestFetchHandler returns the /api/fetch handler with the server's
+// defaults for everything but maxBytes, policy and cache.
+func newTestFetchHandler(maxBytes int64, policy *fetchPolicy, cache *fetchCache) http.Handler {
+	return fetchHandler(fetchConfig{
+		maxBytes: maxBytes,
+		timeout:  fetchUpstreamTimeout,
+		policy:   policy,
+		cache:    cache,
+		cacheTTL: DefaultFetchCacheTTL,
+	})
+}
+
+func loopbackFetchPolicy(t *testing.T) *fetchPolicy {
+	t.Helper()
+	return fetchPolicyFor(t, []string{"127.0.0.0/8", "::1"}, nil, nil)
+}
+
+// fetchPolicyFor returns a policy that resolves the host names in hosts to
+// their address and refuses to resolve other names.
+func fetchPolicyFor(t *testing.T, allow, deny []string, hosts map[string]string) *fetchPolicy {
+	t.Helper()
+	policy, err := newFetchPolicy(allow, deny)
+	if err != nil {
+		t.Fatalf("new fetch policy: %v", err)
+	}
+	policy.lookup = func(_ context.Context, host string) ([]netip.Addr, error) {
+		addr, ok := hosts[host]
+		if !ok {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return []netip.Addr{netip.MustParseAddr(addr)}, nil
+	}
+	return policy
+}
+
+func newTestFetchCache(t *testing.T, dir string) *fetchCache {
+	t.Helper()
+	cache, err := newFetchCache(1<<20, dir, 1<<20)
+	if err != nil {
+		t.Fatalf("new fetch cache: %v", err)
+	}
+	return cache
+}
+
+func serveFetch(t *testing.T, policy *fetchPolicy, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/fetch?url="+url.QueryEscape(target), nil)
+	resp := httptest.NewRecorder()
+	newTestFetchHandler(1024, policy, nil).ServeHTTP(resp, req)
+	return resp
+}
+
+func upstreamPort(server *httptest.Server) string {
+	return server.URL[strings.LastIndex(server.URL, ":")+1:]
+}
+
+func assertFetchForbidden(t *testing.T, resp *httptest.ResponseRecorder) {
+	t.Helper()
+	if resp.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusForbidden, resp.Code, resp.Body.String())
+	}
+	if got := resp.Header().Get(wasmhttp.FetchResultHeader); got != wasmhttp.FetchResultProxyError {
+		t.Fatalf("expected fetch result %q, got %q", wasmhttp.FetchResultProxyError, got)
+	}
+}
+
+// newTestJobQueue returns a job queue that runs analyze for both PDF and
+// BibTeX documents.
+func newTestJobQueue(t *testing.T, workers, queue int, analyze analyzeFunc) *jobQueue {
+	t.Helper()
+	jobs := newJobQueue(analyzeConfig{
+		maxBytes: 1024,
+		workers:  workers,
+		queue:    queue,
+		timeout:  time.Minute,
+		retain:   time.Hour,
+	})
+	jobs.analyzePDF, jobs.analyzeBibTeX = analyze, analyze
+	return jobs
+}
+
+func postAnalyze(t *testing.T, handler http.Handler, target, body string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	return resp
+}
+
+func getJob(t *testing.T, handler http.Handler, location string) workflow.State {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, location, nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
+	}
+	var state workflow.State
+	if err := json.Unmarshal(resp.Body.Bytes(), &state); err != nil {
+		t.Fatalf("decode job state: %v", err)
+	}
+	return state
+}
+
+// waitForJob polls the job at location until it is done or has failed.
+func waitForJob(t *testing.T, handler http.Handler, location string) workflow.State {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		state := getJob(t, handler, location)
+		if state.Phase == "Done" || state.Phase == "Error" {
+			return state
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job still in phase %q", state.Phase)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 func staticTestDir(t *testing.T) string {
 	t.Helper()
 	return t.TempDir()
@@ -6,4 +6,9 @@ const (
 	FetchResultHeader     = "X-Bibcheck-Fetch-Result"
 	FetchResultUpstream   = "upstream"
 	FetchResultProxyError = "proxy-error"
+
+	// FetchCacheHeader tells whether /api/fetch answered from its cache.
+	FetchCacheHeader = "X-Cache"
+	FetchCacheHit    = "HIT"
+	FetchCacheMiss   = "MISS"
 )
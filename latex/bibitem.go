@@ -0,0 +1,124 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package latex
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Item is one \bibitem of a rendered bibliography.
+type Item struct {
+	// Key is the citation key used with \cite.
+	Key string
+	// Label is the optional [label], as plain text.
+	Label string
+	// Text is the entry as plain text.
+	Text string
+}
+
+var (
+	bibliographyRe = regexp.MustCompile(`(?s)\\begin\s*\{thebibliography\}(.*?)(?:\\end\s*\{thebibliography\}|$)`)
+	bibitemRe      = regexp.MustCompile(`\\bibitem\b\s*`)
+	commentLineRe  = regexp.MustCompile(`(?m)^[ \t]*%.*\n?`)
+	continuationRe = regexp.MustCompile(`([^\\])%[ \t]*\n[ \t]*`)
+
+	// markup that should disappear along with its arguments, e.g. the field
+	// names of \bibinfo{author}{...} in .bbl files written by natbib styles
+	dropRe     = regexp.MustCompile(`\\(?:bibinfo|bibfield|BibitemShut|providecommand\*?\s*\{\\[A-Za-z]+\}(?:\[\d\])?)\s*\{[^{}]*\}|\\(?:penalty|hskip|vskip)\s*-?[\d.]+\w*|\\(?:BibitemOpen|urlprefix|doiprefix|newblock|natexlab)\b`)
+	hrefRe     = regexp.MustCompile(`\\href\s*\{([^{}]*)\}\s*\{([^{}]*)\}`)
+	verbatimRe = regexp.MustCompile(`\\(url|doi)\s*\{([^{}]*)\}`)
+	// placeholderRe matches what itemText puts in place of a verbatim argument
+	placeholderRe = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// BibItems returns the \bibitem entries of the thebibliography environments
+// in src, which may be a .bbl file or a whole .tex document.
+func BibItems(src string) []Item {
+	src = commentLineRe.ReplaceAllString(src, "")
+	src = continuationRe.ReplaceAllString(src, "$1")
+
+	bodies := []string{}
+	for _, m := range bibliographyRe.FindAllStringSubmatch(src, -1) {
+		bodies = append(bodies, m[1])
+	}
+
+	items := []Item{}
+	for _, body := range bodies {
+		chunks := bibitemRe.Split(body, -1)
+		// chunks[0] is the environment's {widest-label} argument
+		for _, chunk := range chunks[1:] {
+			if item, ok := parseBibItem(chunk); ok {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+func parseBibItem(chunk string) (Item, bool) {
+	var item Item
+	rest := strings.TrimLeft(chunk, " \t\n")
+	if strings.HasPrefix(rest, "[") {
+		label, n := bracketed(rest, '[', ']')
+		item.Label = ToText(label)
+		rest = strings.TrimLeft(rest[n:], " \t\n")
+	}
+	if !strings.HasPrefix(rest, "{") {
+		return item, false
+	}
+	key, n := bracketed(rest, '{', '}')
+	item.Key = strings.TrimSpace(key)
+	item.Text = itemText(rest[n:])
+	return item, item.Key != "" && item.Text != ""
+}
+
+// bracketed returns the contents of the group opening s and the length of
+// the group, honoring nested braces.
+func bracketed(s string, open, close byte) (string, int) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '{' && open != '{':
+			depth++
+		case s[i] == '}' && close != '}':
+			depth--
+		case s[i] == open:
+			depth++
+		case s[i] == close:
+			depth--
+			if depth == 0 {
+				return s[1:i], i + 1
+			}
+		}
+	}
+	return s[1:], len(s)
+}
+
+func itemText(s string) string {
+	s = dropRe.ReplaceAllString(s, " ")
+
+	// URLs and DOIs are set verbatim, so their "~" and "--" must not become a
+	// space and a dash; they are held out of ToText behind placeholders
+	var verbatim []string
+	hold := func(text string) string {
+		verbatim = append(verbatim, strings.TrimSpace(text))
+		return "\x00" + strconv.Itoa(len(verbatim)-1) + "\x00"
+	}
+	s = hrefRe.ReplaceAllStringFunc(s, func(m string) string {
+		groups := hrefRe.FindStringSubmatch(m)
+		return "{" + groups[2] + "} " + hold(groups[1])
+	})
+	s = verbatimRe.ReplaceAllStringFunc(s, func(m string) string {
+		groups := verbatimRe.FindStringSubmatch(m)
+		if groups[1] == "doi" {
+			return hold("doi:" + strings.TrimSpace(groups[2]))
+		}
+		return hold(groups[2])
+	})
+	return placeholderRe.ReplaceAllStringFunc(ToText(s), func(m string) string {
+		i, _ := strconv.Atoi(placeholderRe.FindStringSubmatch(m)[1])
+		return verbatim[i]
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package latex
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testBBL = `\begin{thebibliography}{10}
+\providecommand{\url}[1]{\texttt{#1}}
+\providecommand{\doi}[1]{doi: #1}
+
+\bibitem{edwards2014kokkos}
+H.~C. Edwards, C.~R. Trott, and D.~Sunderland.
+\newblock Kokkos: Enabling manycore performance portability through
+  polymorphic memory access patterns.
+\newblock \emph{J. Parallel Distrib. Comput.}, 74(12):3202--3216, 2014.
+\newblock \doi{10.1016/j.jpdc.2014.07.003}.
+
+% a comment between items
+\bibitem[M{\"u}ller et~al.(2020)M{\"u}ller, Smith, and
+  Jones]{muller2020}
+J.~M{\"u}ller, A.~Smith, and B.~Jones.
+\newblock Sparse solvers%
+\newblock \href{https://example.com/paper}{online}.
+
+\bibitem{empty}
+
+\end{thebibliography}
+`
+
+func TestBibItems(t *testing.T) {
+	got := BibItems(testBBL)
+	want := []Item{
+		{
+			Key:  "edwards2014kokkos",
+			Text: "H. C. Edwards, C. R. Trott, and D. Sunderland. Kokkos: Enabling manycore performance portability through polymorphic memory access patterns. J. Parallel Distrib. Comput., 74(12):3202–3216, 2014. doi:10.1016/j.jpdc.2014.07.003.",
+		},
+		{
+			Key:   "muller2020",
+			Label: "Müller et al.(2020)Müller, Smith, and Jones",
+			Text:  "J. Müller, A. Smith, and B. Jones. Sparse solvers online https://example.com/paper.",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got  %#v\nwant %#v", got, want)
+	}
+}
+
+func TestBibItemsInDocument(t *testing.T) {
+	doc := `\documentclass{article}
+\begin{document}
+As shown in~\cite{knuth}.
+\begin{thebibliography}{9}
+\bibitem{knuth} D.~E. Knuth. \textit{The \TeX book}. Addison-Wesley, 1984.
+\end{thebibliography}
+\end{document}`
+	got := BibItems(doc)
+	if len(got) != 1 || got[0].Key != "knuth" || got[0].Text != "D. E. Knuth. The TeXbook. Addison-Wesley, 1984." {
+		t.Fatalf("unexpected items: %#v", got)
+	}
+
+	if got := BibItems(`no bibliography here`); len(got) != 0 {
+		t.Fatalf("unexpected items: %#v", got)
+	}
+}
+
+func TestBibItemsKeepURLsAndDOIsVerbatim(t *testing.T) {
+	bbl := `\begin{thebibliography}{9}
+\bibitem{page} A.~Author. Home page. \url{https://example.com/~author/a--b}.
+\bibitem{paper} A.~Author. A paper. \doi{10.1002/(SICI)1097-0258(19980815/30)17:15/16<1661::AID-SIM968>3.0.CO;2-2}, \href{https://example.com/~x}{PDF}.
+\end{thebibliography}`
+	got := BibItems(bbl)
+	want := []string{
+		"A. Author. Home page. https://example.com/~author/a--b.",
+		"A. Author. A paper. doi:10.1002/(SICI)1097-0258(19980815/30)17:15/16<1661::AID-SIM968>3.0.CO;2-2, PDF https://example.com/~x.",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected items: %#v", got)
+	}
+	for i := range want {
+		if got[i].Text != want[i] {
+			t.Errorf("%s: got %q, want %q", got[i].Key, got[i].Text, want[i])
+		}
+	}
+}
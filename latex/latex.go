@@ -0,0 +1,164 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package latex converts the LaTeX markup found in bibliography fields to
+// plain text.
+package latex
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// combining maps LaTeX accent commands to Unicode combining marks.
+var combining = map[byte]string{
+	'`':  "̀",
+	'\'': "́",
+	'^':  "̂",
+	'~':  "̃",
+	'=':  "̄",
+	'u':  "̆",
+	'.':  "̇",
+	'"':  "̈",
+	'r':  "̊",
+	'H':  "̋",
+	'v':  "̌",
+	'c':  "̧",
+	'k':  "̨",
+}
+
+// symbols maps argument-less commands to their text.
+var symbols = map[string]string{
+	"aa": "å", "AA": "Å", "ae": "æ", "AE": "Æ", "o": "ø", "O": "Ø",
+	"oe": "œ", "OE": "Œ", "ss": "ß", "l": "ł", "L": "Ł", "i": "ı", "j": "ȷ",
+	"&": "&", "%": "%", "$": "$", "#": "#", "_": "_", "{": "{", "}": "}",
+	"textendash": "–", "textemdash": "—", "ldots": "…", "dots": "…",
+	"textquoteright": "’", "textquoteleft": "‘", "LaTeX": "LaTeX", "TeX": "TeX",
+	",": " ", ";": " ", " ": " ", "\\": " ", "newline": " ", "par": " ",
+}
+
+var (
+	commandRe = regexp.MustCompile(`^\\([A-Za-z]+|.)`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+)
+
+// ToText returns s with accents resolved, formatting commands replaced by
+// their argument, and braces, ties and math shifts removed.
+func ToText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case '\\':
+			m := commandRe.FindStringSubmatch(s[i:])
+			if m == nil {
+				i++
+				continue
+			}
+			name := m[1]
+			i += len(m[0])
+			if len(name) == 1 && combining[name[0]] != "" && (name[0] < 'a' || name[0] > 'z' || peekArg(s, i)) {
+				arg, n := argument(s, i)
+				i += n
+				base := strings.NewReplacer("ı", "i", "ȷ", "j").Replace(ToText(arg))
+				b.WriteString(norm.NFC.String(base + combining[name[0]]))
+				continue
+			}
+			if text, ok := symbols[name]; ok {
+				b.WriteString(text)
+				if isLetters(name) {
+					// "\o{}" and "\ss " consume their terminator
+					i += skipTerminator(s, i)
+				}
+				continue
+			}
+			if isLetters(name) {
+				// formatting commands like \emph{...} keep their argument;
+				// a following space belongs to the command
+				for i < len(s) && s[i] == ' ' {
+					i++
+				}
+			}
+		case '{', '}', '$':
+			i++
+		case '~':
+			b.WriteByte(' ')
+			i++
+		case '-':
+			switch {
+			case strings.HasPrefix(s[i:], "---"):
+				b.WriteString("—")
+				i += 3
+			case strings.HasPrefix(s[i:], "--"):
+				b.WriteString("–")
+				i += 2
+			default:
+				b.WriteByte(c)
+				i++
+			}
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return strings.TrimSpace(spaceRe.ReplaceAllString(b.String(), " "))
+}
+
+// peekArg reports whether a letter accent command such as \c or \v is
+// followed by an argument rather than being part of a word.
+func peekArg(s string, i int) bool {
+	return i < len(s) && (s[i] == '{' || s[i] == ' ')
+}
+
+// argument returns the argument of an accent command starting at s[i], either
+// a braced group or a single character, and the number of bytes it spans.
+func argument(s string, i int) (string, int) {
+	start := i
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	if i >= len(s) {
+		return "", i - start
+	}
+	if s[i] != '{' {
+		if s[i] == '\\' {
+			if m := commandRe.FindString(s[i:]); m != "" {
+				return m, i - start + len(m)
+			}
+		}
+		return s[i : i+1], i - start + 1
+	}
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return s[i+1 : j], j - start + 1
+			}
+		}
+	}
+	return s[i+1:], len(s) - start
+}
+
+func skipTerminator(s string, i int) int {
+	if strings.HasPrefix(s[i:], "{}") {
+		return 2
+	}
+	if i < len(s) && s[i] == ' ' {
+		return 1
+	}
+	return 0
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
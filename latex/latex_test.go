@@ -0,0 +1,24 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package latex
+
+import "testing"
+
+func TestToText(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want string
+	}{
+		{`{Kokkos}: Enabling {GPU} performance`, "Kokkos: Enabling GPU performance"},
+		{`M{\"u}ller and M\"uller and M\"{u}ller`, "Müller and Müller and Müller"},
+		{`Adr{\'\i}an, \c{C}elik, Dvo\v{r}\'ak`, "Adrían, Çelik, Dvořák"},
+		{`\emph{Journal} of \textbf{Things}`, "Journal of Things"},
+		{`Smith~J. and Stra\ss e and \o{}rsted`, "Smith J. and Straße and ørsted"},
+		{`pp. 1--10, R\&D`, "pp. 1–10, R&D"},
+		{`$O(n)$ sorting`, "O(n) sorting"},
+	} {
+		if got := ToText(tc.in); got != tc.want {
+			t.Errorf("ToText(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -3,18 +3,23 @@
 package lookup
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/sandialabs/bibcheck/arxiv"
+	"github.com/sandialabs/bibcheck/entries"
 )
 
 // returns nil if not found
-func GetArxivMetadata(id, rawEntry string) (*arxiv.Entry, error) {
-
-	arxivClient := arxiv.NewClient()
+func GetArxivMetadata(ctx context.Context, id, rawEntry string) (*arxiv.Entry, error) {
+	return getArxivMetadata(ctx, arxiv.NewClient(), id)
+}
 
-	rec, err := arxivClient.GetByID(id)
+func getArxivMetadata(ctx context.Context, arxivClient *arxiv.Client, id string) (*arxiv.Entry, error) {
+	rec, err := arxivClient.GetByID(ctx, id)
 
 	if errors.Is(err, arxiv.ErrDoesNotExist) {
 		return nil, nil
@@ -24,3 +29,64 @@ func GetArxivMetadata(id, rawEntry string) (*arxiv.Entry, error) {
 
 	return rec, nil
 }
+
+type arxivSource struct{}
+
+func (arxivSource) Key() string { return "arxiv" }
+
+func (arxivSource) Identify(text string) string { return entries.ExtractArxiv(text) }
+
+func (s arxivSource) Lookup(q *Query, r *Result) {
+	id := s.Identify(q.Text)
+	if id == "" {
+		return
+	}
+	log.Printf("Detected arXiv %s", id)
+	r.Arxiv.ID = id
+	if entry, err := getArxivMetadata(q.Context(), arxiv.NewClient(arxiv.WithTransport(q.transport()), arxiv.WithCache(q.cache())), id); err != nil {
+		r.Arxiv.Error = fmt.Errorf("arxiv check error: %w", err)
+	} else {
+		r.Arxiv.Entry = entry
+		r.Arxiv.Status = SearchStatusDone
+	}
+}
+
+func (arxivSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "arXiv", State: SourceSkipped}
+	switch {
+	case r.Arxiv.Entry != nil:
+		status.State = SourceFound
+		status.Detail = r.Arxiv.Entry.ToString()
+		status.Record = recordFromArxiv(r.Arxiv.ID, r.Arxiv.Entry)
+		status.Evidence = status.Detail
+	case r.Arxiv.Error != nil:
+		status.State = SourceError
+		status.Detail = r.Arxiv.Error.Error()
+		status.Err = r.Arxiv.Error
+	case r.Arxiv.ID != "":
+		status.State = SourceNotFound
+		status.Detail = r.Arxiv.ID
+	}
+	return status
+}
+
+func recordFromArxiv(id string, entry *arxiv.Entry) *Record {
+	authors := make([]string, 0, len(entry.Authors))
+	for _, author := range entry.Authors {
+		authors = append(authors, author.Name)
+	}
+	if entry.ID != "" {
+		id = entry.ID
+	}
+	return &Record{
+		Source:  "arXiv",
+		ID:      id,
+		Title:   strings.Join(strings.Fields(entry.Title), " "),
+		Authors: authors,
+		Venue:   entry.JournalRef,
+		Year:    parseYear(entry.Published),
+		DOI:     entry.DOI,
+		URL:     id,
+		Text:    entry.ToString(),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/sandialabs/bibcheck/crossref"
 )
@@ -14,14 +15,14 @@ const (
 	CrossrefMatchThreshold float64 = 99 // determined empirically
 )
 
-func crossrefQueryBibliographic(client *crossref.Client, entry string) (*crossref.CrossrefWork, string, error) {
+func crossrefQueryBibliographic(ctx context.Context, client *crossref.Client, entry string) (*crossref.CrossrefWork, string, error) {
 	if client == nil {
 		return nil, "", fmt.Errorf("crossref client is required")
 	}
 
 	// search for 2 results
 	log.Print("query crossref.org...")
-	crossrefResp, err := client.QueryBibliographic(context.Background(), entry, 2)
+	crossrefResp, err := client.QueryBibliographic(ctx, entry, 2)
 	if err != nil {
 		return nil, "", fmt.Errorf("crossref API error: %w", err)
 	}
@@ -51,3 +52,72 @@ func crossrefQueryBibliographic(client *crossref.Client, entry string) (*crossre
 	log.Println("crossref.org best score:", best.Score)
 	return best, "", nil
 }
+
+type crossrefSource struct{}
+
+func (crossrefSource) Key() string { return "crossref" }
+
+func (crossrefSource) Identify(string) string { return "" }
+
+func (crossrefSource) Lookup(q *Query, r *Result) {
+	var client *crossref.Client
+	if q.Config != nil {
+		client = q.Config.CrossrefClient
+	}
+	if work, comment, err := crossrefQueryBibliographic(q.Context(), client, q.Text); err != nil {
+		r.Crossref.Error = err
+	} else {
+		if work == nil {
+			log.Printf("crossref.org query returned no record: %s", comment)
+		}
+		r.Crossref.Work = work
+		r.Crossref.Status = SearchStatusDone
+		r.Crossref.Comment = comment
+	}
+}
+
+func (crossrefSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "Crossref", State: SourceSkipped}
+	switch {
+	case r.Crossref.Work != nil:
+		status.State = SourceMatched
+		status.Detail = r.Crossref.Work.ToString()
+		status.Record = recordFromCrossref(r.Crossref.Work)
+		status.Evidence = status.Detail
+	case r.Crossref.Error != nil:
+		status.State = SourceError
+		status.Detail = r.Crossref.Error.Error()
+		status.Err = r.Crossref.Error
+	case r.Crossref.Comment != "":
+		status.State = SourceNoMatch
+		status.Detail = r.Crossref.Comment
+	case r.Crossref.Status == SearchStatusDone:
+		status.State = SourceNoMatch
+	}
+	return status
+}
+
+func recordFromCrossref(work *crossref.CrossrefWork) *Record {
+	rec := &Record{
+		Source: "Crossref",
+		ID:     work.DOI,
+		DOI:    work.DOI,
+		Text:   work.ToString(),
+	}
+	if work.DOI != "" {
+		rec.URL = "https://doi.org/" + work.DOI
+	}
+	if len(work.Title) > 0 {
+		rec.Title = work.Title[0]
+	}
+	for _, author := range work.Author {
+		rec.Authors = append(rec.Authors, strings.TrimSpace(author.Given+" "+author.Family))
+	}
+	if len(work.ContainerTitle) > 0 {
+		rec.Venue = work.ContainerTitle[0]
+	}
+	if len(work.Published.DateParts) > 0 && len(work.Published.DateParts[0]) > 0 {
+		rec.Year = work.Published.DateParts[0][0]
+	}
+	return rec
+}
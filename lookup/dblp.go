@@ -0,0 +1,150 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/dblp"
+)
+
+const dblpSearchHits = 5
+
+type DBLPResult struct {
+	Status  string
+	Info    *dblp.Info
+	Comment string
+	Error   error
+}
+
+type dblpSource struct{}
+
+func (dblpSource) Key() string { return "dblp" }
+
+func (dblpSource) Identify(string) string { return "" }
+
+func (dblpSource) Lookup(q *Query, r *Result) {
+	if q.Parser == nil {
+		return
+	}
+	fields, err := q.Fields()
+	if err != nil {
+		r.DBLP.Error = err
+		return
+	}
+	if fields.Title == "" {
+		r.DBLP.Status = SearchStatusDone
+		r.DBLP.Comment = "no title to search for"
+		return
+	}
+
+	client := dblp.NewClient(dblp.WithTransport(q.transport()), dblp.WithCache(q.cache()))
+	if q.Config != nil && q.Config.DBLPClient != nil {
+		client = q.Config.DBLPClient
+	}
+
+	// DBLP requires every query word to match, so punctuation is dropped
+	log.Print("query dblp.org...")
+	resp, err := client.SearchPublications(q.Context(), normalizeForContainment(fields.Title), dblpSearchHits)
+	if err != nil {
+		r.DBLP.Error = fmt.Errorf("dblp search error: %w", err)
+		return
+	}
+
+	info, comment := dblpConclusiveMatch(q.Text, resp.Result.Hits.Hit)
+	r.DBLP.Info = info
+	r.DBLP.Comment = comment
+	r.DBLP.Status = SearchStatusDone
+}
+
+// dblpConclusiveMatch picks the single hit whose title and first author
+// appear in the entry text. When several hits qualify (typically a preprint
+// and its published version), the venue named in the entry breaks the tie.
+func dblpConclusiveMatch(text string, hits []dblp.Hit) (*dblp.Info, string) {
+	if len(hits) == 0 {
+		return nil, "no matches found"
+	}
+
+	normalizedText := " " + normalizeForContainment(text) + " "
+	candidates := []*dblp.Info{}
+	for i := range hits {
+		info := &hits[i].Info
+		title := normalizeForContainment(info.CleanTitle())
+		if title == "" || !strings.Contains(normalizedText, " "+title+" ") {
+			continue
+		}
+		if authors := info.AuthorNames(); len(authors) > 0 {
+			if surname := lastNameOf(authors[0]); !strings.Contains(normalizedText, " "+surname+" ") {
+				continue
+			}
+		}
+		candidates = append(candidates, info)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, "no result title and first author appear in entry"
+	case 1:
+		return candidates[0], ""
+	}
+
+	byVenue := []*dblp.Info{}
+	for _, info := range candidates {
+		for _, venue := range info.Venue {
+			if v := normalizeForContainment(venue); v != "" && strings.Contains(normalizedText, " "+v+" ") {
+				byVenue = append(byVenue, info)
+				break
+			}
+		}
+	}
+	if len(byVenue) == 1 {
+		return byVenue[0], ""
+	}
+	return nil, "no single conclusive match"
+}
+
+// lastNameOf returns the normalized final word of a "Given Family" name.
+func lastNameOf(name string) string {
+	words := strings.Fields(normalizeForContainment(name))
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+func (dblpSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "DBLP", State: SourceSkipped}
+	switch {
+	case r.DBLP.Info != nil:
+		status.State = SourceMatched
+		status.Detail = r.DBLP.Info.ToString()
+		status.Record = recordFromDBLP(r.DBLP.Info)
+		status.Evidence = status.Detail
+	case r.DBLP.Error != nil:
+		status.State = SourceError
+		status.Detail = r.DBLP.Error.Error()
+		status.Err = r.DBLP.Error
+	case r.DBLP.Comment != "":
+		status.State = SourceNoMatch
+		status.Detail = r.DBLP.Comment
+	case r.DBLP.Status == SearchStatusDone:
+		status.State = SourceNoMatch
+	}
+	return status
+}
+
+func recordFromDBLP(info *dblp.Info) *Record {
+	return &Record{
+		Source:  "DBLP",
+		ID:      info.Key,
+		Title:   info.CleanTitle(),
+		Authors: info.AuthorNames(),
+		Venue:   strings.Join(info.Venue, ", "),
+		Year:    info.YearInt(),
+		DOI:     info.DOI,
+		URL:     info.URL,
+		Text:    info.ToString(),
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"testing"
+
+	"github.com/sandialabs/bibcheck/dblp"
+)
+
+func dblpHit(key, title, venue string, authors ...string) dblp.Hit {
+	hit := dblp.Hit{}
+	hit.Info.Key = key
+	hit.Info.Title = title + "."
+	hit.Info.Venue = []string{venue}
+	for _, author := range authors {
+		hit.Info.Authors.Author = append(hit.Info.Authors.Author, dblp.Author{Text: author})
+	}
+	return hit
+}
+
+func TestDBLPConclusiveMatch(t *testing.T) {
+	text := `Carl Pearson, Aurya Javeed, and Karen Devine. 2022. Machine Learning for CUDA+MPI Design Rules. In IPDPS Workshops. 880–889.`
+	published := dblpHit("conf/ipps/PearsonJD22", "Machine Learning for CUDA+MPI Design Rules", "IPDPS Workshops", "Carl Pearson", "Aurya Javeed")
+	preprint := dblpHit("journals/corr/abs-2203-02530", "Machine Learning for CUDA+MPI Design Rules", "CoRR", "Carl Pearson", "Aurya Javeed")
+	wrongAuthor := dblpHit("conf/x/Other22", "Machine Learning for CUDA+MPI Design Rules", "IPDPS Workshops", "Someone Else")
+
+	if info, comment := dblpConclusiveMatch(text, []dblp.Hit{preprint, published}); info == nil || info.Key != published.Info.Key {
+		t.Fatalf("expected venue to pick published version, got %+v (%s)", info, comment)
+	}
+	if info, _ := dblpConclusiveMatch(text, []dblp.Hit{wrongAuthor}); info != nil {
+		t.Fatal("accepted a hit whose first author is not in the entry")
+	}
+	if _, comment := dblpConclusiveMatch(text, []dblp.Hit{published, published}); comment != "no single conclusive match" {
+		t.Fatalf("comment = %q, want tie", comment)
+	}
+	if _, comment := dblpConclusiveMatch(text, nil); comment != "no matches found" {
+		t.Fatalf("comment = %q", comment)
+	}
+}
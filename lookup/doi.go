@@ -3,16 +3,24 @@
 package lookup
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 
 	"github.com/sandialabs/bibcheck/doi"
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/match"
 )
 
 // returns whether the id was found on DOI.org
-func CheckDOI(id string) (bool, error) {
+func CheckDOI(ctx context.Context, id string) (bool, error) {
+	return checkDOI(ctx, doi.NewClient(), id)
+}
+
+func checkDOI(ctx context.Context, client *doi.Client, id string) (bool, error) {
 	log.Println("checking doi", id, "...")
-	_, err := doi.ResolveDOI(id)
+	_, err := client.ResolveDOI(ctx, id)
 
 	if err != nil {
 		if errors.Is(err, doi.DoesNotExistError) {
@@ -22,3 +30,112 @@ func CheckDOI(id string) (bool, error) {
 	}
 	return true, nil
 }
+
+type doiSource struct{}
+
+func (doiSource) Key() string { return "doi" }
+
+func (doiSource) Identify(text string) string { return entries.ExtractDOI(text) }
+
+func (s doiSource) Lookup(q *Query, r *Result) {
+	id := s.Identify(q.Text)
+	if id == "" {
+		return
+	}
+	log.Println("Detected DOI", id)
+	r.DOIOrg.ID = id
+	client := doi.NewClient(doi.WithTransport(q.transport()), doi.WithCache(q.cache()))
+	found, err := checkDOI(q.Context(), client, id)
+	if err != nil {
+		r.DOIOrg.Error = fmt.Errorf("CheckDOI error: %w", err)
+		return
+	}
+	log.Println("DOI found:", found)
+	r.DOIOrg.Found = found
+	r.DOIOrg.Status = SearchStatusDone
+	if !found {
+		return
+	}
+
+	// A real DOI proves nothing if it belongs to some other work
+	work, err := client.GetCSL(q.Context(), id)
+	if err != nil {
+		log.Printf("DOI metadata unavailable: %v", err)
+		return
+	}
+	r.DOIOrg.Work = work
+	r.DOIOrg.Match = match.Compare(match.Citation{Text: q.Text}, match.Work{
+		Title:   string(work.Title),
+		Authors: work.Authors(),
+		Year:    work.Year(),
+		Venue:   string(work.ContainerTitle),
+	})
+	r.DOIOrg.Match.Source = "DOI"
+	r.DOIOrg.WrongWork = isWrongWork(r.DOIOrg.Match)
+}
+
+// isWrongWork reports whether a comparison shows a different work, rather
+// than metadata too sparse to compare.
+func isWrongWork(m *match.Result) bool {
+	if m.Verdict != match.VerdictMismatch {
+		return false
+	}
+	for _, f := range m.Fields {
+		if f.Name == match.FieldTitle {
+			return f.Verdict != match.VerdictMissing
+		}
+	}
+	return false
+}
+
+// WrongDOI reports whether the entry's DOI exists but points to a different
+// work than the entry describes.
+func (r *Result) WrongDOI() bool {
+	return r.DOIOrg.WrongWork
+}
+
+func (doiSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "DOI", State: SourceSkipped}
+	switch {
+	case r.DOIOrg.WrongWork:
+		status.State = SourceWrongWork
+		status.Detail = "DOI exists but points to a different work: " + r.DOIOrg.Work.ToString()
+		if r.DOIOrg.Match != nil {
+			status.Detail += " (" + r.DOIOrg.Match.Explain() + ")"
+		}
+	case r.DOIOrg.Work != nil:
+		status.State = SourceMatched
+		status.Detail = r.DOIOrg.Work.ToString()
+		status.Record = recordFromCSL(r.DOIOrg.ID, r.DOIOrg.Work)
+		status.Evidence = status.Detail
+	case r.DOIOrg.Found:
+		status.State = SourceFound
+		status.Detail = "exists"
+		status.Evidence = "<DOI from bibliography entry exists, no metadata provided.>"
+	case r.DOIOrg.Error != nil:
+		status.State = SourceError
+		status.Detail = r.DOIOrg.Error.Error()
+		status.Err = r.DOIOrg.Error
+	case r.DOIOrg.ID != "":
+		status.State = SourceNotFound
+		status.Detail = r.DOIOrg.ID
+	}
+	return status
+}
+
+func recordFromCSL(id string, work *doi.CSL) *Record {
+	if work.DOI != "" {
+		id = work.DOI
+	}
+	return &Record{
+		Source:  "DOI",
+		ID:      id,
+		Title:   string(work.Title),
+		Authors: work.Authors(),
+		Venue:   string(work.ContainerTitle),
+		Year:    work.Year(),
+		DOI:     id,
+		URL:     "https://doi.org/" + id,
+		Text:    work.ToString(),
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/elsevier"
+)
+
+type elsevierSource struct{}
+
+func (elsevierSource) Key() string { return "elsevier" }
+
+func (elsevierSource) Identify(string) string { return "" }
+
+func (elsevierSource) Lookup(q *Query, r *Result) {
+	if q.Config == nil || q.Config.ElsevierClient == nil {
+		return
+	}
+
+	fields, err := q.Fields()
+	if err != nil {
+		log.Printf("%v", err)
+		r.Elsevier.Error = err
+		return
+	}
+	if len(fields.Authors.Authors) == 0 || fields.Title == "" || fields.Pub == "" {
+		log.Println("unable to parse sufficient metadata for Elsevier search")
+		r.Elsevier.Error = fmt.Errorf("unable to parse sufficient metadata for elsevier search")
+		return
+	}
+
+	resp, err := q.Config.ElsevierClient.Search(q.Context(), &elsevier.SearchQuery{
+		Title:   fields.Title,
+		Authors: strings.Join(fields.Authors.Authors, " AND "),
+		Pub:     fields.Pub,
+	})
+	if err != nil {
+		log.Printf("elsevier.Search error: %v", err)
+		r.Elsevier.Error = fmt.Errorf("elsevier.Search error: %w", err)
+	} else if len(resp.Results) < 1 {
+		r.Elsevier.Status = SearchStatusDone
+		log.Printf("elsevier.Search returned no results")
+	} else {
+		r.Elsevier.Status = SearchStatusDone
+		r.Elsevier.Result = resp.Results[0]
+	}
+}
+
+func (elsevierSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "Elsevier", State: SourceSkipped}
+	switch {
+	case r.Elsevier.Result != nil:
+		status.State = SourceMatched
+		status.Detail = r.Elsevier.Result.ToString()
+		status.Record = recordFromElsevier(r.Elsevier.Result)
+		status.Evidence = status.Detail
+	case r.Elsevier.Error != nil:
+		status.State = SourceError
+		status.Detail = r.Elsevier.Error.Error()
+		status.Err = r.Elsevier.Error
+	case r.Elsevier.Status == SearchStatusDone:
+		status.State = SourceNoMatch
+	}
+	return status
+}
+
+func recordFromElsevier(result *elsevier.SearchResult) *Record {
+	authors := make([]string, 0, len(result.Authors))
+	for _, author := range result.Authors {
+		authors = append(authors, author.Name)
+	}
+	return &Record{
+		Source:  "Elsevier",
+		ID:      result.PII,
+		Title:   result.Title,
+		Authors: authors,
+		Venue:   result.SourceTitle,
+		Year:    parseYear(result.PublicationDate),
+		DOI:     result.DOI,
+		URL:     result.URI,
+		Text:    result.ToString(),
+	}
+}
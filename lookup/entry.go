@@ -3,22 +3,26 @@
 package lookup
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"net/url"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/sandialabs/bibcheck/arxiv"
 	"github.com/sandialabs/bibcheck/crossref"
+	"github.com/sandialabs/bibcheck/dblp"
 	"github.com/sandialabs/bibcheck/documents"
+	"github.com/sandialabs/bibcheck/doi"
 	"github.com/sandialabs/bibcheck/elsevier"
 	"github.com/sandialabs/bibcheck/entries"
 	"github.com/sandialabs/bibcheck/internal/wasmhttp"
+	"github.com/sandialabs/bibcheck/match"
+	"github.com/sandialabs/bibcheck/openalex"
 	"github.com/sandialabs/bibcheck/osti"
+	"github.com/sandialabs/bibcheck/retraction"
 )
 
 const (
@@ -39,7 +43,14 @@ type DOIOrgResult struct {
 	Status string
 	ID     string
 	Found  bool
-	Error  error
+	// Work is the metadata registered for the DOI, if doi.org provided it.
+	Work *doi.CSL
+	// Match compares the entry against Work.
+	Match *match.Result
+	// WrongWork is set when the DOI exists but its registered metadata
+	// describes a different work than the entry.
+	WrongWork bool
+	Error     error
 }
 
 type OSTIResult struct {
@@ -71,6 +82,7 @@ type CrossrefResult struct {
 
 type OnlineResult struct {
 	Status   string
+	URL      string
 	Metadata *documents.Metadata
 	Error    error
 }
@@ -87,27 +99,63 @@ type Result struct {
 
 	Arxiv    ArxivResult
 	Crossref CrossrefResult
+	DBLP     DBLPResult
 	DOIOrg   DOIOrgResult
 	Elsevier ElsevierResult
 	OSTI     OSTIResult
+	OpenAlex OpenAlexResult
 	Online   OnlineResult
 	Web      Search
 
+	Published  PublishedResult
+	Retraction RetractionResult
+
 	Summary SummarizeResult
+
+	// Extra holds the results of registered sources that have no field of
+	// their own, keyed by Source.Key. See SourceResult and SetSourceResult.
+	Extra map[string]any
+}
+
+// SourceResult returns the result the source named key stored with
+// SetSourceResult, or nil.
+func (r *Result) SourceResult(key string) any {
+	return r.Extra[key]
+}
+
+// SetSourceResult stores the result of the source named key.
+func (r *Result) SetSourceResult(key string, v any) {
+	if r.Extra == nil {
+		r.Extra = map[string]any{}
+	}
+	r.Extra[key] = v
 }
 
 type EntryConfig struct {
 	ElsevierClient *elsevier.Client
 	CrossrefClient *crossref.Client
+	// DBLPClient is used for DBLP lookups; nil uses a default client.
+	DBLPClient *dblp.Client
+	// OpenAlexClient is used for OpenAlex lookups; nil uses a default client.
+	OpenAlexClient *openalex.Client
+	// RetractionWatch supplements Crossref's retraction notices; nil uses
+	// Crossref alone.
+	RetractionWatch *retraction.Watch
+	// Strategy overrides DefaultStrategy when non-empty.
+	Strategy []Step
+	// Transport carries the requests of the clients that sources build
+	// themselves; nil uses http.DefaultTransport.
+	Transport http.RoundTripper
+	// NoHTTPCache keeps the clients that sources build themselves from
+	// answering requests from the on-disk HTTP cache or storing responses
+	// in it.
+	NoHTTPCache bool
 }
 
-func retrieveUrl(url string) ([]byte, string, error) {
-	client := &http.Client{
-		Timeout: retrieveTimeout,
-	}
+func retrieveUrl(ctx context.Context, client *http.Client, url string) ([]byte, string, error) {
 	fetchURL := wasmhttp.FetchURL(url)
 	log.Println("GET", url)
-	req, err := http.NewRequest(http.MethodGet, fetchURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, nil)
 	if err != nil {
 		return nil, "", fmt.Errorf("http.NewRequest error: %w", err)
 	}
@@ -157,6 +205,12 @@ func fetchResponseError(resp *http.Response, usingProxy bool) error {
 			} else if truncated {
 				message += "..."
 			}
+			if resp.StatusCode == http.StatusTooManyRequests {
+				if retry := resp.Header.Get("Retry-After"); retry != "" {
+					return fmt.Errorf("/api/fetch rate limit reached, retry after %ss: %s", retry, message)
+				}
+				return fmt.Errorf("/api/fetch rate limit reached: %s", message)
+			}
 			return fmt.Errorf("/api/fetch error: %s", message)
 		case wasmhttp.FetchResultUpstream:
 			// The status and body came from the requested URL.
@@ -176,7 +230,11 @@ func fetchResponseError(resp *http.Response, usingProxy bool) error {
 }
 
 // analyze bib entry `text`
-func Entry(text string, mode string,
+//
+// Sources are tried in the order given by cfg.Strategy, or DefaultStrategy if
+// none is configured. Once ctx is done no further sources are tried and its
+// error is returned.
+func Entry(ctx context.Context, text string, mode string,
 	class entries.Classifier,
 	extract documents.MetaExtractor,
 	entryParser entries.Parser,
@@ -186,177 +244,38 @@ func Entry(text string, mode string,
 	EA := &Result{
 		Text: text,
 	}
-
-	// check DOI if present
-	// The existence or not of the DOI is not very useful alone, so continue on
-	if doi := entries.ExtractDOI(text); doi != "" {
-		log.Println("Detected DOI", doi)
-		EA.DOIOrg.ID = doi
-		if found, err := CheckDOI(doi); err != nil {
-			EA.DOIOrg.Error = fmt.Errorf("CheckDOI error: %w", err)
-		} else {
-			log.Println("DOI found:", found)
-			EA.DOIOrg.Found = found
-			EA.DOIOrg.Status = SearchStatusDone
-		}
+	q := &Query{
+		Text:   text,
+		Parser: entryParser,
+		Meta:   extract,
+		Config: cfg,
+		ctx:    ctx,
 	}
 
-	// Check OSTI if present
-	// Finding the ID should provide enough info to evaluate the entry
-	if osti := entries.ExtractOSTI(text); osti != "" {
-		log.Printf("Detected OSTI %s", osti)
-		EA.OSTI.ID = osti
-		if rec, err := GetOSTIRecord(osti, text); err != nil {
-			EA.OSTI.Error = fmt.Errorf("GetOSTIRecord error: %w", err)
-		} else {
-			EA.OSTI.Record = rec
-			EA.OSTI.Status = SearchStatusDone
-			return EA, nil
-		}
+	strategy := DefaultStrategy()
+	if cfg != nil && len(cfg.Strategy) > 0 {
+		strategy = cfg.Strategy
 	}
 
-	// Check arXiv if present
-	// Finding the ID should provide enough info to evaluate the entry
-	if id := entries.ExtractArxiv(text); id != "" {
-		log.Printf("Detected arXiv %s", id)
-		if entry, err := GetArxivMetadata(id, text); err != nil {
-			EA.Arxiv.Error = fmt.Errorf("arxiv check error: %w", err)
-		} else {
-			EA.Arxiv.Entry = entry
-			EA.Arxiv.ID = id
-			EA.Arxiv.Status = SearchStatusDone
-			return EA, nil
+	matched := false
+	sufficient := false
+	for _, step := range strategy {
+		if step.Disabled || (step.Fallback && matched) || (sufficient && !step.Always) {
+			continue
 		}
-	}
-
-	// if we got here, this wasn't OSTI or arxiv
-	// pursue some general lookup strategies:
-	// * elsevier
-	// * crossref
-
-	// Elsevier search
-	if cfg != nil && cfg.ElsevierClient != nil {
-
-		log.Println("Extracting metadata for Elsevier search...")
-		var wg sync.WaitGroup
-		var authorsErr, titleErr, pubErr error
-		var authors *entries.Authors
-		var title, pub string
-		wg.Add(3)
-		go func() {
-			defer wg.Done()
-			authors, authorsErr = entryParser.ParseAuthors(text)
-			log.Printf("authors: %v", authors.Authors)
-		}()
-		go func() {
-			defer wg.Done()
-			title, titleErr = entryParser.ParseTitle(text)
-			log.Printf("title: %v", title)
-		}()
-		go func() {
-			defer wg.Done()
-			pub, pubErr = entryParser.ParsePub(text)
-			log.Printf("pub: %v", pub)
-		}()
-		wg.Wait()
-
-		if authorsErr != nil {
-			log.Printf("ParseAuthors error: %v\n", authorsErr)
-			EA.Elsevier.Error = fmt.Errorf("ParseAuthors error: %w", authorsErr)
-		} else if titleErr != nil {
-			log.Printf("ParseTitle error: %v\n", titleErr)
-			EA.Elsevier.Error = fmt.Errorf("ParseTitle error: %w", titleErr)
-		} else if pubErr != nil {
-			log.Printf("ParsePub error: %v\n", pubErr)
-			EA.Elsevier.Error = fmt.Errorf("ParsePub error: %w", pubErr)
-		} else if len(authors.Authors) > 0 && title != "" && pub != "" {
-			resp, err := cfg.ElsevierClient.Search(&elsevier.SearchQuery{
-				Title:   title,
-				Authors: strings.Join(authors.Authors, " AND "),
-				Pub:     pub,
-			})
-
-			if err != nil {
-				log.Printf("elsevier.Search error: %v", err)
-				EA.Elsevier.Error = fmt.Errorf("elsevier.Search error: %w", err)
-			} else if len(resp.Results) < 1 {
-				EA.Elsevier.Status = SearchStatusDone
-				log.Printf("elsevier.Search returned no results")
-			} else {
-				EA.Elsevier.Status = SearchStatusDone
-				best := resp.Results[0]
-				EA.Elsevier.Result = best
-			}
-		} else {
-			log.Println("unable to parse sufficient metadata for Elsevier search")
-			EA.Elsevier.Error = fmt.Errorf("unable to parse sufficient metadata for elsevier search")
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
-	}
-
-	// crossref search
-	var crossrefClient *crossref.Client
-	if cfg != nil {
-		crossrefClient = cfg.CrossrefClient
-	}
-	if work, comment, err := crossrefQueryBibliographic(crossrefClient, text); err != nil {
-		EA.Crossref.Error = err
-	} else {
-		if work == nil {
-			log.Printf("crossref.org query returned no record: %s", comment)
+		source, ok := SourceByKey(step.Source)
+		if !ok {
+			return nil, fmt.Errorf("unknown lookup source %q", step.Source)
 		}
-		EA.Crossref.Work = work
-		EA.Crossref.Status = SearchStatusDone
-		EA.Crossref.Comment = comment
-	}
-
-	// if we have an elsevier or crossref result we're satisfied
-	if EA.Crossref.Work != nil || EA.Elsevier.Result != nil {
-		return EA, nil
-	}
-
-	// otherwise, let's try to treat this as a generic online resource
-	if online, err := entryParser.ParseOnline(text); err != nil {
-		EA.Online.Error = fmt.Errorf("ParseOnline error: %v", err)
-	} else if parsedURL, err := url.Parse(online.URL); err != nil {
-		EA.Online.Error = fmt.Errorf("ParseOnline provided a URL that did not parse: %v", err)
-	} else if online.URL != "" {
-		log.Printf("Online:\n  URL:     %s\n  Title:   %s\n  Authors: %s",
-			online.URL,
-			online.Title,
-			strings.Join(online.Authors, ", "),
-		)
-
-		// TODO: we can somehow do format=markdown for github, which might produce better results
-
-		if body, contentType, err := retrieveUrl(online.URL); err != nil {
-			log.Printf("retrieve url error: %s", err)
-			EA.Online.Error = fmt.Errorf("retrieve url error: %w", err)
-		} else {
-			log.Println("retrieved URL content type:", contentType)
-			contentTypeLower := strings.ToLower(contentType)
-
-			if strings.HasSuffix(strings.ToLower(parsedURL.Path), ".pdf") &&
-				!strings.Contains(contentTypeLower, "application/pdf") {
-				EA.Online.Error = fmt.Errorf("URL ending in .pdf returned non-PDF content type: %s", contentType)
-			} else if strings.Contains(contentTypeLower, "application/pdf") {
-				if meta, err := extract.PDFMetadata(body); err != nil {
-					EA.Online.Error = fmt.Errorf("extract.PDFMetadata error: %w", err)
-				} else {
-					EA.Online.Metadata = meta
-					EA.Online.Status = SearchStatusDone
-				}
-			} else if strings.Contains(contentTypeLower, "text/html") {
-				if meta, err := extract.HTMLMetadata(body); err != nil {
-					EA.Online.Error = fmt.Errorf("extract.HTMLMetadata error: %w", err)
-				} else {
-					EA.Online.Metadata = meta
-					EA.Online.Status = SearchStatusDone
-				}
-
-			} else {
-				EA.Online.Error = fmt.Errorf("unexpected content type: %s", contentType)
+		source.Lookup(q, EA)
+		if source.Status(EA).Record != nil {
+			matched = true
+			if step.Sufficient {
+				sufficient = true
 			}
-
 		}
 	}
 
@@ -364,7 +283,7 @@ func Entry(text string, mode string,
 }
 
 // analyze entry `id` from base-64 encoded pdf file `encoded`
-func EntryFromBase64(encoded string, id int, mode string,
+func EntryFromBase64(ctx context.Context, encoded string, id string, mode string,
 	class entries.Classifier,
 	docExtract documents.EntryFromRawExtractor,
 	docMeta documents.MetaExtractor,
@@ -377,18 +296,18 @@ func EntryFromBase64(encoded string, id int, mode string,
 	}
 
 	// Extract citation text
-	text, err := docExtract.EntryFromRaw(encoded, id)
+	text, err := docExtract.EntryFromRaw(ctx, encoded, id)
 	if err != nil {
-		return nil, fmt.Errorf("error extracting citation %d: %w", id, err)
+		return nil, fmt.Errorf("error extracting citation %s: %w", id, err)
 	}
-	log.Printf("=== Entry %d ===", id)
+	log.Printf("=== Entry %s ===", id)
 	log.Print(text)
 
-	return Entry(text, mode, class, docMeta, entryParser, cfg)
+	return Entry(ctx, text, mode, class, docMeta, entryParser, cfg)
 }
 
 // analyze entry `id` from a prepared bibliography artifact.
-func EntryFromBibliography(b *documents.Bibliography, id int, mode string,
+func EntryFromBibliography(ctx context.Context, b *documents.Bibliography, id string, mode string,
 	class entries.Classifier,
 	docExtract documents.EntryFromBibliographyExtractor,
 	docMeta documents.MetaExtractor,
@@ -401,12 +320,12 @@ func EntryFromBibliography(b *documents.Bibliography, id int, mode string,
 	}
 
 	// Extract citation text
-	text, err := docExtract.EntryFromBibliography(b, id)
+	text, err := docExtract.EntryFromBibliography(ctx, b, id)
 	if err != nil {
-		return nil, fmt.Errorf("error extracting citation %d: %w", id, err)
+		return nil, fmt.Errorf("error extracting citation %s: %w", id, err)
 	}
-	log.Printf("=== Entry %d ===", id)
+	log.Printf("=== Entry %s ===", id)
 	log.Print(text)
 
-	return Entry(text, mode, class, docMeta, entryParser, cfg)
+	return Entry(ctx, text, mode, class, docMeta, entryParser, cfg)
 }
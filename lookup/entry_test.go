@@ -1,17 +1,19 @@
 package lookup_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/sandialabs/bibcheck/cassette"
 	"github.com/sandialabs/bibcheck/elsevier"
 	"github.com/sandialabs/bibcheck/lookup"
 	"github.com/sandialabs/bibcheck/shirty"
 )
 
 func shirtyWorkflowFromEnv() *shirty.Workflow {
-	if apiKey, ok := os.LookupEnv("SHIRTY_API_KEY"); ok {
+	if apiKey, ok := cassette.LookupEnv("SHIRTY_API_KEY"); ok {
 		return shirty.NewWorkflow(apiKey, "https://shirty.sandia.gov/api/v1")
 	}
 	return nil
@@ -32,7 +34,7 @@ through Hierarchical Communicators. Parallel Comput. 76 (2018),
 70–90. https://doi.org/10.1016/j.parco.2018.05.006`
 		expected := "10.1016/j.parco.2018.05.006"
 
-		if EA, err := lookup.Entry(text, "", w, w, w, &lookup.EntryConfig{
+		if EA, err := lookup.Entry(context.Background(), text, "", w, w, w, &lookup.EntryConfig{
 			ElsevierClient: elsevierClientFromEnv(),
 		}); err != nil {
 			t.Fatalf("Entry error: %v", err)
@@ -57,7 +59,7 @@ Kelley, Christian R Trott, Jeremiah Wilke, and Ichitaro Yamazaki. 2021. Kokkos k
 sparse/dense linear algebra and graph kernels. arXiv preprint arXiv:2103.11991 -, - (2021), 1–12`
 		expected := "https://arxiv.org/abs/2103.11991"
 
-		if EA, err := lookup.Entry(text, "", w, w, w, &lookup.EntryConfig{
+		if EA, err := lookup.Entry(context.Background(), text, "", w, w, w, &lookup.EntryConfig{
 			ElsevierClient: elsevierClientFromEnv(),
 		}); err != nil {
 			t.Fatalf("Entry error: %v", err)
@@ -81,7 +83,7 @@ Mercier. 2018. Hardware Topology Management in MPI Applications
 through Hierarchical Communicators. Parallel Comput. 76 (2018),
 70–90. https://doi.org/10.1016/j.parco.2018.05.006`
 
-		if EA, err := lookup.Entry(text, "", w, w, w, &lookup.EntryConfig{
+		if EA, err := lookup.Entry(context.Background(), text, "", w, w, w, &lookup.EntryConfig{
 			ElsevierClient: elsevierClientFromEnv(),
 		}); err != nil {
 			t.Fatalf("Entry error: %v", err)
@@ -112,7 +114,7 @@ Swarm and Evolutionary Computation,
 Volume 100,
 2026,`
 
-	EA, err := lookup.Entry(text, "", w, w, w, &lookup.EntryConfig{
+	EA, err := lookup.Entry(context.Background(), text, "", w, w, w, &lookup.EntryConfig{
 		ElsevierClient: elsevierClientFromEnv(),
 	})
 	if err != nil {
@@ -156,7 +158,7 @@ Swarm and Evolutionary Computation,
 Volume 100,
 2026,`
 
-	EA, err := lookup.Entry(text, "", w, w, w, nil)
+	EA, err := lookup.Entry(context.Background(), text, "", w, w, w, nil)
 	if err != nil {
 		t.Fatalf("Entry error: %v", err)
 	}
@@ -190,7 +192,7 @@ func Test_Online_1(t *testing.T) {
 		text := `2023. Frontier User Guide. https://docs.olcf.ornl.gov/systems/frontier_
 user_guide.html`
 
-		EA, err := lookup.Entry(text, "", w, w, w, nil)
+		EA, err := lookup.Entry(context.Background(), text, "", w, w, w, nil)
 		if err != nil {
 			t.Fatalf("Entry error: %v", err)
 		}
@@ -27,6 +27,23 @@ func TestFetchResponseErrorReportsProxyErrorBody(t *testing.T) {
 	}
 }
 
+func TestFetchResponseErrorReportsRateLimit(t *testing.T) {
+	resp := &http.Response{
+		Status:     "429 Too Many Requests",
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			wasmhttp.FetchResultHeader: []string{wasmhttp.FetchResultProxyError},
+			"Retry-After":              []string{"12"},
+		},
+		Body: io.NopCloser(strings.NewReader("too many requests from 192.0.2.7\n")),
+	}
+
+	err := fetchResponseError(resp, true)
+	if err == nil || err.Error() != "/api/fetch rate limit reached, retry after 12s: too many requests from 192.0.2.7" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestFetchResponseErrorPreservesUpstreamErrorProvenance(t *testing.T) {
 	resp := &http.Response{
 		Status:     "502 Bad Gateway",
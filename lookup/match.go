@@ -0,0 +1,25 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import "github.com/sandialabs/bibcheck/match"
+
+// Match compares the entry text against every matched record and returns the
+// best-scoring comparison, or nil if no source matched a record.
+func (r *Result) Match() *match.Result {
+	var best *match.Result
+	citation := match.Citation{Text: r.Text}
+	for _, record := range r.Records() {
+		m := match.Compare(citation, match.Work{
+			Title:   record.Title,
+			Authors: record.Authors,
+			Year:    record.Year,
+			Venue:   record.Venue,
+		})
+		m.Source = record.Source
+		if best == nil || m.Score > best.Score {
+			best = m
+		}
+	}
+	return best
+}
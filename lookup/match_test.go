@@ -0,0 +1,73 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sandialabs/bibcheck/doi"
+	"github.com/sandialabs/bibcheck/match"
+)
+
+func TestResultMatchUsesMatchedRecords(t *testing.T) {
+	ran := []string{}
+	registerFakes(t, fakeSource{key: "first", found: true, ran: &ran})
+
+	result, err := Entry(context.Background(), "A. Author. A useful paper. 2020.", "", nil, nil, nil, &EntryConfig{Strategy: []Step{{Source: "first"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := result.Match()
+	if m == nil {
+		t.Fatal("expected a match result")
+	}
+	if m.Source != "first" || m.Verdict != match.VerdictMatch {
+		t.Fatalf("unexpected match: %s", m.Explain())
+	}
+
+	if (&Result{Text: "nothing found"}).Match() != nil {
+		t.Fatal("expected no match result without records")
+	}
+}
+
+func TestDOIPointingToDifferentWork(t *testing.T) {
+	text := `Brice Goglin, Emmanuel Jeannot, Farouk Mansouri, and Guillaume Mercier. 2018. Hardware Topology Management in MPI Applications through Hierarchical Communicators. Parallel Comput. 76 (2018), 70–90. doi:10.1016/j.parco.2018.05.006`
+	for _, tc := range []struct {
+		name  string
+		title string
+		want  SourceState
+	}{
+		{"same work", "Hardware topology management in MPI applications through hierarchical communicators", SourceMatched},
+		{"different work", "Cardiac outcomes of influenza vaccination in elderly patients", SourceWrongWork},
+	} {
+		work := &doi.CSL{}
+		data := fmt.Sprintf(`{"DOI":"10.1016/j.parco.2018.05.006","title":%q,"author":[{"given":"Brice","family":"Goglin"}]}`, tc.title)
+		if err := json.Unmarshal([]byte(data), work); err != nil {
+			t.Fatal(err)
+		}
+		r := &Result{Text: text}
+		r.DOIOrg.ID = work.DOI
+		r.DOIOrg.Found = true
+		r.DOIOrg.Work = work
+		r.DOIOrg.Match = match.Compare(match.Citation{Text: text}, match.Work{Title: tc.title, Authors: work.Authors()})
+		r.DOIOrg.WrongWork = isWrongWork(r.DOIOrg.Match)
+
+		status := doiSource{}.Status(r)
+		if status.State != tc.want {
+			t.Fatalf("%s: state = %s, want %s (%s)", tc.name, status.State, tc.want, status.Detail)
+		}
+		if r.WrongDOI() != (tc.want == SourceWrongWork) {
+			t.Fatalf("%s: WrongDOI() = %v", tc.name, r.WrongDOI())
+		}
+		if tc.want == SourceWrongWork && (status.Record != nil || status.Evidence != "") {
+			t.Fatalf("%s: a different work was offered as evidence", tc.name)
+		}
+		if tc.want == SourceWrongWork && !strings.HasSuffix(status.Detail, work.ToString()+" ("+r.DOIOrg.Match.Explain()+")") {
+			t.Fatalf("%s: detail = %q", tc.name, status.Detail)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+)
+
+type onlineSource struct{}
+
+func (onlineSource) Key() string { return "online" }
+
+func (onlineSource) Identify(string) string { return "" }
+
+func (onlineSource) Lookup(q *Query, r *Result) {
+	// without a parser, as for LaTeX input with no LLM, the entry's URL cannot
+	// be found, which is no fault of the entry
+	if q.Parser == nil {
+		log.Print("online: no entry parser configured, skipping")
+		return
+	}
+
+	online, err := q.Parser.ParseOnline(q.Context(), q.Text)
+	if err != nil {
+		r.Online.Error = fmt.Errorf("ParseOnline error: %v", err)
+		return
+	}
+	parsedURL, err := url.Parse(online.URL)
+	if err != nil {
+		r.Online.Error = fmt.Errorf("ParseOnline provided a URL that did not parse: %v", err)
+		return
+	}
+	if online.URL == "" {
+		return
+	}
+	log.Printf("Online:\n  URL:     %s\n  Title:   %s\n  Authors: %s",
+		online.URL,
+		online.Title,
+		strings.Join(online.Authors, ", "),
+	)
+	r.Online.URL = online.URL
+
+	// TODO: we can somehow do format=markdown for github, which might produce better results
+
+	body, contentType, err := retrieveUrl(q.Context(), q.cache().Client(retrieveTimeout, q.transport()), online.URL)
+	if err != nil {
+		log.Printf("retrieve url error: %s", err)
+		r.Online.Error = fmt.Errorf("retrieve url error: %w", err)
+		return
+	}
+	log.Println("retrieved URL content type:", contentType)
+	contentTypeLower := strings.ToLower(contentType)
+
+	if strings.HasSuffix(strings.ToLower(parsedURL.Path), ".pdf") &&
+		!strings.Contains(contentTypeLower, "application/pdf") {
+		r.Online.Error = fmt.Errorf("URL ending in .pdf returned non-PDF content type: %s", contentType)
+	} else if q.Meta == nil {
+		r.Online.Error = fmt.Errorf("no metadata extractor configured")
+	} else if strings.Contains(contentTypeLower, "application/pdf") {
+		if meta, err := q.Meta.PDFMetadata(q.Context(), body); err != nil {
+			r.Online.Error = fmt.Errorf("extract.PDFMetadata error: %w", err)
+		} else {
+			r.Online.Metadata = meta
+			r.Online.Status = SearchStatusDone
+		}
+	} else if strings.Contains(contentTypeLower, "text/html") {
+		if meta, err := q.Meta.HTMLMetadata(q.Context(), body); err != nil {
+			r.Online.Error = fmt.Errorf("extract.HTMLMetadata error: %w", err)
+		} else {
+			r.Online.Metadata = meta
+			r.Online.Status = SearchStatusDone
+		}
+	} else {
+		r.Online.Error = fmt.Errorf("unexpected content type: %s", contentType)
+	}
+}
+
+func (onlineSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "Online", State: SourceSkipped}
+	switch {
+	case r.Online.Metadata != nil:
+		status.State = SourceFound
+		status.Detail = r.Online.Metadata.ToString()
+		status.Record = &Record{
+			Source:  "Online",
+			ID:      r.Online.URL,
+			Title:   r.Online.Metadata.Title,
+			Authors: r.Online.Metadata.Authors,
+			Year:    parseYear(r.Online.Metadata.PublicationDate),
+			URL:     r.Online.URL,
+			Text:    status.Detail,
+		}
+		status.Evidence = status.Detail
+	case r.Online.Error != nil:
+		status.State = SourceError
+		status.Detail = r.Online.Error.Error()
+		status.Err = r.Online.Error
+	case r.Online.Status == SearchStatusDone:
+		status.State = SourceNotFound
+	}
+	return status
+}
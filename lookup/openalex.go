@@ -0,0 +1,170 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/match"
+	"github.com/sandialabs/bibcheck/openalex"
+)
+
+type OpenAlexResult struct {
+	Status  string
+	DOI     string
+	Work    *openalex.Work
+	Comment string
+	Error   error
+	// WrongWork is set when the entry's DOI names a work other than the one
+	// the entry describes.
+	WrongWork bool
+}
+
+type openAlexSource struct{}
+
+func (openAlexSource) Key() string { return "openalex" }
+
+func (openAlexSource) Identify(text string) string { return entries.ExtractDOI(text) }
+
+func (s openAlexSource) Lookup(q *Query, r *Result) {
+	client := openalex.NewClient(openalex.WithTransport(q.transport()), openalex.WithCache(q.cache()))
+	if q.Config != nil && q.Config.OpenAlexClient != nil {
+		client = q.Config.OpenAlexClient
+	}
+	ctx := q.Context()
+
+	// A DOI identifies the work directly
+	if doi := s.Identify(q.Text); doi != "" {
+		r.OpenAlex.DOI = doi
+		work, err := client.GetByDOI(ctx, doi)
+		switch {
+		case err == nil && !isWrongWork(compareOpenAlex(q.Text, work)):
+			r.OpenAlex.Work = work
+			r.OpenAlex.Status = SearchStatusDone
+			return
+		case err == nil:
+			// A real DOI proves nothing if it belongs to some other work, so
+			// search for the entry instead
+			log.Printf("OpenAlex work for DOI %s is a different work", doi)
+			r.OpenAlex.WrongWork = true
+		case errors.Is(err, openalex.ErrDoesNotExist):
+			log.Printf("OpenAlex has no work for DOI %s", doi)
+		default:
+			r.OpenAlex.Error = fmt.Errorf("openalex DOI lookup error: %w", err)
+			return
+		}
+	}
+
+	log.Print("query openalex.org...")
+	resp, err := client.SearchWorks(ctx, q.Text, 2)
+	if err != nil {
+		r.OpenAlex.Error = fmt.Errorf("openalex search error: %w", err)
+		return
+	}
+	work, comment := openAlexConclusiveMatch(q.Text, resp.Results)
+
+	// The bibliographic string may carry too much noise for the full-text
+	// search, so retry with just the title.
+	if work == nil && q.Parser != nil {
+		if fields, err := q.Fields(); err != nil {
+			log.Printf("openalex: %v", err)
+		} else if fields.Title != "" {
+			resp, err := client.SearchTitle(ctx, fields.Title, 2)
+			if err != nil {
+				r.OpenAlex.Error = fmt.Errorf("openalex title search error: %w", err)
+				return
+			}
+			work, comment = openAlexConclusiveMatch(q.Text, resp.Results)
+		}
+	}
+
+	if work == nil && r.OpenAlex.WrongWork {
+		comment = "DOI points to a different work; " + comment
+	}
+	r.OpenAlex.Work = work
+	r.OpenAlex.Comment = comment
+	r.OpenAlex.Status = SearchStatusDone
+}
+
+// compareOpenAlex compares the entry text against an OpenAlex work.
+func compareOpenAlex(text string, work *openalex.Work) *match.Result {
+	return match.Compare(match.Citation{Text: text}, match.Work{
+		Title:   work.TitleOrName(),
+		Authors: work.Authors(),
+		Year:    work.PublicationYear,
+		Venue:   work.Venue(),
+	})
+}
+
+// openAlexConclusiveMatch accepts the best search result only when its title
+// appears in the entry text and no other result claims the same title.
+func openAlexConclusiveMatch(text string, works []openalex.Work) (*openalex.Work, string) {
+	if len(works) == 0 {
+		return nil, "no matches found"
+	}
+
+	normalizedText := normalizeForContainment(text)
+	best := &works[0]
+	title := normalizeForContainment(best.TitleOrName())
+	if title == "" || !strings.Contains(normalizedText, title) {
+		return nil, "best match title does not appear in entry"
+	}
+	for _, other := range works[1:] {
+		if other.ID != best.ID && normalizeForContainment(other.TitleOrName()) == title {
+			return nil, "no single conclusive match"
+		}
+	}
+	return best, ""
+}
+
+// normalizeForContainment lower-cases s and reduces it to space-separated
+// runs of letters and digits.
+func normalizeForContainment(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func (openAlexSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "OpenAlex", State: SourceSkipped}
+	switch {
+	case r.OpenAlex.Work != nil:
+		status.State = SourceMatched
+		status.Detail = r.OpenAlex.Work.ToString()
+		status.Record = recordFromOpenAlex(r.OpenAlex.Work)
+		status.Evidence = status.Detail
+	case r.OpenAlex.Error != nil:
+		status.State = SourceError
+		status.Detail = r.OpenAlex.Error.Error()
+		status.Err = r.OpenAlex.Error
+	case r.OpenAlex.Comment != "":
+		status.State = SourceNoMatch
+		status.Detail = r.OpenAlex.Comment
+	case r.OpenAlex.Status == SearchStatusDone:
+		status.State = SourceNoMatch
+	}
+	return status
+}
+
+func recordFromOpenAlex(work *openalex.Work) *Record {
+	rec := &Record{
+		Source:  "OpenAlex",
+		ID:      work.ID,
+		Title:   work.TitleOrName(),
+		Authors: work.Authors(),
+		Venue:   work.Venue(),
+		Year:    work.PublicationYear,
+		DOI:     work.BareDOI(),
+		URL:     work.ID,
+		Text:    work.ToString(),
+	}
+	if work.DOI != "" {
+		rec.URL = work.DOI
+	}
+	return rec
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sandialabs/bibcheck/openalex"
+)
+
+func TestOpenAlexConclusiveMatch(t *testing.T) {
+	text := `Brice Goglin, Emmanuel Jeannot, Farouk Mansouri, and Guillaume
+Mercier. 2018. Hardware Topology Management in MPI Applications
+through Hierarchical Communicators. Parallel Comput. 76 (2018), 70–90.`
+	match := openalex.Work{ID: "W1", Title: "Hardware topology management in MPI applications through hierarchical communicators"}
+	other := openalex.Work{ID: "W2", Title: "Topology-aware MPI"}
+
+	if work, comment := openAlexConclusiveMatch(text, []openalex.Work{match, other}); work == nil {
+		t.Fatalf("expected match, got comment %q", comment)
+	}
+	if work, _ := openAlexConclusiveMatch(text, []openalex.Work{other, match}); work != nil {
+		t.Fatal("accepted a best result whose title is not in the entry")
+	}
+	duplicate := match
+	duplicate.ID = "W3"
+	if _, comment := openAlexConclusiveMatch(text, []openalex.Work{match, duplicate}); comment != "no single conclusive match" {
+		t.Fatalf("comment = %q, want tie", comment)
+	}
+	if _, comment := openAlexConclusiveMatch(text, nil); comment != "no matches found" {
+		t.Fatalf("comment = %q", comment)
+	}
+}
+
+func TestOpenAlexRejectsDOIOfDifferentWork(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/works/doi:") {
+			fmt.Fprint(w, `{"id": "W1", "doi": "https://doi.org/10.1000/real", "title": "Crystal structures of zeolites", "publication_year": 2011,
+				"authorships": [{"author": {"display_name": "Someone Else"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"meta": {"count": 0}, "results": []}`)
+	}))
+	defer srv.Close()
+	client := openalex.NewClient(openalex.WithBaseURL(srv.URL), openalex.WithCache(nil))
+
+	text := "A. Author. Fast sparse solvers for exascale machines. J. Things, 2020. doi:10.1000/real"
+	q := &Query{Text: text, Config: &EntryConfig{OpenAlexClient: client}}
+	r := &Result{}
+	openAlexSource{}.Lookup(q, r)
+	if !r.OpenAlex.WrongWork || r.OpenAlex.Work != nil {
+		t.Fatalf("result = %+v, want a rejected DOI work", r.OpenAlex)
+	}
+	if status := (openAlexSource{}).Status(r); status.State != SourceNoMatch {
+		t.Fatalf("status = %+v, want no match", status)
+	}
+	if r.HasMatch() {
+		t.Fatal("HasMatch reports the unrelated DOI work")
+	}
+}
@@ -3,15 +3,22 @@
 package lookup
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 
+	"github.com/sandialabs/bibcheck/entries"
 	"github.com/sandialabs/bibcheck/osti"
 )
 
 // returns nil if no match is found on OSTI
-func GetOSTIRecord(id, rawEntry string) (*osti.Record, error) {
+func GetOSTIRecord(ctx context.Context, id, rawEntry string) (*osti.Record, error) {
+	return getOSTIRecord(ctx, osti.NewClient(), id)
+}
+
+func getOSTIRecord(ctx context.Context, ostiClient *osti.Client, id string) (*osti.Record, error) {
 
 	id = strings.TrimPrefix(id, "https://www.osti.gov/biblio/")
 	id = strings.TrimPrefix(id, "http://www.osti.gov/biblio/")
@@ -22,9 +29,7 @@ func GetOSTIRecord(id, rawEntry string) (*osti.Record, error) {
 	id = strings.TrimPrefix(id, "www.osti.gov/biblo/")
 	id = strings.TrimPrefix(id, "osti.gov/biblo/")
 
-	ostiClient := osti.NewClient()
-
-	rec, err := ostiClient.GetRecord(id)
+	rec, err := ostiClient.GetRecord(ctx, id)
 	if errors.Is(err, osti.ErrDoesNotExist) {
 		return nil, nil
 	} else if err != nil {
@@ -33,3 +38,57 @@ func GetOSTIRecord(id, rawEntry string) (*osti.Record, error) {
 
 	return rec, err
 }
+
+type ostiSource struct{}
+
+func (ostiSource) Key() string { return "osti" }
+
+func (ostiSource) Identify(text string) string { return entries.ExtractOSTI(text) }
+
+func (s ostiSource) Lookup(q *Query, r *Result) {
+	id := s.Identify(q.Text)
+	if id == "" {
+		return
+	}
+	log.Printf("Detected OSTI %s", id)
+	r.OSTI.ID = id
+	if rec, err := getOSTIRecord(q.Context(), osti.NewClient(osti.WithTransport(q.transport()), osti.WithCache(q.cache())), id); err != nil {
+		r.OSTI.Error = fmt.Errorf("GetOSTIRecord error: %w", err)
+	} else {
+		r.OSTI.Record = rec
+		r.OSTI.Status = SearchStatusDone
+	}
+}
+
+func (ostiSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "OSTI", State: SourceSkipped}
+	switch {
+	case r.OSTI.Record != nil:
+		status.State = SourceFound
+		status.Detail = r.OSTI.Record.ToString()
+		status.Record = recordFromOSTI(r.OSTI.Record)
+		status.Evidence = status.Detail
+	case r.OSTI.Error != nil:
+		status.State = SourceError
+		status.Detail = r.OSTI.Error.Error()
+		status.Err = r.OSTI.Error
+	case r.OSTI.ID != "":
+		status.State = SourceNotFound
+		status.Detail = r.OSTI.ID
+	}
+	return status
+}
+
+func recordFromOSTI(rec *osti.Record) *Record {
+	return &Record{
+		Source:  "OSTI",
+		ID:      rec.OstiID,
+		Title:   rec.Title,
+		Authors: rec.Authors,
+		Venue:   rec.ConferenceInfo,
+		Year:    parseYear(rec.PublicationDate),
+		DOI:     rec.DOI,
+		URL:     "https://www.osti.gov/biblio/" + rec.OstiID,
+		Text:    rec.ToString(),
+	}
+}
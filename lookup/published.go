@@ -0,0 +1,231 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/crossref"
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/match"
+	"github.com/sandialabs/bibcheck/retraction"
+)
+
+// publishedSearchRows bounds the Crossref title search for a published
+// version.
+const publishedSearchRows = 5
+
+// ostiDOIPrefixes are the prefixes of DOIs OSTI registers for the reports in
+// its own collection. Other DOIs on OSTI records are the publisher's.
+var ostiDOIPrefixes = []string{"10.2172/", "10.11578/"}
+
+type PublishedResult struct {
+	Status string
+	// DOI is the published version's DOI, or "" if only its journal
+	// reference is known.
+	DOI string
+	// Citation describes the published version.
+	Citation string
+	// Via says how the published version was found.
+	Via string
+	// Cited is set when the entry already cites the published version.
+	Cited bool
+	Error error
+}
+
+// preprint is a cited work as arXiv or OSTI describes it.
+type preprint struct {
+	// doi is the preprint's own DOI, if it has one.
+	doi     string
+	title   string
+	authors []string
+	// publishedDOI and journalRef are what the source says of the
+	// published version.
+	publishedDOI string
+	journalRef   string
+	via          string
+}
+
+// preprintOf returns the preprint or report the entry resolved to, or nil if
+// neither arXiv nor OSTI found it.
+func preprintOf(r *Result) *preprint {
+	switch {
+	case r.Arxiv.Entry != nil:
+		entry := r.Arxiv.Entry
+		record := recordFromArxiv(r.Arxiv.ID, entry)
+		p := &preprint{
+			title:      record.Title,
+			authors:    record.Authors,
+			journalRef: strings.Join(strings.Fields(entry.JournalRef), " "),
+			via:        "arXiv DOI",
+		}
+		// authors sometimes list several DOIs
+		if fields := strings.Fields(entry.DOI); len(fields) > 0 && !isArxivDOI(fields[0]) {
+			p.publishedDOI = fields[0]
+		}
+		return p
+	case r.OSTI.Record != nil:
+		record := r.OSTI.Record
+		p := &preprint{title: record.Title, authors: record.Authors, via: "OSTI record DOI"}
+		if isOSTIDOI(record.DOI) {
+			p.doi = record.DOI
+		} else {
+			// OSTI hosts accepted manuscripts under the publisher's DOI
+			p.publishedDOI = record.DOI
+		}
+		return p
+	}
+	return nil
+}
+
+func isArxivDOI(doi string) bool {
+	return strings.HasPrefix(retraction.NormalizeDOI(doi), "10.48550/")
+}
+
+func isOSTIDOI(doi string) bool {
+	doi = retraction.NormalizeDOI(doi)
+	for _, prefix := range ostiDOIPrefixes {
+		if strings.HasPrefix(doi, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// publishedSource suggests the peer-reviewed version of a cited preprint or
+// report. It uses the published DOI arXiv or OSTI records, then Crossref's
+// is-preprint-of relations, then a Crossref search for the preprint's title
+// and first author, and falls back to arXiv's journal reference.
+type publishedSource struct{}
+
+func (publishedSource) Key() string { return "published" }
+
+func (publishedSource) Identify(text string) string { return "" }
+
+func (publishedSource) Lookup(q *Query, r *Result) {
+	p := preprintOf(r)
+	if p == nil {
+		return
+	}
+
+	client := crossref.NewClient(crossref.WithTransport(q.transport()), crossref.WithCache(q.cache()))
+	if q.Config != nil && q.Config.CrossrefClient != nil {
+		client = q.Config.CrossrefClient
+	}
+
+	doi, via := p.publishedDOI, p.via
+	var work *crossref.CrossrefWork
+	if doi == "" && p.doi != "" {
+		log.Println("checking for a published version of", p.doi, "...")
+		published, err := client.PublishedVersion(q.Context(), p.doi)
+		if err != nil {
+			r.Published.Error = fmt.Errorf("crossref relation error: %w", err)
+			return
+		}
+		doi, via = published, "Crossref is-preprint-of relation"
+	}
+	if doi == "" && p.title != "" {
+		log.Printf("searching for a published version of %q ...", p.title)
+		var err error
+		work, err = searchPublished(q, client, p)
+		if err != nil {
+			r.Published.Error = fmt.Errorf("crossref search error: %w", err)
+			return
+		}
+		if work != nil {
+			doi, via = work.DOI, "Crossref title search"
+		}
+	}
+	r.Published.Status = SearchStatusDone
+
+	if doi == "" {
+		if p.journalRef != "" {
+			r.Published.Citation = p.journalRef
+			r.Published.Via = "arXiv journal reference"
+		}
+		return
+	}
+	r.Published.DOI = doi
+	r.Published.Via = via
+	r.Published.Cited = retraction.NormalizeDOI(entries.ExtractDOI(q.Text)) == retraction.NormalizeDOI(doi)
+	if work == nil && !r.Published.Cited {
+		// only the DOI is known, so describe the work for the suggestion
+		if w, err := client.Work(q.Context(), doi); err == nil {
+			work = w
+		} else {
+			log.Printf("crossref work %s error: %v", doi, err)
+		}
+	}
+	if work != nil {
+		r.Published.Citation = strings.TrimSpace(work.ToString())
+	} else {
+		r.Published.Citation = "doi:" + doi
+	}
+}
+
+// searchPublished returns the Crossref work with the preprint's title and
+// first author under another DOI, or nil if there is none.
+func searchPublished(q *Query, client *crossref.Client, p *preprint) (*crossref.CrossrefWork, error) {
+	surname := ""
+	if len(p.authors) > 0 {
+		surname = match.Surname(p.authors[0])
+	}
+	works, err := client.SearchPublished(q.Context(), p.title, surname, publishedSearchRows)
+	if err != nil {
+		return nil, err
+	}
+	for i := range works {
+		work := &works[i]
+		if len(work.Title) == 0 || match.Normalize(work.Title[0]) != match.Normalize(p.title) {
+			continue
+		}
+		if retraction.NormalizeDOI(work.DOI) == retraction.NormalizeDOI(p.doi) || isArxivDOI(work.DOI) {
+			continue
+		}
+		if surname != "" && len(work.Author) > 0 && !hasSurname(work, surname) {
+			continue
+		}
+		return work, nil
+	}
+	return nil, nil
+}
+
+func hasSurname(work *crossref.CrossrefWork, surname string) bool {
+	for _, author := range work.Author {
+		if match.Surname(author.Family) == surname {
+			return true
+		}
+	}
+	return false
+}
+
+func (publishedSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "Published version", State: SourceSkipped}
+	switch {
+	case r.Published.Cited:
+		status.State = SourceMatched
+		status.Detail = "entry cites the published version, doi:" + r.Published.DOI
+	case r.Published.Citation != "":
+		status.State = SourceFound
+		status.Detail = fmt.Sprintf("%s (via %s)", r.Published.Citation, r.Published.Via)
+	case r.Published.Error != nil:
+		status.State = SourceError
+		status.Detail = r.Published.Error.Error()
+		status.Err = r.Published.Error
+	case r.Published.Status == SearchStatusDone:
+		status.State = SourceNotFound
+		status.Detail = "no published version found"
+	}
+	return status
+}
+
+// Suggestion returns the published version to cite instead of the preprint
+// or report the entry cites, or "" if there is none.
+func (r *Result) Suggestion() string {
+	if r.Published.Cited || r.Published.Citation == "" {
+		return ""
+	}
+	return r.Published.Citation
+}
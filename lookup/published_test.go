@@ -0,0 +1,123 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sandialabs/bibcheck/arxiv"
+	"github.com/sandialabs/bibcheck/crossref"
+	"github.com/sandialabs/bibcheck/osti"
+)
+
+// routeTransport answers Crossref requests from bodies keyed by URL path.
+type routeTransport map[string]string
+
+func (t routeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := t[req.URL.EscapedPath()]
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusNotFound
+		body = "Resource not found."
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func lookupPublished(t *testing.T, text string, r *Result, routes routeTransport) SourceStatus {
+	t.Helper()
+	client := crossref.NewClient(
+		crossref.WithHTTPClient(&http.Client{Transport: routes}),
+		crossref.WithCache(nil),
+	)
+	r.Text = text
+	publishedSource{}.Lookup(&Query{Text: text, Config: &EntryConfig{CrossrefClient: client}}, r)
+	return publishedSource{}.Status(r)
+}
+
+const solverWork = `{"status":"ok","message":{"DOI":"10.1000/jcp.1","title":["A Fast Solver"],
+	"author":[{"given":"Jane","family":"Smith"}],"container-title":["J. Comput. Phys."],
+	"published-print":{"date-parts":[[2021,3]]}}}`
+
+func TestPublishedFromArxivDOI(t *testing.T) {
+	r := &Result{}
+	r.Arxiv.ID = "https://arxiv.org/abs/2001.00001"
+	r.Arxiv.Entry = &arxiv.Entry{Title: "A fast solver", Authors: []arxiv.Author{{Name: "Jane Smith"}}, DOI: "10.1000/JCP.1"}
+
+	status := lookupPublished(t, "J. Smith. A fast solver. arXiv:2001.00001, 2020.", r, routeTransport{
+		"/v1/works/10.1000%2FJCP.1": solverWork,
+	})
+	if status.State != SourceFound || r.Published.Via != "arXiv DOI" {
+		t.Fatalf("status = %+v, published = %+v", status, r.Published)
+	}
+	if got := r.Suggestion(); !strings.Contains(got, "J. Comput. Phys.") || !strings.Contains(got, "doi:10.1000/jcp.1") {
+		t.Fatalf("suggestion = %q", got)
+	}
+}
+
+func TestPublishedFromTitleSearch(t *testing.T) {
+	r := &Result{}
+	r.Arxiv.ID = "https://arxiv.org/abs/2001.00001"
+	r.Arxiv.Entry = &arxiv.Entry{Title: "A Fast\n  Solver", Authors: []arxiv.Author{{Name: "Jane Smith"}}}
+
+	status := lookupPublished(t, "J. Smith. A fast solver. arXiv:2001.00001, 2020.", r, routeTransport{
+		"/v1/works": `{"status":"ok","message":{"items":[
+			{"DOI":"10.1000/other","title":["A Fast Solver for Something Else"],"author":[{"family":"Smith"}]},
+			{"DOI":"10.1000/namesake","title":["A fast solver"],"author":[{"family":"Jones"}]},
+			{"DOI":"10.1000/jcp.1","title":["A fast solver"],"author":[{"family":"Smith"}]}]}}`,
+	})
+	if status.State != SourceFound || r.Published.DOI != "10.1000/jcp.1" || r.Published.Via != "Crossref title search" {
+		t.Fatalf("status = %+v, published = %+v", status, r.Published)
+	}
+}
+
+func TestPublishedFallsBackToJournalRef(t *testing.T) {
+	r := &Result{}
+	r.Arxiv.ID = "https://arxiv.org/abs/2001.00001"
+	r.Arxiv.Entry = &arxiv.Entry{Title: "A fast solver", JournalRef: "J. Comput.\n Phys. 400 (2021)"}
+
+	status := lookupPublished(t, "J. Smith. A fast solver. arXiv:2001.00001, 2020.", r, routeTransport{
+		"/v1/works": `{"status":"ok","message":{"items":[]}}`,
+	})
+	if status.State != SourceFound || r.Suggestion() != "J. Comput. Phys. 400 (2021)" {
+		t.Fatalf("status = %+v, suggestion = %q", status, r.Suggestion())
+	}
+}
+
+func TestPublishedFromOSTIReportRelation(t *testing.T) {
+	r := &Result{}
+	r.OSTI.Record = &osti.Record{OstiID: "1234", Title: "A fast solver", Authors: []string{"Smith, Jane"}, DOI: "10.2172/1234"}
+
+	status := lookupPublished(t, "J. Smith. A fast solver. SAND2020-1, https://www.osti.gov/biblio/1234", r, routeTransport{
+		"/v1/works/10.2172%2F1234": `{"status":"ok","message":{"DOI":"10.2172/1234",
+			"relation":{"is-preprint-of":[{"id-type":"doi","id":"10.1000/jcp.1","asserted-by":"subject"}]}}}`,
+		"/v1/works/10.1000%2Fjcp.1": solverWork,
+	})
+	if status.State != SourceFound || r.Published.Via != "Crossref is-preprint-of relation" {
+		t.Fatalf("status = %+v, published = %+v", status, r.Published)
+	}
+}
+
+func TestPublishedAlreadyCited(t *testing.T) {
+	r := &Result{}
+	r.OSTI.Record = &osti.Record{OstiID: "1234", Title: "A fast solver", DOI: "10.1000/jcp.1"}
+
+	status := lookupPublished(t, "J. Smith. A fast solver. J. Comput. Phys., 2021. doi:10.1000/JCP.1", r, routeTransport{})
+	if status.State != SourceMatched || r.Suggestion() != "" {
+		t.Fatalf("status = %+v, suggestion = %q", status, r.Suggestion())
+	}
+}
+
+func TestPublishedSkipsOtherSources(t *testing.T) {
+	r := &Result{}
+	status := lookupPublished(t, "J. Smith. A fast solver. J. Comput. Phys., 2021.", r, routeTransport{})
+	if status.State != SourceSkipped {
+		t.Fatalf("status = %+v", status)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/crossref"
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/retraction"
+)
+
+type RetractionResult struct {
+	Status  string
+	DOI     string
+	Notices []retraction.Notice
+	Error   error
+}
+
+// Retracted reports whether the cited work has been retracted or withdrawn.
+func (r *Result) Retracted() bool {
+	return retraction.Retracted(r.Retraction.Notices)
+}
+
+// retractionSource checks the DOI of the cited work, from the entry text or
+// from a record matched by an earlier source, for retraction, withdrawal and
+// correction notices.
+type retractionSource struct{}
+
+func (retractionSource) Key() string { return "retraction" }
+
+func (retractionSource) Identify(text string) string { return entries.ExtractDOI(text) }
+
+func (s retractionSource) Lookup(q *Query, r *Result) {
+	doi := s.Identify(q.Text)
+	if r.WrongDOI() {
+		// the entry's DOI is some other work's
+		doi = ""
+	}
+	if doi == "" {
+		for _, record := range r.Records() {
+			if record.DOI != "" {
+				doi = record.DOI
+				break
+			}
+		}
+	}
+	if doi == "" {
+		return
+	}
+	r.Retraction.DOI = doi
+
+	var watched []retraction.Notice
+	client := crossref.NewClient(crossref.WithTransport(q.transport()), crossref.WithCache(q.cache()))
+	if q.Config != nil {
+		watched = q.Config.RetractionWatch.Lookup(doi)
+		if q.Config.CrossrefClient != nil {
+			client = q.Config.CrossrefClient
+		}
+	}
+
+	log.Println("checking updates to", doi, "...")
+	updates, err := client.Updates(q.Context(), doi)
+	if err != nil {
+		r.Retraction.Error = fmt.Errorf("crossref updates error: %w", err)
+	}
+	r.Retraction.Notices = retraction.Merge(watched, retraction.FromCrossref(updates))
+	if err == nil || len(r.Retraction.Notices) > 0 {
+		r.Retraction.Status = SearchStatusDone
+	}
+}
+
+func (retractionSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: "Retraction", State: SourceSkipped}
+	switch {
+	case len(r.Retraction.Notices) > 0:
+		status.State = SourceFlagged
+		notices := make([]string, len(r.Retraction.Notices))
+		for i, notice := range r.Retraction.Notices {
+			notices[i] = notice.String()
+		}
+		status.Detail = strings.Join(notices, "; ")
+	case r.Retraction.Error != nil:
+		status.State = SourceError
+		status.Detail = r.Retraction.Error.Error()
+		status.Err = r.Retraction.Error
+	case r.Retraction.Status == SearchStatusDone:
+		status.State = SourceNotFound
+		status.Detail = "no notices for " + r.Retraction.DOI
+	}
+	return status
+}
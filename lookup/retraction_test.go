@@ -0,0 +1,79 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sandialabs/bibcheck/crossref"
+	"github.com/sandialabs/bibcheck/retraction"
+)
+
+// notFoundTransport answers every request with 404, recording the hosts it
+// was sent to.
+type notFoundTransport struct {
+	requests int
+	hosts    []string
+}
+
+func (t *notFoundTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	t.hosts = append(t.hosts, req.URL.Host)
+	return &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(strings.NewReader("Resource not found.")),
+		Request:    req,
+	}, nil
+}
+
+func TestRetractionRunsAfterSufficientSource(t *testing.T) {
+	ran := []string{}
+	registerFakes(t,
+		fakeSource{key: "first", found: true, ran: &ran},
+		fakeSource{key: "second", ran: &ran},
+	)
+	watch, err := retraction.ParseWatch(strings.NewReader(
+		"OriginalPaperDOI,RetractionNature,RetractionDOI\n10.1000/paper,Retraction,10.1000/notice\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &notFoundTransport{}
+	client := crossref.NewClient(
+		crossref.WithHTTPClient(&http.Client{Transport: transport}),
+		crossref.WithCache(nil),
+	)
+
+	text := "A. Author. A retracted paper. J. Things, 2020. doi:10.1000/paper"
+	result, err := Entry(context.Background(), text, "", nil, nil, nil, &EntryConfig{
+		CrossrefClient:  client,
+		RetractionWatch: watch,
+		Strategy: []Step{
+			{Source: "first", Sufficient: true},
+			{Source: "second"},
+			{Source: "retraction", Always: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ran, ",") != "first" {
+		t.Fatalf("ran = %v, want [first]", ran)
+	}
+	if transport.requests == 0 {
+		t.Fatal("crossref was not asked for updates")
+	}
+	if !result.Retracted() {
+		t.Fatalf("notices = %+v, want a retraction", result.Retraction.Notices)
+	}
+	status := retractionSource{}.Status(result)
+	if status.State != SourceFlagged || !strings.Contains(status.Detail, "doi:10.1000/notice") {
+		t.Fatalf("status = %+v", status)
+	}
+	if result.HasError() {
+		t.Fatal("unknown DOI reported as an error")
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sandialabs/bibcheck/documents"
+	"github.com/sandialabs/bibcheck/entries"
+	"github.com/sandialabs/bibcheck/httpcache"
+)
+
+// SourceState describes the outcome of one source for one entry.
+type SourceState string
+
+const (
+	SourceSkipped  SourceState = "skipped"
+	SourceFound    SourceState = "found"
+	SourceNotFound SourceState = "not-found"
+	SourceMatched  SourceState = "matched"
+	SourceNoMatch  SourceState = "no-match"
+	SourceError    SourceState = "error"
+	// SourceFlagged means the source found a problem with the cited work
+	// itself, such as a retraction.
+	SourceFlagged SourceState = "flagged"
+	// SourceWrongWork means the entry's identifier exists but belongs to a
+	// different work than the entry describes.
+	SourceWrongWork SourceState = "wrong-work"
+)
+
+// Record is the source-independent description of a work returned by a
+// Source. Fields the source does not provide are left empty.
+type Record struct {
+	Source  string
+	ID      string
+	Title   string
+	Authors []string
+	Venue   string
+	Year    int
+	DOI     string
+	URL     string
+	// Text is the source's own rendering of the record.
+	Text string
+}
+
+// SourceStatus is the display-oriented outcome of a Source for one Result.
+type SourceStatus struct {
+	Name   string
+	State  SourceState
+	Detail string
+	// Record is the normalized metadata the source matched, if any.
+	Record *Record
+	// Evidence describes what the source established, for summarizers.
+	// It is empty when the source found nothing.
+	Evidence string
+	Err      error
+}
+
+// Source is a metadata database that can verify a bibliography entry.
+//
+// Lookup records its outcome on Result, either on the source's own field or
+// with Result.SetSourceResult under its Key, and Status reads it back in a common form so renderers and summarizers do not need to
+// know about individual sources.
+type Source interface {
+	// Key is the stable lower-case name used in configuration.
+	Key() string
+	// Identify returns the source-specific identifier found in the entry text,
+	// or "" if the text does not reference this source directly.
+	Identify(text string) string
+	Lookup(q *Query, r *Result)
+	Status(r *Result) SourceStatus
+}
+
+// Step configures how one source takes part in lookup.Entry.
+type Step struct {
+	Source string
+	// Disabled sources are skipped.
+	Disabled bool
+	// Sufficient stops the lookup after this source matches a record.
+	Sufficient bool
+	// Fallback sources only run when no earlier source matched a record.
+	Fallback bool
+	// Always sources run even after a Sufficient source matched, for checks
+	// that build on the records found by earlier sources.
+	Always bool
+}
+
+// DefaultStrategy is the order in which sources are tried when
+// EntryConfig.Strategy is empty.
+func DefaultStrategy() []Step {
+	return []Step{
+		// The existence of a DOI is not very useful alone, so continue on
+		{Source: "doi"},
+		// Finding the ID should provide enough info to evaluate the entry
+		{Source: "osti", Sufficient: true},
+		{Source: "arxiv", Sufficient: true},
+		{Source: "elsevier"},
+		{Source: "crossref"},
+		{Source: "openalex"},
+		{Source: "dblp"},
+		// Treat the entry as a generic online resource if nothing else matched
+		{Source: "online", Fallback: true},
+		// Suggest the peer-reviewed version of a preprint or report
+		{Source: "published", Always: true},
+		// Check the cited work for retractions once its DOI is known
+		{Source: "retraction", Always: true},
+	}
+}
+
+// StrategyFor returns the steps for the named sources, in the given order.
+// Sources in the default strategy keep their default settings.
+func StrategyFor(keys []string) ([]Step, error) {
+	defaults := map[string]Step{}
+	for _, step := range DefaultStrategy() {
+		defaults[step.Source] = step
+	}
+
+	steps := make([]Step, 0, len(keys))
+	for _, key := range keys {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, ok := SourceByKey(key); !ok {
+			return nil, fmt.Errorf("unknown lookup source %q (available: %s)", key, strings.Join(SourceKeys(), ", "))
+		}
+		step, ok := defaults[key]
+		if !ok {
+			step = Step{Source: key}
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = []Source{
+		doiSource{},
+		ostiSource{},
+		arxivSource{},
+		elsevierSource{},
+		crossrefSource{},
+		openAlexSource{},
+		dblpSource{},
+		onlineSource{},
+		publishedSource{},
+		retractionSource{},
+	}
+)
+
+// Register adds a source to the registry. Registered sources are reported by
+// Result.Statuses in registration order.
+func Register(s Source) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for i, existing := range registry {
+		if existing.Key() == s.Key() {
+			registry[i] = s
+			return
+		}
+	}
+	registry = append(registry, s)
+}
+
+// Sources returns the registered sources in registration order.
+func Sources() []Source {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Source(nil), registry...)
+}
+
+// SourceByKey returns the registered source named key.
+func SourceByKey(key string) (Source, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, s := range registry {
+		if s.Key() == key {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// SourceKeys returns the keys of the registered sources.
+func SourceKeys() []string {
+	sources := Sources()
+	keys := make([]string, len(sources))
+	for i, s := range sources {
+		keys[i] = s.Key()
+	}
+	return keys
+}
+
+// Statuses reports the outcome of every registered source for r.
+func (r *Result) Statuses() []SourceStatus {
+	sources := Sources()
+	statuses := make([]SourceStatus, len(sources))
+	for i, s := range sources {
+		statuses[i] = s.Status(r)
+	}
+	return statuses
+}
+
+// Records returns the normalized records matched by any source.
+func (r *Result) Records() []Record {
+	records := []Record{}
+	for _, status := range r.Statuses() {
+		if status.Record != nil {
+			records = append(records, *status.Record)
+		}
+	}
+	return records
+}
+
+// Evidence returns the search results to compare the entry text against.
+func (r *Result) Evidence() []string {
+	evidence := []string{}
+	for _, status := range r.Statuses() {
+		if status.Evidence != "" {
+			evidence = append(evidence, status.Evidence)
+		}
+	}
+	return evidence
+}
+
+// HasError reports whether any source failed.
+func (r *Result) HasError() bool {
+	for _, status := range r.Statuses() {
+		if status.State == SourceError {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMatch reports whether any source found or matched the entry.
+func (r *Result) HasMatch() bool {
+	for _, status := range r.Statuses() {
+		if status.State == SourceFound || status.State == SourceMatched {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields is the structured metadata parsed from an entry's text.
+type Fields struct {
+	Authors *entries.Authors
+	Title   string
+	Pub     string
+}
+
+// Query is the entry being looked up, shared by all sources in one call to
+// Entry.
+type Query struct {
+	Text   string
+	Parser entries.Parser
+	Meta   documents.MetaExtractor
+	Config *EntryConfig
+
+	ctx context.Context
+
+	fieldsOnce sync.Once
+	fields     *Fields
+	fieldsErr  error
+}
+
+// Context returns the context of the Entry call, which sources use for their
+// requests. It is never nil.
+func (q *Query) Context() context.Context {
+	if q.ctx == nil {
+		return context.Background()
+	}
+	return q.ctx
+}
+
+// transport returns the RoundTripper for the clients sources build
+// themselves, or nil for http.DefaultTransport.
+func (q *Query) transport() http.RoundTripper {
+	if q.Config == nil {
+		return nil
+	}
+	return q.Config.Transport
+}
+
+// cache returns the HTTP cache for the clients sources build themselves, or
+// nil if they should not use one.
+func (q *Query) cache() *httpcache.Cache {
+	if q.Config != nil && q.Config.NoHTTPCache {
+		return nil
+	}
+	return httpcache.Default()
+}
+
+// Fields parses the entry's authors, title and publication venue. The
+// parser is called at most once per Query.
+func (q *Query) Fields() (*Fields, error) {
+	q.fieldsOnce.Do(func() {
+		q.fields, q.fieldsErr = parseFields(q.Context(), q.Parser, q.Text)
+	})
+	return q.fields, q.fieldsErr
+}
+
+func parseFields(ctx context.Context, entryParser entries.Parser, text string) (*Fields, error) {
+	if entryParser == nil {
+		return nil, fmt.Errorf("no entry parser configured")
+	}
+
+	log.Println("Extracting entry metadata...")
+	var wg sync.WaitGroup
+	var authorsErr, titleErr, pubErr error
+	fields := &Fields{}
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		fields.Authors, authorsErr = entryParser.ParseAuthors(ctx, text)
+		if fields.Authors != nil {
+			log.Printf("authors: %v", fields.Authors.Authors)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		fields.Title, titleErr = entryParser.ParseTitle(ctx, text)
+		log.Printf("title: %v", fields.Title)
+	}()
+	go func() {
+		defer wg.Done()
+		fields.Pub, pubErr = entryParser.ParsePub(ctx, text)
+		log.Printf("pub: %v", fields.Pub)
+	}()
+	wg.Wait()
+
+	if authorsErr != nil {
+		return nil, fmt.Errorf("ParseAuthors error: %w", authorsErr)
+	} else if titleErr != nil {
+		return nil, fmt.Errorf("ParseTitle error: %w", titleErr)
+	} else if pubErr != nil {
+		return nil, fmt.Errorf("ParsePub error: %w", pubErr)
+	}
+	if fields.Authors == nil {
+		fields.Authors = &entries.Authors{}
+	}
+	return fields, nil
+}
+
+var yearRe = regexp.MustCompile(`\b(1[5-9]\d\d|20\d\d)\b`)
+
+// parseYear returns the first plausible year in a date string.
+func parseYear(date string) int {
+	match := yearRe.FindString(date)
+	if match == "" {
+		return 0
+	}
+	year, _ := strconv.Atoi(match)
+	return year
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package lookup
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeSource records whether it ran and reports a record when found is set.
+type fakeSource struct {
+	key   string
+	found bool
+	ran   *[]string
+	// cancel, if set, is called after the source runs
+	cancel context.CancelFunc
+}
+
+func (s fakeSource) Key() string            { return s.key }
+func (s fakeSource) Identify(string) string { return "" }
+
+func (s fakeSource) Lookup(q *Query, r *Result) {
+	*s.ran = append(*s.ran, s.key)
+	if s.cancel != nil {
+		s.cancel()
+	}
+	if s.found {
+		r.SetSourceResult(s.key, "A useful paper")
+	}
+}
+
+func (s fakeSource) Status(r *Result) SourceStatus {
+	status := SourceStatus{Name: s.key, State: SourceSkipped}
+	if title, ok := r.SourceResult(s.key).(string); ok {
+		status.State = SourceMatched
+		status.Record = &Record{Source: s.key, Title: title}
+		status.Evidence = title
+	}
+	return status
+}
+
+func registerFakes(t *testing.T, fakes ...fakeSource) {
+	t.Helper()
+	saved := Sources()
+	t.Cleanup(func() {
+		registryMu.Lock()
+		registry = saved
+		registryMu.Unlock()
+	})
+	for _, fake := range fakes {
+		Register(fake)
+	}
+}
+
+func TestEntryStopsAfterSufficientSource(t *testing.T) {
+	ran := []string{}
+	registerFakes(t,
+		fakeSource{key: "first", found: true, ran: &ran},
+		fakeSource{key: "second", ran: &ran},
+	)
+
+	result, err := Entry(context.Background(), "entry", "", nil, nil, nil, &EntryConfig{Strategy: []Step{
+		{Source: "first", Sufficient: true},
+		{Source: "second"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ran, ",") != "first" {
+		t.Fatalf("ran = %v, want [first]", ran)
+	}
+	if !result.HasMatch() {
+		t.Fatal("expected a match")
+	}
+	if got := result.Evidence(); len(got) != 1 || got[0] != "A useful paper" {
+		t.Fatalf("evidence = %v", got)
+	}
+}
+
+func TestEntrySkipsFallbackAfterMatch(t *testing.T) {
+	ran := []string{}
+	registerFakes(t,
+		fakeSource{key: "first", found: true, ran: &ran},
+		fakeSource{key: "fallback", ran: &ran},
+		fakeSource{key: "disabled", ran: &ran},
+	)
+
+	_, err := Entry(context.Background(), "entry", "", nil, nil, nil, &EntryConfig{Strategy: []Step{
+		{Source: "disabled", Disabled: true},
+		{Source: "first"},
+		{Source: "fallback", Fallback: true},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ran, ",") != "first" {
+		t.Fatalf("ran = %v, want [first]", ran)
+	}
+}
+
+func TestEntryStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ran := []string{}
+	registerFakes(t,
+		fakeSource{key: "first", ran: &ran, cancel: cancel},
+		fakeSource{key: "second", ran: &ran},
+	)
+
+	_, err := Entry(ctx, "entry", "", nil, nil, nil, &EntryConfig{Strategy: []Step{
+		{Source: "first"},
+		{Source: "second"},
+	}})
+	if err != context.Canceled {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if strings.Join(ran, ",") != "first" {
+		t.Fatalf("ran = %v, want [first]", ran)
+	}
+}
+
+func TestEntryRejectsUnknownSource(t *testing.T) {
+	_, err := Entry(context.Background(), "entry", "", nil, nil, nil, &EntryConfig{Strategy: []Step{{Source: "nope"}}})
+	if err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestStrategyForKeepsDefaultSettings(t *testing.T) {
+	steps, err := StrategyFor([]string{"crossref", " OSTI "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 2 || steps[0].Source != "crossref" || steps[1].Source != "osti" {
+		t.Fatalf("unexpected steps: %+v", steps)
+	}
+	if !steps[1].Sufficient {
+		t.Fatal("osti should remain sufficient")
+	}
+	if _, err := StrategyFor([]string{"nope"}); err == nil {
+		t.Fatal("expected unknown source error")
+	}
+}
+
+func TestStatusesCoverEverySource(t *testing.T) {
+	result := &Result{}
+	result.DOIOrg.ID = "10.1234/example"
+
+	statuses := result.Statuses()
+	if len(statuses) != len(Sources()) {
+		t.Fatalf("len(statuses) = %d, want %d", len(statuses), len(Sources()))
+	}
+	if statuses[0].Name != "DOI" || statuses[0].State != SourceNotFound {
+		t.Fatalf("unexpected DOI status: %+v", statuses[0])
+	}
+	for _, status := range statuses[1:] {
+		if status.State != SourceSkipped {
+			t.Fatalf("unexpected %s status: %+v", status.Name, status)
+		}
+	}
+}
+
+func TestEntrySendsRequestsThroughTransport(t *testing.T) {
+	t.Setenv("HTTP_CACHE_ENABLED", "false")
+	transport := &notFoundTransport{}
+
+	text := "J. Smith. A paper. 2020. doi:10.1000/paper arXiv:2001.00001 https://www.osti.gov/biblio/1234"
+	_, err := Entry(context.Background(), text, "", nil, nil, nil, &EntryConfig{
+		Transport: transport,
+		Strategy:  []Step{{Source: "doi"}, {Source: "osti"}, {Source: "arxiv"}, {Source: "openalex"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, host := range []string{"doi.org", "www.osti.gov", "export.arxiv.org", "api.openalex.org"} {
+		if !slices.Contains(transport.hosts, host) {
+			t.Errorf("no request to %s through the transport, got %v", host, transport.hosts)
+		}
+	}
+}
+
+func TestOnlineSkippedWithoutParser(t *testing.T) {
+	text := "A. Author. Home page. https://example.com/author"
+	result, err := Entry(context.Background(), text, "", nil, nil, nil, &EntryConfig{
+		Strategy: []Step{{Source: "online"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.HasError() {
+		t.Fatalf("online lookup without a parser reported an error: %v", result.Online.Error)
+	}
+	if status := (onlineSource{}).Status(result); status.State != SourceSkipped {
+		t.Fatalf("status = %+v, want skipped", status)
+	}
+}
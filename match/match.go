@@ -0,0 +1,391 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package match compares a cited bibliography entry against metadata found
+// for it, field by field and without an LLM, so that the verdict is
+// reproducible and can be explained to authors.
+package match
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Verdict string
+
+const (
+	VerdictMatch    Verdict = "match"
+	VerdictPartial  Verdict = "partial"
+	VerdictMismatch Verdict = "mismatch"
+	// VerdictMissing means the field could not be compared because the found
+	// metadata does not provide it.
+	VerdictMissing Verdict = "missing"
+)
+
+const (
+	FieldTitle   = "title"
+	FieldAuthors = "authors"
+	FieldYear    = "year"
+	FieldVenue   = "venue"
+)
+
+const (
+	// MatchThreshold is the minimum score for an overall match.
+	MatchThreshold = 0.85
+	// MismatchThreshold is the score below which the entry is a mismatch.
+	MismatchThreshold = 0.5
+	// YearTolerance allows for preprints and online-first publication.
+	YearTolerance = 1
+)
+
+// weights of each field in the overall score. Missing fields are left out
+// and the remaining weights are renormalized.
+var weights = map[string]float64{
+	FieldTitle:   0.5,
+	FieldAuthors: 0.3,
+	FieldYear:    0.1,
+	FieldVenue:   0.1,
+}
+
+// Citation is the entry as cited. Text is required; the structured fields
+// are optional and, when empty, are located in Text instead.
+type Citation struct {
+	Text    string
+	Title   string
+	Authors []string
+	EtAl    bool
+	Year    int
+	Venue   string
+}
+
+// Work is the metadata found for the entry.
+type Work struct {
+	Title   string
+	Authors []string
+	Year    int
+	Venue   string
+}
+
+// Field is the comparison of one field.
+type Field struct {
+	Name    string
+	Verdict Verdict
+	Score   float64
+	Detail  string
+}
+
+// Result is the comparison of a citation against one work.
+type Result struct {
+	// Source names the record compared against; set by callers.
+	Source  string
+	Score   float64
+	Verdict Verdict
+	Fields  []Field
+}
+
+// Compare scores how well work matches the citation.
+func Compare(c Citation, w Work) *Result {
+	r := &Result{
+		Fields: []Field{
+			compareTitle(c, w),
+			compareAuthors(c, w),
+			compareYear(c, w),
+			compareVenue(c, w),
+		},
+	}
+
+	var total, weight float64
+	titleMismatch := false
+	for _, f := range r.Fields {
+		if f.Verdict == VerdictMissing {
+			continue
+		}
+		total += weights[f.Name] * f.Score
+		weight += weights[f.Name]
+		if f.Name == FieldTitle && f.Verdict == VerdictMismatch {
+			titleMismatch = true
+		}
+	}
+	if weight > 0 {
+		r.Score = total / weight
+	}
+
+	switch {
+	case weight == 0 || titleMismatch || r.Score < MismatchThreshold:
+		r.Verdict = VerdictMismatch
+	case r.Score >= MatchThreshold:
+		r.Verdict = VerdictMatch
+	default:
+		r.Verdict = VerdictPartial
+	}
+	return r
+}
+
+// Explain describes the comparison in one line.
+func (r *Result) Explain() string {
+	parts := make([]string, 0, len(r.Fields))
+	for _, f := range r.Fields {
+		part := f.Name + " " + string(f.Verdict)
+		if f.Detail != "" {
+			part += " (" + f.Detail + ")"
+		}
+		parts = append(parts, part)
+	}
+	against := ""
+	if r.Source != "" {
+		against = " against " + r.Source
+	}
+	return fmt.Sprintf("%s%s, score %.2f: %s", r.Verdict, against, r.Score, strings.Join(parts, "; "))
+}
+
+func verdictFor(score float64) Verdict {
+	switch {
+	case score >= MatchThreshold:
+		return VerdictMatch
+	case score >= MismatchThreshold:
+		return VerdictPartial
+	default:
+		return VerdictMismatch
+	}
+}
+
+func compareTitle(c Citation, w Work) Field {
+	f := Field{Name: FieldTitle, Verdict: VerdictMissing}
+	title := Tokens(w.Title)
+	if len(title) == 0 {
+		return f
+	}
+
+	if c.Title != "" {
+		f.Score = similarity(Normalize(c.Title), strings.Join(title, " "))
+	} else {
+		f.Score = bestWindowSimilarity(Tokens(c.Text), title)
+	}
+	f.Verdict = verdictFor(f.Score)
+	f.Detail = fmt.Sprintf("%.2f", f.Score)
+	return f
+}
+
+// bestWindowSimilarity finds the run of text tokens most similar to title,
+// allowing the cited title to be a word shorter or longer.
+func bestWindowSimilarity(text, title []string) float64 {
+	target := strings.Join(title, " ")
+	best := 0.0
+	for size := max(1, len(title)-1); size <= len(title)+1; size++ {
+		for start := 0; start+size <= len(text); start++ {
+			if s := similarity(strings.Join(text[start:start+size], " "), target); s > best {
+				best = s
+			}
+		}
+	}
+	return best
+}
+
+func compareAuthors(c Citation, w Work) Field {
+	f := Field{Name: FieldAuthors, Verdict: VerdictMissing}
+	found := surnames(w.Authors)
+	if len(found) == 0 {
+		return f
+	}
+
+	var matched, expected int
+	if len(c.Authors) > 0 {
+		cited := surnames(c.Authors)
+		matched = lcs(cited, found)
+		expected = max(len(cited), len(found))
+		if c.EtAl && len(cited) <= len(found) {
+			// the citation lists only the leading authors
+			expected = len(cited)
+		}
+	} else {
+		matched, expected = alignInText(c.Text, found)
+	}
+
+	if expected == 0 {
+		return f
+	}
+	f.Score = float64(matched) / float64(expected)
+	f.Verdict = verdictFor(f.Score)
+	f.Detail = fmt.Sprintf("%d/%d in order", matched, expected)
+	return f
+}
+
+func surnames(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if s := Surname(name); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// alignInText counts the found authors whose surnames appear in the text in
+// order. If the text abbreviates its author list with "et al.", only the
+// authors cited before it are expected.
+func alignInText(text string, found []string) (int, int) {
+	tokens := Tokens(text)
+	etAl := len(tokens)
+	if loc := etAlRe.FindStringIndex(text); loc != nil {
+		etAl = len(Tokens(text[:loc[0]]))
+	}
+
+	positions := make([]int, 0, len(found))
+	for _, surname := range found {
+		positions = append(positions, indexOf(tokens[:etAl], surname))
+	}
+
+	if etAl < len(tokens) {
+		// count the leading authors cited before "et al."
+		matched, last := 0, -1
+		for _, pos := range positions {
+			if pos <= last {
+				break
+			}
+			matched++
+			last = pos
+		}
+		if matched == 0 {
+			return 0, 1
+		}
+		return matched, matched
+	}
+
+	present := []int{}
+	for _, pos := range positions {
+		if pos >= 0 {
+			present = append(present, pos)
+		}
+	}
+	return longestIncreasing(present), len(found)
+}
+
+func indexOf(tokens []string, word string) int {
+	for i, t := range tokens {
+		if t == word {
+			return i
+		}
+	}
+	return -1
+}
+
+// lcs is the length of the longest common subsequence of a and b.
+func lcs(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				cur[j] = prev[j-1] + 1
+			} else {
+				cur[j] = max(prev[j], cur[j-1])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// longestIncreasing is the length of the longest strictly increasing
+// subsequence of xs.
+func longestIncreasing(xs []int) int {
+	tails := []int{}
+	for _, x := range xs {
+		i := 0
+		for i < len(tails) && tails[i] < x {
+			i++
+		}
+		if i == len(tails) {
+			tails = append(tails, x)
+		} else {
+			tails[i] = x
+		}
+	}
+	return len(tails)
+}
+
+func compareYear(c Citation, w Work) Field {
+	f := Field{Name: FieldYear, Verdict: VerdictMissing}
+	if w.Year == 0 {
+		return f
+	}
+
+	cited := Years(c.Text)
+	if c.Year != 0 {
+		cited = []int{c.Year}
+	}
+	if len(cited) == 0 {
+		// an entry without a year, like an undated web page, does not
+		// contradict the record
+		f.Detail = fmt.Sprintf("no year cited, found %d", w.Year)
+		return f
+	}
+
+	closest := cited[0]
+	for _, year := range cited[1:] {
+		if abs(year-w.Year) < abs(closest-w.Year) {
+			closest = year
+		}
+	}
+	switch diff := abs(closest - w.Year); {
+	case diff == 0:
+		f.Verdict, f.Score = VerdictMatch, 1
+	case diff <= YearTolerance:
+		f.Verdict, f.Score = VerdictPartial, 0.5
+		f.Detail = fmt.Sprintf("cited %d, found %d", closest, w.Year)
+	default:
+		f.Verdict = VerdictMismatch
+		f.Detail = fmt.Sprintf("cited %d, found %d", closest, w.Year)
+	}
+	return f
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func compareVenue(c Citation, w Work) Field {
+	f := Field{Name: FieldVenue, Verdict: VerdictMissing}
+	venue := Tokens(w.Venue)
+	if len(venue) == 0 {
+		return f
+	}
+
+	if venueMatches(c, venue) {
+		f.Verdict, f.Score = VerdictMatch, 1
+	} else {
+		f.Verdict = VerdictMismatch
+		f.Detail = "found " + w.Venue
+	}
+	return f
+}
+
+// venueMatches accepts the full venue name, its acronym, or a word-by-word
+// abbreviation of it.
+func venueMatches(c Citation, venue []string) bool {
+	if c.Venue != "" {
+		cited := Tokens(c.Venue)
+		return strings.Join(cited, " ") == strings.Join(venue, " ") ||
+			abbreviates(cited, venue) ||
+			(len(cited) == 1 && cited[0] == acronym(venue)) ||
+			(len(venue) == 1 && venue[0] == acronym(cited))
+	}
+
+	text := Tokens(c.Text)
+	if containsWords(text, venue) {
+		return true
+	}
+	if a := acronym(venue); len(a) > 1 && indexOf(text, a) >= 0 {
+		return true
+	}
+	size := len(significant(venue))
+	for start := 0; start+size <= len(text); start++ {
+		if abbreviates(text[start:start+size], venue) {
+			return true
+		}
+	}
+	return false
+}
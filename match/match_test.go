@@ -0,0 +1,167 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package match
+
+import (
+	"strings"
+	"testing"
+)
+
+const kokkosEntry = `H. C. Edwards, C. R. Trott, and D. Sunderland, "Kokkos: Enabling manycore performance portability through polymorphic memory access patterns," J. Parallel Distrib. Comput., vol. 74, no. 12, pp. 3202–3216, 2014.`
+
+var kokkosWork = Work{
+	Title:   "Kokkos: Enabling manycore performance portability through polymorphic memory access patterns",
+	Authors: []string{"H. Carter Edwards", "Christian R. Trott", "Daniel Sunderland"},
+	Year:    2014,
+	Venue:   "Journal of Parallel and Distributed Computing",
+}
+
+func fieldNamed(t *testing.T, r *Result, name string) Field {
+	t.Helper()
+	for _, f := range r.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	t.Fatalf("no %s field in %+v", name, r.Fields)
+	return Field{}
+}
+
+func TestCompareMatchesEntryText(t *testing.T) {
+	r := Compare(Citation{Text: kokkosEntry}, kokkosWork)
+	if r.Verdict != VerdictMatch || r.Score != 1 {
+		t.Fatalf("got %s %.2f: %s", r.Verdict, r.Score, r.Explain())
+	}
+	for _, f := range r.Fields {
+		if f.Verdict != VerdictMatch {
+			t.Fatalf("%s verdict = %s (%s)", f.Name, f.Verdict, f.Detail)
+		}
+	}
+}
+
+func TestCompareDifferentTitleIsMismatch(t *testing.T) {
+	work := kokkosWork
+	work.Title = "RAJA: Portable performance for large-scale scientific applications"
+	r := Compare(Citation{Text: kokkosEntry}, work)
+	if r.Verdict != VerdictMismatch {
+		t.Fatalf("got %s: %s", r.Verdict, r.Explain())
+	}
+	if f := fieldNamed(t, r, FieldTitle); f.Verdict != VerdictMismatch {
+		t.Fatalf("title verdict = %s", f.Verdict)
+	}
+}
+
+func TestCompareToleratesTypoAndDiacritics(t *testing.T) {
+	text := `J. Adrían and M. Müller. Scalabel solvers for sparse linear systems. SIAM J. Sci. Comput., 2019.`
+	work := Work{
+		Title:   "Scalable Solvers for Sparse Linear Systems",
+		Authors: []string{"Jose Adrian", "Markus Muller"},
+		Year:    2019,
+		Venue:   "SIAM Journal on Scientific Computing",
+	}
+	r := Compare(Citation{Text: text}, work)
+	if r.Verdict != VerdictMatch {
+		t.Fatalf("got %s: %s", r.Verdict, r.Explain())
+	}
+}
+
+func TestCompareAuthors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		text    string
+		verdict Verdict
+	}{
+		{"all in order", "Edwards, Trott, Sunderland", VerdictMatch},
+		{"et al.", "Edwards et al.", VerdictMatch},
+		{"et al. with two", "Edwards, Trott, et al.", VerdictMatch},
+		{"swapped", "Trott, Edwards, Sunderland", VerdictPartial},
+		{"missing one", "Edwards, Sunderland", VerdictPartial},
+		{"wrong first author", "Smith et al.", VerdictMismatch},
+		{"none", "Smith and Jones", VerdictMismatch},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := compareAuthors(Citation{Text: tc.text}, kokkosWork)
+			if f.Verdict != tc.verdict {
+				t.Fatalf("verdict = %s (%s), want %s", f.Verdict, f.Detail, tc.verdict)
+			}
+		})
+	}
+}
+
+func TestCompareStructuredAuthors(t *testing.T) {
+	f := compareAuthors(Citation{Authors: []string{"Edwards, H. C."}, EtAl: true}, kokkosWork)
+	if f.Verdict != VerdictMatch {
+		t.Fatalf("verdict = %s (%s)", f.Verdict, f.Detail)
+	}
+	f = compareAuthors(Citation{Authors: []string{"Edwards, H. C."}}, kokkosWork)
+	if f.Verdict != VerdictMismatch {
+		t.Fatalf("verdict = %s (%s)", f.Verdict, f.Detail)
+	}
+}
+
+func TestCompareYear(t *testing.T) {
+	for _, tc := range []struct {
+		text    string
+		verdict Verdict
+	}{
+		{"2014", VerdictMatch},
+		{"2015", VerdictPartial},
+		{"arXiv 2013, published 2014", VerdictMatch},
+		{"2010", VerdictMismatch},
+		{"no year here", VerdictMissing},
+	} {
+		if f := compareYear(Citation{Text: tc.text}, kokkosWork); f.Verdict != tc.verdict {
+			t.Errorf("%q: verdict = %s, want %s", tc.text, f.Verdict, tc.verdict)
+		}
+	}
+}
+
+func TestCompareVenue(t *testing.T) {
+	for _, tc := range []struct {
+		text    string
+		verdict Verdict
+	}{
+		{"Journal of Parallel and Distributed Computing", VerdictMatch},
+		{"J. Parallel Distrib. Comput.", VerdictMatch},
+		{"JPDC", VerdictMatch},
+		{"Parallel Computing", VerdictMismatch},
+	} {
+		if f := compareVenue(Citation{Text: tc.text}, kokkosWork); f.Verdict != tc.verdict {
+			t.Errorf("%q: verdict = %s, want %s", tc.text, f.Verdict, tc.verdict)
+		}
+	}
+}
+
+func TestCompareVenueAcronymWithNonASCIIInitials(t *testing.T) {
+	work := Work{Venue: "Økonomisk Årbok"}
+	if f := compareVenue(Citation{Venue: "ØÅ"}, work); f.Verdict != VerdictMatch {
+		t.Errorf("verdict = %s, want %s (%s)", f.Verdict, VerdictMatch, f.Detail)
+	}
+}
+
+func TestCompareSkipsMissingFields(t *testing.T) {
+	r := Compare(Citation{Text: kokkosEntry}, Work{Title: kokkosWork.Title})
+	if r.Verdict != VerdictMatch || r.Score != 1 {
+		t.Fatalf("got %s %.2f", r.Verdict, r.Score)
+	}
+	if f := fieldNamed(t, r, FieldVenue); f.Verdict != VerdictMissing {
+		t.Fatalf("venue verdict = %s", f.Verdict)
+	}
+
+	if r := Compare(Citation{Text: kokkosEntry}, Work{}); r.Verdict != VerdictMismatch {
+		t.Fatalf("empty work verdict = %s", r.Verdict)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	work := kokkosWork
+	work.Year = 2015
+	r := Compare(Citation{Text: kokkosEntry}, work)
+	r.Source = "Crossref"
+	got := r.Explain()
+	for _, want := range []string{"against Crossref", "year partial (cited 2014, found 2015)", "title match"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("%q missing %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package match
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	yearRe = regexp.MustCompile(`\b(1[5-9]\d\d|20\d\d)[a-z]?\b`)
+	etAlRe = regexp.MustCompile(`(?i)\bet\.?\s+al\b`)
+)
+
+// stopwords are ignored when building acronyms and abbreviations of venues.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "at": true, "for": true, "in": true,
+	"of": true, "on": true, "the": true, "to": true, "proceedings": true,
+}
+
+// Tokens folds s to lower-case ASCII-ish letters and digits and splits it
+// into words. Diacritics are removed so "Adrían" and "Adrian" compare equal.
+func Tokens(s string) []string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+	return strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Normalize returns the tokens of s joined by single spaces.
+func Normalize(s string) string {
+	return strings.Join(Tokens(s), " ")
+}
+
+// Surname returns the normalized family name of a "Given Family" or
+// "Family, Given" name.
+func Surname(name string) string {
+	if family, _, ok := strings.Cut(name, ","); ok && strings.TrimSpace(family) != "" {
+		name = family
+	}
+	words := Tokens(name)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// Years returns every plausible publication year in s.
+func Years(s string) []int {
+	years := []int{}
+	for _, m := range yearRe.FindAllStringSubmatch(s, -1) {
+		year, _ := strconv.Atoi(m[1])
+		years = append(years, year)
+	}
+	return years
+}
+
+// HasEtAl reports whether s abbreviates its author list with "et al.".
+func HasEtAl(s string) bool {
+	return etAlRe.MatchString(s)
+}
+
+// similarity is the Levenshtein ratio of two normalized strings, in [0, 1].
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	longest := max(len(ra), len(rb))
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+// significant drops stopwords from tokens.
+func significant(tokens []string) []string {
+	out := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if !stopwords[t] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// acronym returns the initials of the significant tokens, e.g. "ipdps" for
+// "International Parallel and Distributed Processing Symposium".
+func acronym(tokens []string) string {
+	var b strings.Builder
+	for _, t := range significant(tokens) {
+		r, _ := utf8.DecodeRuneInString(t)
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// abbreviates reports whether every token of short is a prefix of the
+// corresponding significant token of long, as in "Parallel Comput." for
+// "Parallel Computing".
+func abbreviates(short, long []string) bool {
+	short, long = significant(short), significant(long)
+	if len(short) == 0 || len(short) != len(long) {
+		return false
+	}
+	for i := range short {
+		if !strings.HasPrefix(long[i], short[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsWords reports whether the token sequence needle appears
+// contiguously in haystack.
+func containsWords(haystack, needle []string) bool {
+	if len(needle) == 0 {
+		return false
+	}
+	return strings.Contains(" "+strings.Join(haystack, " ")+" ", " "+strings.Join(needle, " ")+" ")
+}
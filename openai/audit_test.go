@@ -3,6 +3,7 @@
 package openai
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -34,7 +35,7 @@ func TestChatWritesReplayableAuditSet(t *testing.T) {
 		},
 	}
 
-	if _, err := client.Chat(req); err != nil {
+	if _, err := client.Chat(context.Background(), req); err != nil {
 		t.Fatalf("Chat() error = %v", err)
 	}
 
@@ -115,7 +116,7 @@ func TestChatWritesAuditSetForEveryRetryAttempt(t *testing.T) {
 	now := time.Date(2026, time.June, 11, 12, 34, 56, 0, time.Local)
 	client := newAuditTestClient(t, server.URL, "token", dir, func() time.Time { return now })
 
-	if _, err := client.Chat(&ChatRequest{Model: "test-model"}); err != nil {
+	if _, err := client.Chat(context.Background(), &ChatRequest{Model: "test-model"}); err != nil {
 		t.Fatalf("Chat() error = %v", err)
 	}
 
@@ -230,7 +231,7 @@ func TestChatDoesNotAuditDisabledOrPreflightFailures(t *testing.T) {
 		dir := t.TempDir()
 		client := newAuditTestClient(t, server.URL, "token", dir, time.Now)
 		client.audit.enabled = false
-		if _, err := client.Chat(&ChatRequest{}); err != nil {
+		if _, err := client.Chat(context.Background(), &ChatRequest{}); err != nil {
 			t.Fatalf("Chat() error = %v", err)
 		}
 		if files := auditFiles(t, dir, "*"); len(files) != 0 {
@@ -241,7 +242,7 @@ func TestChatDoesNotAuditDisabledOrPreflightFailures(t *testing.T) {
 	t.Run("marshal error", func(t *testing.T) {
 		dir := t.TempDir()
 		client := newAuditTestClient(t, "https://example.com", "token", dir, time.Now)
-		_, err := client.Chat(&ChatRequest{ResponseFormat: NewResponseFormat(func() {})})
+		_, err := client.Chat(context.Background(), &ChatRequest{ResponseFormat: NewResponseFormat(func() {})})
 		if err == nil {
 			t.Fatal("Chat() error = nil, want marshal error")
 		}
@@ -262,7 +263,7 @@ func TestChatContinuesWhenAuditWriteFails(t *testing.T) {
 		t.Fatal(err)
 	}
 	client := newAuditTestClient(t, server.URL, "token", dir, time.Now)
-	if _, err := client.Chat(&ChatRequest{}); err != nil {
+	if _, err := client.Chat(context.Background(), &ChatRequest{}); err != nil {
 		t.Fatalf("Chat() error = %v", err)
 	}
 }
@@ -275,6 +276,7 @@ func newAuditTestClient(t *testing.T, baseURL, apiKey, dir string, now func() ti
 	}
 	client := NewClient(apiKey, WithBaseUrl(baseURL))
 	client.audit = logger
+	client.cache = nil
 	return client
 }
 
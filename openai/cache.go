@@ -0,0 +1,85 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package openai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/sandialabs/bibcheck/config"
+)
+
+// responseCache stores successful chat responses on disk, keyed by the
+// endpoint and the marshalled request: model, messages, response format, and
+// temperature. A nil *responseCache caches nothing.
+type responseCache struct {
+	dir string
+}
+
+func newResponseCache(enabled bool, dir string) *responseCache {
+	if !enabled {
+		return nil
+	}
+	if dir == "" {
+		resolved, err := config.LLMCacheDir(config.Settings{})
+		if err != nil {
+			return nil
+		}
+		dir = resolved
+	}
+	return &responseCache{dir: dir}
+}
+
+func (c *responseCache) key(url string, request []byte) string {
+	h := sha256.New()
+	h.Write([]byte(url))
+	h.Write([]byte{0})
+	h.Write(request)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *responseCache) get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	body, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+func (c *responseCache) put(key string, body []byte) {
+	if c == nil {
+		return
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		log.Printf("openai cache: mkdir failed dir=%q err=%v", filepath.Dir(path), err)
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		log.Printf("openai cache: write failed path=%q err=%v", path, err)
+		return
+	}
+	_, err = tmp.Write(body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		log.Printf("openai cache: write failed path=%q err=%v", path, err)
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func (c *responseCache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
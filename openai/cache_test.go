@@ -0,0 +1,82 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newCacheTestServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"choices":[{"Message":{"role":"assistant","content":"first"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"Message":{"role":"assistant","content":"later"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func chatContent(t *testing.T, client *Client, req *ChatRequest) string {
+	t.Helper()
+	content, err := client.ChatGetChoiceZero(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestChatReusesCachedResponse(t *testing.T) {
+	server, hits := newCacheTestServer(t)
+	client := NewClient("key", WithBaseUrl(server.URL), WithAuditEnabled(false), WithResponseCacheDir(t.TempDir()))
+	req := &ChatRequest{
+		Model:       "test-model",
+		Messages:    []Message{MakeUserMessage("parse this entry")},
+		Temperature: Temperature(0),
+	}
+
+	if got := chatContent(t, client, req); got != "first" {
+		t.Fatalf("first response = %q", got)
+	}
+	if got := chatContent(t, client, req); got != "first" {
+		t.Fatalf("second response = %q, want cached %q", got, "first")
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("upstream requests = %d, want 1", hits.Load())
+	}
+
+	// any change to the request is a different key
+	for _, changed := range []*ChatRequest{
+		{Model: "other-model", Messages: req.Messages, Temperature: req.Temperature},
+		{Model: req.Model, Messages: req.Messages, Temperature: Temperature(0.7)},
+		{Model: req.Model, Messages: req.Messages, Temperature: req.Temperature, ResponseFormat: NewResponseFormat(map[string]any{"name": "x"})},
+		{Model: req.Model, Messages: []Message{MakeUserMessage("parse that entry")}, Temperature: req.Temperature},
+	} {
+		if got := chatContent(t, client, changed); got != "later" {
+			t.Fatalf("response to changed request = %q, want fresh answer", got)
+		}
+	}
+}
+
+func TestChatWithoutResponseCache(t *testing.T) {
+	server, hits := newCacheTestServer(t)
+	dir := t.TempDir()
+	client := NewClient("key", WithBaseUrl(server.URL), WithAuditEnabled(false), WithResponseCacheDir(dir), WithResponseCache(false))
+	req := &ChatRequest{Model: "test-model", Messages: []Message{MakeUserMessage("entry")}}
+
+	chatContent(t, client, req)
+	if got := chatContent(t, client, req); got != "later" {
+		t.Fatalf("second response = %q, want fresh answer", got)
+	}
+	if hits.Load() != 2 {
+		t.Fatalf("upstream requests = %d, want 2", hits.Load())
+	}
+}
@@ -74,15 +74,16 @@ func (r *ChatResponse) GetChoiceZero() ([]byte, error) {
 	return []byte(r.Choices[0].Message.Content), nil
 }
 
-func (c *Client) ChatGetChoiceZero(req *ChatRequest) ([]byte, error) {
-	resp, err := c.Chat(req)
+func (c *Client) ChatGetChoiceZero(ctx context.Context, req *ChatRequest) ([]byte, error) {
+	resp, err := c.Chat(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("openai error: %w", err)
 	}
 	return resp.GetChoiceZero()
 }
 
-func (c *Client) Chat(req *ChatRequest) (*ChatResponse, error) {
+// Chat sends req, retrying timeouts and retryable statuses until ctx is done.
+func (c *Client) Chat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
 	url := c.baseUrl + "/chat/completions"
 
 	// Marshal the request to JSON
@@ -92,12 +93,21 @@ func (c *Client) Chat(req *ChatRequest) (*ChatResponse, error) {
 	}
 	requestBytes := len(jsonData)
 
+	cacheKey := c.cache.key(url, jsonData)
+	if body, ok := c.cache.get(cacheKey); ok {
+		var chatResp ChatResponse
+		if err := json.Unmarshal(body, &chatResp); err == nil {
+			log.Printf("POST %s (%dB) answered from cache", url, requestBytes)
+			return &chatResp, nil
+		}
+	}
+
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		auditRecord := newAuditRecord(http.MethodPost, url, req, requestBytes, attempt+1)
 
 		// Create the HTTP request
 		log.Printf("POST %s (%dB)", url, requestBytes)
-		httpReq, err := http.NewRequest("POST", url, bytes.NewReader(jsonData))
+		httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonData))
 		if err != nil {
 			auditRecord.Outcome = "request_build_error"
 			auditRecord.Error = formatAuditError(err)
@@ -122,7 +132,7 @@ func (c *Client) Chat(req *ChatRequest) (*ChatResponse, error) {
 			}
 			auditRecord.Error = formatAuditError(err)
 
-			if isRetryableTimeout(err) && attempt < maxRetries {
+			if isRetryableTimeout(err) && ctx.Err() == nil && attempt < maxRetries {
 				waitFor := retryDelayForAttempt(attempt)
 				auditAttempt.finish(auditRecord)
 				log.Printf(
@@ -132,7 +142,9 @@ func (c *Client) Chat(req *ChatRequest) (*ChatResponse, error) {
 					maxRetries+1,
 					err,
 				)
-				time.Sleep(waitFor)
+				if err := sleepContext(ctx, waitFor); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			auditAttempt.finish(auditRecord)
@@ -165,6 +177,7 @@ func (c *Client) Chat(req *ChatRequest) (*ChatResponse, error) {
 			}
 			auditRecord.Outcome = "success"
 			auditAttempt.finish(auditRecord)
+			c.cache.put(cacheKey, body)
 			return &chatResp, nil
 		}
 
@@ -185,7 +198,9 @@ func (c *Client) Chat(req *ChatRequest) (*ChatResponse, error) {
 				maxRetries+1,
 				correlationLog,
 			)
-			time.Sleep(waitFor)
+			if err := sleepContext(ctx, waitFor); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
@@ -210,6 +225,18 @@ func Temperature(t float64) *float64 {
 	return &t
 }
 
+// sleepContext waits for d, returning early with ctx's error if it is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func retryDelayForAttempt(attempt int) time.Duration {
 	return time.Second << attempt
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/sandialabs/bibcheck/cassette"
 	"github.com/sandialabs/bibcheck/config"
 )
 
@@ -14,6 +15,7 @@ type Client struct {
 	baseUrl    string
 	httpClient *http.Client
 	audit      *auditLogger
+	cache      *responseCache
 }
 
 type ClientOpt func(*Client)
@@ -32,9 +34,11 @@ func NewClient(apiKey string, options ...ClientOpt) *Client {
 	c := &Client{
 		apiKey: apiKey,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: cassette.Transport(nil),
 		},
 		audit: audit,
+		cache: newResponseCache(settings.LLMCacheEnable && settings.Cassette == "", settings.LLMCacheDir),
 	}
 	for _, o := range options {
 		o(c)
@@ -54,6 +58,13 @@ func WithTimeout(t time.Duration) ClientOpt {
 	}
 }
 
+// WithTransport sends requests through t instead of http.DefaultTransport.
+func WithTransport(t http.RoundTripper) ClientOpt {
+	return func(c *Client) {
+		c.httpClient.Transport = cassette.Transport(t)
+	}
+}
+
 func WithAuditEnabled(enabled bool) ClientOpt {
 	return func(c *Client) {
 		if c.audit == nil {
@@ -83,6 +94,25 @@ func WithAuditDir(dir string) ClientOpt {
 	}
 }
 
+// WithResponseCache enables or disables reusing earlier responses to
+// identical requests.
+func WithResponseCache(enabled bool) ClientOpt {
+	return func(c *Client) {
+		if !enabled {
+			c.cache = nil
+		} else if c.cache == nil {
+			c.cache = newResponseCache(true, config.Runtime().LLMCacheDir)
+		}
+	}
+}
+
+// WithResponseCacheDir stores cached responses in dir.
+func WithResponseCacheDir(dir string) ClientOpt {
+	return func(c *Client) {
+		c.cache = newResponseCache(true, dir)
+	}
+}
+
 func (c *Client) auditEnabledOrDefault() bool {
 	if c.audit != nil {
 		return c.audit.enabled
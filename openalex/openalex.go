@@ -0,0 +1,259 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package openalex
+
+// https://docs.openalex.org/api-entities/works
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sandialabs/bibcheck/cassette"
+	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/httpcache"
+	"github.com/sandialabs/bibcheck/internal/wasmhttp"
+)
+
+const (
+	baseURL        = "https://api.openalex.org"
+	defaultTimeout = 30 * time.Second
+)
+
+var ErrDoesNotExist = errors.New("openalex work does not exist")
+
+// Client is a client for the OpenAlex works API.
+type Client struct {
+	httpClient *http.Client
+	cache      *httpcache.Cache
+	baseURL    string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the HTTP client used for upstream requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) { c.httpClient = client }
+}
+
+// WithTransport sends upstream requests through t instead of
+// http.DefaultTransport.
+func WithTransport(t http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Timeout: c.httpClient.Timeout, Transport: cassette.Transport(t)}
+	}
+}
+
+// WithCache replaces the response cache; nil disables caching.
+func WithCache(cache *httpcache.Cache) Option {
+	return func(c *Client) { c.cache = cache }
+}
+
+// WithBaseURL replaces the OpenAlex API base URL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimSuffix(baseURL, "/") }
+}
+
+// NewClient returns an OpenAlex client whose responses go through the
+// default HTTP cache.
+func NewClient(options ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{Timeout: defaultTimeout, Transport: cassette.Transport(nil)},
+		cache:      httpcache.Default(),
+		baseURL:    baseURL,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Work is an OpenAlex work. Only the fields bibcheck compares are decoded.
+type Work struct {
+	ID              string       `json:"id"`
+	DOI             string       `json:"doi"`
+	Title           string       `json:"title"`
+	DisplayName     string       `json:"display_name"`
+	PublicationYear int          `json:"publication_year"`
+	PublicationDate string       `json:"publication_date"`
+	Type            string       `json:"type"`
+	RelevanceScore  float64      `json:"relevance_score"`
+	Authorships     []Authorship `json:"authorships"`
+	PrimaryLocation *Location    `json:"primary_location"`
+}
+
+type Authorship struct {
+	Author struct {
+		DisplayName string `json:"display_name"`
+	} `json:"author"`
+}
+
+type Location struct {
+	Source *struct {
+		DisplayName string `json:"display_name"`
+	} `json:"source"`
+}
+
+// WorksResponse is the response to a works search.
+type WorksResponse struct {
+	Meta struct {
+		Count int `json:"count"`
+	} `json:"meta"`
+	Results []Work `json:"results"`
+}
+
+// TitleOrName returns the work's title, falling back to its display name.
+func (w *Work) TitleOrName() string {
+	if w.Title != "" {
+		return w.Title
+	}
+	return w.DisplayName
+}
+
+// Authors returns the display names of the work's authors in order.
+func (w *Work) Authors() []string {
+	names := make([]string, 0, len(w.Authorships))
+	for _, authorship := range w.Authorships {
+		if name := authorship.Author.DisplayName; name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Venue returns the name of the journal, proceedings or repository that
+// hosts the work's primary location.
+func (w *Work) Venue() string {
+	if w.PrimaryLocation == nil || w.PrimaryLocation.Source == nil {
+		return ""
+	}
+	return w.PrimaryLocation.Source.DisplayName
+}
+
+// BareDOI returns the work's DOI without the https://doi.org/ prefix.
+func (w *Work) BareDOI() string {
+	return strings.TrimPrefix(w.DOI, "https://doi.org/")
+}
+
+func (w *Work) ToString() string {
+	s := ""
+	if authors := w.Authors(); len(authors) > 0 {
+		s += strings.Join(authors, ", ") + ". "
+	}
+	if title := w.TitleOrName(); title != "" {
+		s += "\"" + title + "\". "
+	}
+	if venue := w.Venue(); venue != "" {
+		s += venue + ", "
+	}
+	if w.PublicationYear != 0 {
+		s += fmt.Sprintf("%d. ", w.PublicationYear)
+	}
+	if doi := w.BareDOI(); doi != "" {
+		s += "doi:" + doi + ". "
+	}
+	return s
+}
+
+// SearchWorks performs a full-text search of works for a title or
+// bibliographic string.
+func (c *Client) SearchWorks(ctx context.Context, query string, rows int) (*WorksResponse, error) {
+	params := url.Values{}
+	params.Set("search", query)
+	params.Set("per-page", fmt.Sprintf("%d", rows))
+	return c.searchWorks(ctx, params)
+}
+
+// SearchTitle searches for works whose title matches title.
+func (c *Client) SearchTitle(ctx context.Context, title string, rows int) (*WorksResponse, error) {
+	params := url.Values{}
+	// commas separate filters, so they can't appear in a filter value
+	params.Set("filter", "title.search:"+strings.ReplaceAll(title, ",", " "))
+	params.Set("per-page", fmt.Sprintf("%d", rows))
+	return c.searchWorks(ctx, params)
+}
+
+// GetByDOI retrieves the work registered for doi.
+func (c *Client) GetByDOI(ctx context.Context, doi string) (*Work, error) {
+	doi = strings.TrimPrefix(doi, "https://doi.org/")
+	doi = strings.TrimPrefix(doi, "http://doi.org/")
+	doi = strings.TrimPrefix(doi, "doi.org/")
+
+	endpoint := c.baseURL + "/works/doi:" + url.PathEscape(doi)
+	if email := config.UserEmail(); email != "" {
+		endpoint += "?" + url.Values{"mailto": {email}}.Encode()
+	}
+
+	body, err := c.get(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var work Work
+	if err := json.NewDecoder(body).Decode(&work); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &work, nil
+}
+
+func (c *Client) searchWorks(ctx context.Context, params url.Values) (*WorksResponse, error) {
+	if email := config.UserEmail(); email != "" {
+		params.Set("mailto", email) // puts us in the polite pool
+	}
+	body, err := c.get(ctx, c.baseURL+"/works?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var resp WorksResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &resp, nil
+}
+
+func (c *Client) get(ctx context.Context, endpoint string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", config.UserAgent())
+	wasmhttp.ConfigureRequest(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrDoesNotExist
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+	}
+	return resp.Body, nil
+}
+
+// do answers req from the cache, or else sends it and caches the response.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if resp, ok := c.cache.Lookup(req); ok {
+		return resp, nil
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.cache.Store(req, resp)
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package openalex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandialabs/bibcheck/httpcache"
+)
+
+const workJSON = `{
+	"id": "https://openalex.org/W1",
+	"doi": "https://doi.org/10.1016/j.parco.2018.05.006",
+	"title": "Hardware topology management in MPI applications through hierarchical communicators",
+	"publication_year": 2018,
+	"authorships": [
+		{"author": {"display_name": "Brice Goglin"}},
+		{"author": {"display_name": "Emmanuel Jeannot"}}
+	],
+	"primary_location": {"source": {"display_name": "Parallel Computing"}}
+}`
+
+func TestGetByDOI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/works/doi:10.1016/j.parco.2018.05.006" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(workJSON))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	work, err := client.GetByDOI(context.Background(), "https://doi.org/10.1016/j.parco.2018.05.006")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := work.BareDOI(); got != "10.1016/j.parco.2018.05.006" {
+		t.Fatalf("doi = %q", got)
+	}
+	if got := strings.Join(work.Authors(), ", "); got != "Brice Goglin, Emmanuel Jeannot" {
+		t.Fatalf("authors = %q", got)
+	}
+	if got := work.Venue(); got != "Parallel Computing" {
+		t.Fatalf("venue = %q", got)
+	}
+	if got := work.ToString(); !strings.Contains(got, "2018") || !strings.Contains(got, "Parallel Computing") {
+		t.Fatalf("ToString = %q", got)
+	}
+
+	if _, err := client.GetByDOI(context.Background(), "10.1234/missing"); !errors.Is(err, ErrDoesNotExist) {
+		t.Fatalf("missing DOI error = %v, want ErrDoesNotExist", err)
+	}
+}
+
+func TestSearchTitleUsesFilter(t *testing.T) {
+	var filter string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter = r.URL.Query().Get("filter")
+		_, _ = w.Write([]byte(`{"meta": {"count": 1}, "results": [` + workJSON + `]}`))
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(WithBaseURL(server.URL)).SearchTitle(context.Background(), "Kokkos, kernels", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter != "title.search:Kokkos  kernels" {
+		t.Fatalf("filter = %q", filter)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].PublicationYear != 2018 {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+}
+
+func TestGetByDOIUsesCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(workJSON))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithCache(httpcache.New(t.TempDir(), time.Hour)))
+	for range 2 {
+		if _, err := client.GetByDOI(context.Background(), "10.1016/j.parco.2018.05.006"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("upstream requests = %d, want 1", requests)
+	}
+}
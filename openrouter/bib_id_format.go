@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 
@@ -24,7 +25,7 @@ func NewBibIDFormatResponseFormat() *ResponseFormat {
 	}
 }
 
-func (c *Client) BibIdFormat(b *documents.Bibliography) (string, error) {
+func (c *Client) BibIdFormat(ctx context.Context, b *documents.Bibliography) (string, error) {
 	req := ChatRequest{
 		Model: "google/gemini-2.5-flash",
 		Messages: []Message{
@@ -45,7 +46,7 @@ func (c *Client) BibIdFormat(b *documents.Bibliography) (string, error) {
 	result := struct {
 		Format string `json:"id_format"`
 	}{}
-	if err := c.chatStructured(req, &result); err != nil {
+	if err := c.chatStructured(ctx, req, &result); err != nil {
 		return BibIdFormatUnknown, fmt.Errorf("BibIdFormat error: %w", err)
 	}
 
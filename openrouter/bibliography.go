@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"log"
@@ -19,15 +20,15 @@ func NewBibliographyPageResponseFormat() *ResponseFormat {
 	}
 }
 
-func (c *Client) PrepareBibliography(filePath string) (*documents.Bibliography, error) {
+func (c *Client) PrepareBibliography(ctx context.Context, filePath string) (*documents.Bibliography, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("read pdf error: %w", err)
 	}
-	return c.PrepareBibliographyContent(data)
+	return c.PrepareBibliographyContent(ctx, data)
 }
 
-func (c *Client) PrepareBibliographyContent(pdf []byte) (*documents.Bibliography, error) {
+func (c *Client) PrepareBibliographyContent(ctx context.Context, pdf []byte) (*documents.Bibliography, error) {
 	pageCount, err := documents.PDFPageCount(pdf)
 	if err != nil {
 		return nil, fmt.Errorf("pdf page count error: %w", err)
@@ -43,7 +44,7 @@ func (c *Client) PrepareBibliographyContent(pdf []byte) (*documents.Bibliography
 		if err != nil {
 			return nil, fmt.Errorf("slice page %d error: %w", page, err)
 		}
-		match, err := c.pageContainsBibliography(pagePDF)
+		match, err := c.pageContainsBibliography(ctx, pagePDF)
 		if err != nil {
 			return nil, fmt.Errorf("page %d bibliography classification error: %w", page, err)
 		}
@@ -81,7 +82,7 @@ func (c *Client) PrepareBibliographyContent(pdf []byte) (*documents.Bibliography
 	}, nil
 }
 
-func (c *Client) pageContainsBibliography(pagePDF []byte) (bool, error) {
+func (c *Client) pageContainsBibliography(ctx context.Context, pagePDF []byte) (bool, error) {
 	temperature := new(int)
 	*temperature = 0
 
@@ -97,7 +98,7 @@ func (c *Client) pageContainsBibliography(pagePDF []byte) (bool, error) {
 	result := struct {
 		ContainsBibliography bool `json:"contains_bibliography"`
 	}{}
-	if err := c.chatStructured(req, &result); err != nil {
+	if err := c.chatStructured(ctx, req, &result); err != nil {
 		return false, fmt.Errorf("pageContainsBibliography error: %w", err)
 	}
 
@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -18,7 +19,7 @@ func NewClassifyEntryResponseFormat() *ResponseFormat {
 	}
 }
 
-func (c *Client) Classify(text string) (string, error) {
+func (c *Client) Classify(ctx context.Context, text string) (string, error) {
 	baseURL := c.baseUrl
 	model := "google/gemini-2.5-flash"
 
@@ -45,7 +46,7 @@ Produce JSON.`),
 		Temperature: temperature,
 	}
 
-	resp, err := c.ChatCompletion(req, baseURL)
+	resp, err := c.ChatCompletion(ctx, req, baseURL)
 	if err != nil {
 		return entries.KindUnknown, fmt.Errorf("chat completion error: %w", err)
 	}
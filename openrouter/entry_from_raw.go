@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 
@@ -17,21 +18,21 @@ func NewBibEntryTextResponseFormat() *ResponseFormat {
 	}
 }
 
-func (c *Client) EntryFromRaw(b64 string, i int) (string, error) {
+func (c *Client) EntryFromRaw(ctx context.Context, b64 string, i string) (string, error) {
 	raw, err := base64.StdEncoding.DecodeString(b64)
 	if err != nil {
 		return "", fmt.Errorf("decode base64 pdf error: %w", err)
 	}
 
-	bibliography, err := c.PrepareBibliographyContent(raw)
+	bibliography, err := c.PrepareBibliographyContent(ctx, raw)
 	if err != nil {
 		return "", fmt.Errorf("prepare bibliography error: %w", err)
 	}
 
-	return c.EntryFromBibliography(bibliography, i)
+	return c.EntryFromBibliography(ctx, bibliography, i)
 }
 
-func (c *Client) EntryFromBibliography(b *documents.Bibliography, i int) (string, error) {
+func (c *Client) EntryFromBibliography(ctx context.Context, b *documents.Bibliography, i string) (string, error) {
 
 	req := makeLlama3170BChatRequest(
 		NewBibEntryTextResponseFormat(),
@@ -43,14 +44,14 @@ If so, extract ONLY THAT ENTRY from the bibliography of the provided document.
 - Preserve any errors in the entry.
 - Omit the inline reference ID that the document uses, e.g. [1] or [Smith1997].
 Produce JSON.`),
-		userStringAndBase64File(fmt.Sprintf("Extract bibliography entry %d", i), base64.StdEncoding.EncodeToString(b.PDF)),
+		userStringAndBase64File(fmt.Sprintf("Extract bibliography entry %s", i), base64.StdEncoding.EncodeToString(b.PDF)),
 	)
 
 	result := struct {
 		EntryExists       bool   `json:"entry_exists"`
 		BibliographyEntry string `json:"bibliography_entry"`
 	}{}
-	if err := c.chatStructured(req, &result); err != nil {
+	if err := c.chatStructured(ctx, req, &result); err != nil {
 		return "", fmt.Errorf("EntryFromBibliography error: %w", err)
 	}
 
@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 
@@ -22,7 +23,7 @@ func NewExtractBibResponseFormat() *ResponseFormat {
 	}
 }
 
-func (c *Client) ExtractBib(b *documents.Bibliography) ([]Entry, error) {
+func (c *Client) ExtractBib(ctx context.Context, b *documents.Bibliography) ([]Entry, error) {
 	req := ChatRequest{
 		Model: "google/gemini-2.5-pro",
 		Messages: []Message{
@@ -43,7 +44,7 @@ func (c *Client) ExtractBib(b *documents.Bibliography) ([]Entry, error) {
 	}
 
 	entries := []Entry{}
-	if err := c.chatStructured(req, &entries); err != nil {
+	if err := c.chatStructured(ctx, req, &entries); err != nil {
 		return nil, fmt.Errorf("ExtractBib error: %w", err)
 	}
 
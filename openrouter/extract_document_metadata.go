@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -60,23 +61,23 @@ func htmlRequest(model, html string) *ChatRequest {
 	}
 }
 
-func (c *Client) HTMLMetadata(html []byte) (*documents.Metadata, error) {
+func (c *Client) HTMLMetadata(ctx context.Context, html []byte) (*documents.Metadata, error) {
 	model := "google/gemini-2.5-flash"
 	// model := "mistralai/mistral-medium-3"
-	return c.extractDocumentMetadataImpl(htmlRequest(model, string(html)))
+	return c.extractDocumentMetadataImpl(ctx, htmlRequest(model, string(html)))
 }
 
-func (c *Client) PDFMetadata(raw []byte) (*documents.Metadata, error) {
+func (c *Client) PDFMetadata(ctx context.Context, raw []byte) (*documents.Metadata, error) {
 	model := "google/gemini-2.5-flash"
 	// model := "mistralai/mistral-medium-3"
 	encoded := base64.StdEncoding.EncodeToString(raw)
-	return c.extractDocumentMetadataImpl(encodedPdfRequest(model, encoded))
+	return c.extractDocumentMetadataImpl(ctx, encodedPdfRequest(model, encoded))
 }
 
-func (c *Client) extractDocumentMetadataImpl(req *ChatRequest) (*documents.Metadata, error) {
+func (c *Client) extractDocumentMetadataImpl(ctx context.Context, req *ChatRequest) (*documents.Metadata, error) {
 	baseURL := c.baseUrl
 
-	resp, err := c.ChatCompletion(*req, baseURL)
+	resp, err := c.ChatCompletion(ctx, *req, baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("chat completion error: %w", err)
 	}
@@ -102,7 +103,7 @@ func (c *Client) extractDocumentMetadataImpl(req *ChatRequest) (*documents.Metad
 	return nil, fmt.Errorf("content was not string")
 }
 
-func (c *Client) ExtractDocumentMetadata(encoded string) (*documents.Metadata, error) {
+func (c *Client) ExtractDocumentMetadata(ctx context.Context, encoded string) (*documents.Metadata, error) {
 	baseURL := c.baseUrl
 	model := "google/gemini-2.5-flash"
 
@@ -123,7 +124,7 @@ Provide an empty value for any information not present.
 		},
 	}
 
-	resp, err := c.ChatCompletion(req, baseURL)
+	resp, err := c.ChatCompletion(ctx, req, baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("chat completion error: %w", err)
 	}
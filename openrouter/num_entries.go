@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 
@@ -17,21 +18,21 @@ func NewNumEntriesResponseFormat() *ResponseFormat {
 	}
 }
 
-func (c *Client) NumEntries(b64 string) (int, error) {
+func (c *Client) NumEntries(ctx context.Context, b64 string) (int, error) {
 	raw, err := base64.StdEncoding.DecodeString(b64)
 	if err != nil {
 		return -1, fmt.Errorf("decode base64 pdf error: %w", err)
 	}
 
-	bibliography, err := c.PrepareBibliographyContent(raw)
+	bibliography, err := c.PrepareBibliographyContent(ctx, raw)
 	if err != nil {
 		return -1, fmt.Errorf("prepare bibliography error: %w", err)
 	}
 
-	return c.NumBibliographyEntries(bibliography)
+	return c.NumBibliographyEntries(ctx, bibliography)
 }
 
-func (c *Client) NumBibliographyEntries(b *documents.Bibliography) (int, error) {
+func (c *Client) NumBibliographyEntries(ctx context.Context, b *documents.Bibliography) (int, error) {
 	req := ChatRequest{
 		Model: "google/gemini-2.5-flash",
 		Messages: []Message{
@@ -51,7 +52,7 @@ func (c *Client) NumBibliographyEntries(b *documents.Bibliography) (int, error)
 	result := struct {
 		NumEntries int `json:"num_entries"`
 	}{}
-	if err := c.chatStructured(req, &result); err != nil {
+	if err := c.chatStructured(ctx, req, &result); err != nil {
 		return -1, fmt.Errorf("NumBibliographyEntries error: %w", err)
 	}
 
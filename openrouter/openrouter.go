@@ -4,12 +4,14 @@ package openrouter
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	"github.com/sandialabs/bibcheck/cassette"
 	"github.com/sandialabs/bibcheck/internal/wasmhttp"
 )
 
@@ -35,7 +37,8 @@ func NewClient(apiKey string, options ...Opt) *Client {
 		apiKey:  apiKey,
 		baseUrl: "https://openrouter.ai/api/v1",
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: cassette.Transport(nil),
 		},
 	}
 	for _, o := range options {
@@ -50,6 +53,13 @@ func WithBaseURL(baseURL string) Opt {
 	}
 }
 
+// WithTransport sends requests through t instead of http.DefaultTransport.
+func WithTransport(t http.RoundTripper) Opt {
+	return func(c *Client) {
+		c.httpClient.Transport = cassette.Transport(t)
+	}
+}
+
 type TextContent struct {
 	Type string `json:"type"`
 	Text string `json:"text"`
@@ -222,8 +232,8 @@ func choiceZeroString(resp *ChatResponse) (string, error) {
 	return cstring, nil
 }
 
-func (c *Client) chatStructured(req ChatRequest, dest any) error {
-	resp, err := c.ChatCompletion(req, c.baseUrl)
+func (c *Client) chatStructured(ctx context.Context, req ChatRequest, dest any) error {
+	resp, err := c.ChatCompletion(ctx, req, c.baseUrl)
 	if err != nil {
 		return fmt.Errorf("chat completion error: %w", err)
 	}
@@ -241,7 +251,7 @@ func (c *Client) chatStructured(req ChatRequest, dest any) error {
 }
 
 // ChatCompletion sends a chat completion request
-func (c *Client) ChatCompletion(req ChatRequest, baseURL string) (*ChatResponse, error) {
+func (c *Client) ChatCompletion(ctx context.Context, req ChatRequest, baseURL string) (*ChatResponse, error) {
 	if req.Reasoning != nil {
 		if req.Reasoning.Effort != "" && req.Reasoning.MaxTokens != nil {
 			return nil, fmt.Errorf("reasoning.effort and reasoning.max_tokens are mutually exclusive")
@@ -260,7 +270,7 @@ func (c *Client) ChatCompletion(req ChatRequest, baseURL string) (*ChatResponse,
 	// log.Println(string(jsonData))
 
 	// Create HTTP request
-	httpReq, err := http.NewRequest("POST", baseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/chat/completions", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
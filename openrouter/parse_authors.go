@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/sandialabs/bibcheck/entries"
@@ -16,7 +17,7 @@ func NewParseAuthorsRF() *ResponseFormat {
 	}
 }
 
-func (c *Client) ParseAuthors(text string) (*entries.Authors, error) {
+func (c *Client) ParseAuthors(ctx context.Context, text string) (*entries.Authors, error) {
 	model := "google/gemini-2.5-flash"
 	temperature := new(int)
 	*temperature = 0
@@ -40,7 +41,7 @@ func (c *Client) ParseAuthors(text string) (*entries.Authors, error) {
 	}
 
 	authors := entries.Authors{}
-	if err := c.chatStructured(req, &authors); err != nil {
+	if err := c.chatStructured(ctx, req, &authors); err != nil {
 		return nil, fmt.Errorf("ParseAuthors error: %w", err)
 	}
 
@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/sandialabs/bibcheck/schema"
@@ -16,7 +17,7 @@ func NewParsePubRF() *ResponseFormat {
 }
 
 // ParsePub returns the title of a journal or a book from a bibliography entry
-func (c *Client) ParsePub(text string) (string, error) {
+func (c *Client) ParsePub(ctx context.Context, text string) (string, error) {
 	model := "google/gemini-2.5-flash"
 	temperature := new(int)
 	*temperature = 0
@@ -42,7 +43,7 @@ func (c *Client) ParsePub(text string) (string, error) {
 	result := struct {
 		Title string `json:"title"`
 	}{}
-	if err := c.chatStructured(req, &result); err != nil {
+	if err := c.chatStructured(ctx, req, &result); err != nil {
 		return "", fmt.Errorf("ParsePub error: %w", err)
 	}
 
@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/sandialabs/bibcheck/schema"
@@ -15,7 +16,7 @@ func NewParseTitleRF() *ResponseFormat {
 	}
 }
 
-func (c *Client) ParseTitle(text string) (string, error) {
+func (c *Client) ParseTitle(ctx context.Context, text string) (string, error) {
 	model := "google/gemini-2.5-flash"
 	temperature := new(int)
 	*temperature = 0
@@ -40,7 +41,7 @@ func (c *Client) ParseTitle(text string) (string, error) {
 	result := struct {
 		Title string `json:"title"`
 	}{}
-	if err := c.chatStructured(req, &result); err != nil {
+	if err := c.chatStructured(ctx, req, &result); err != nil {
 		return "", fmt.Errorf("ParseTitle error: %w", err)
 	}
 
@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -16,7 +17,7 @@ func NewParseURLRF() *ResponseFormat {
 	}
 }
 
-func (c *Client) ParseURL(text string) (string, error) {
+func (c *Client) ParseURL(ctx context.Context, text string) (string, error) {
 	baseURL := c.baseUrl
 	model := "google/gemini-2.5-flash"
 
@@ -37,7 +38,7 @@ Produce JSON.
 		},
 	}
 
-	resp, err := c.ChatCompletion(req, baseURL)
+	resp, err := c.ChatCompletion(ctx, req, baseURL)
 	if err != nil {
 		return "", fmt.Errorf("chat completion error: %w", err)
 	}
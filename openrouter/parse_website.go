@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -17,7 +18,7 @@ func NewParseOnlineRF() *ResponseFormat {
 	}
 }
 
-func (c *Client) ParseOnline(text string) (*entries.Online, error) {
+func (c *Client) ParseOnline(ctx context.Context, text string) (*entries.Online, error) {
 	baseURL := c.baseUrl
 	model := "google/gemini-2.5-flash"
 
@@ -36,7 +37,7 @@ Produce JSON.`),
 		},
 	}
 
-	resp, err := c.ChatCompletion(req, baseURL)
+	resp, err := c.ChatCompletion(ctx, req, baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("chat completion error: %w", err)
 	}
@@ -3,11 +3,12 @@
 package openrouter
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
 
-func (c *Client) SearchEntry(text string) (bool, string, error) {
+func (c *Client) SearchEntry(ctx context.Context, text string) (bool, string, error) {
 	baseURL := c.baseUrl
 	// model := "perplexity/sonar"
 	model := "perplexity/sonar-pro"
@@ -30,7 +31,7 @@ The user DOES NOT WANT a summary of search results, NOR of the cited work if it
 		},
 	}
 
-	resp, err := c.ChatCompletion(req, baseURL)
+	resp, err := c.ChatCompletion(ctx, req, baseURL)
 	if err != nil {
 		return false, "", fmt.Errorf("chat completion error: %w", err)
 	}
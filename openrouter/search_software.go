@@ -3,13 +3,14 @@
 package openrouter
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/sandialabs/bibcheck/entries"
 )
 
-func (c *Client) SearchSoftware(software *entries.Software) (bool, string, error) {
+func (c *Client) SearchSoftware(ctx context.Context, software *entries.Software) (bool, string, error) {
 	baseURL := c.baseUrl
 	// model := "perplexity/sonar"
 	model := "perplexity/sonar-pro"
@@ -31,7 +32,7 @@ DO NOT SUMMARIZE THE SEARCH RESULTS.`),
 		},
 	}
 
-	resp, err := c.ChatCompletion(req, baseURL)
+	resp, err := c.ChatCompletion(ctx, req, baseURL)
 	if err != nil {
 		return false, "", fmt.Errorf("chat completion error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package openrouter
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -24,27 +25,8 @@ const (
 )
 
 // Summarize returns (mismatch, comment, error).
-func (c *Client) Summarize(lr *lookup.Result) (bool, string, error) {
-	searchResults := []string{}
-	if lr.Arxiv.Entry != nil {
-		searchResults = append(searchResults, lr.Arxiv.Entry.ToString())
-	}
-	if lr.Crossref.Work != nil {
-		searchResults = append(searchResults, lr.Crossref.Work.ToString())
-	}
-	if lr.DOIOrg.Found {
-		searchResults = append(searchResults, "<DOI from bibliography entry exists, no metadata provided.>")
-	}
-	if lr.Elsevier.Result != nil {
-		searchResults = append(searchResults, lr.Elsevier.Result.ToString())
-	}
-	if lr.Online.Metadata != nil {
-		searchResults = append(searchResults, lr.Online.Metadata.ToString())
-	}
-	if lr.OSTI.Record != nil {
-		searchResults = append(searchResults, lr.OSTI.Record.ToString())
-	}
-
+func (c *Client) Summarize(ctx context.Context, lr *lookup.Result) (bool, string, error) {
+	searchResults := lr.Evidence()
 	if len(searchResults) == 0 {
 		log.Printf("No search results to summarize")
 		return true, "insufficient search result data", nil
@@ -79,7 +61,7 @@ func (c *Client) Summarize(lr *lookup.Result) (bool, string, error) {
 		Temperature: temperature,
 	}
 
-	if err := c.chatStructured(req, &result); err != nil {
+	if err := c.chatStructured(ctx, req, &result); err != nil {
 		return false, "", fmt.Errorf("chat completion error: %w", err)
 	}
 
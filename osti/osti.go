@@ -3,6 +3,7 @@
 package osti
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/sandialabs/bibcheck/config"
+	"github.com/sandialabs/bibcheck/httpcache"
 	"github.com/sandialabs/bibcheck/internal/wasmhttp"
 )
 
@@ -27,6 +29,8 @@ const (
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	transport  http.RoundTripper
+	cache      *httpcache.Cache
 }
 
 // Record represents an OSTI record
@@ -77,33 +81,48 @@ type RecordsResponse struct {
 	PerPage int      `json:"per_page"`
 }
 
+// Option configures a Client.
+type Option func(*Client)
+
+// WithTransport sends requests the cache cannot answer through t instead of
+// http.DefaultTransport.
+func WithTransport(t http.RoundTripper) Option {
+	return func(c *Client) { c.transport = t }
+}
+
+// WithCache replaces the response cache; nil disables caching.
+func WithCache(cache *httpcache.Cache) Option {
+	return func(c *Client) { c.cache = cache }
+}
+
 // NewClient creates a new OSTI API client
-func NewClient() *Client {
-	return &Client{
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-		},
+func NewClient(options ...Option) *Client {
+	c := &Client{
 		baseURL: baseURL,
+		cache:   httpcache.Default(),
 	}
+	for _, option := range options {
+		option(c)
+	}
+	c.httpClient = c.cache.Client(defaultTimeout, c.transport)
+	return c
 }
 
 // NewClientWithTimeout creates a new OSTI API client with custom timeout
 func NewClientWithTimeout(timeout time.Duration) *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
-		baseURL: baseURL,
+		httpClient: httpcache.NewClient(timeout),
+		baseURL:    baseURL,
 	}
 }
 
 // GetRecord retrieves a specific OSTI record by ID
-func (c *Client) GetRecord(ostiID string) (*Record, error) {
+func (c *Client) GetRecord(ctx context.Context, ostiID string) (*Record, error) {
 
 	endpoint := fmt.Sprintf("%s/records/%s", c.baseURL, ostiID)
 	log.Printf("retrieve OSTI record %s: %s", ostiID, endpoint)
 
-	req, err := http.NewRequest("GET", wasmhttp.FetchURL(endpoint), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", wasmhttp.FetchURL(endpoint), nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -147,7 +166,7 @@ type ListRecordsOptions struct {
 }
 
 // ListRecords retrieves a list of OSTI records
-func (c *Client) ListRecords(opts *ListRecordsOptions) (*RecordsResponse, error) {
+func (c *Client) ListRecords(ctx context.Context, opts *ListRecordsOptions) (*RecordsResponse, error) {
 	endpoint := fmt.Sprintf("%s/records", c.baseURL)
 
 	// Build query parameters
@@ -168,7 +187,7 @@ func (c *Client) ListRecords(opts *ListRecordsOptions) (*RecordsResponse, error)
 		endpoint = fmt.Sprintf("%s?%s", endpoint, params.Encode())
 	}
 
-	req, err := http.NewRequest("GET", wasmhttp.FetchURL(endpoint), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", wasmhttp.FetchURL(endpoint), nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -197,15 +216,15 @@ func (c *Client) ListRecords(opts *ListRecordsOptions) (*RecordsResponse, error)
 }
 
 // SearchRecords performs a search query and returns matching records
-func (c *Client) SearchRecords(query string) (*RecordsResponse, error) {
-	return c.ListRecords(&ListRecordsOptions{
+func (c *Client) SearchRecords(ctx context.Context, query string) (*RecordsResponse, error) {
+	return c.ListRecords(ctx, &ListRecordsOptions{
 		Query: query,
 	})
 }
 
 // GetRecordsByPage retrieves records with pagination
-func (c *Client) GetRecordsByPage(page, perPage int) (*RecordsResponse, error) {
-	return c.ListRecords(&ListRecordsOptions{
+func (c *Client) GetRecordsByPage(ctx context.Context, page, perPage int) (*RecordsResponse, error) {
+	return c.ListRecords(ctx, &ListRecordsOptions{
 		Page:    page,
 		PerPage: perPage,
 	})
@@ -0,0 +1,171 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+
+// Package retraction identifies cited works that have been retracted,
+// withdrawn or corrected, from Crossref update metadata and from the
+// Retraction Watch database.
+package retraction
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sandialabs/bibcheck/crossref"
+)
+
+type Kind string
+
+const (
+	KindRetraction          Kind = "retraction"
+	KindWithdrawal          Kind = "withdrawal"
+	KindCorrection          Kind = "correction"
+	KindExpressionOfConcern Kind = "expression-of-concern"
+)
+
+const (
+	SourceCrossref        = "Crossref"
+	SourceRetractionWatch = "Retraction Watch"
+)
+
+// Notice is a published notice that updates a cited work.
+type Notice struct {
+	Kind Kind
+	// DOI is the DOI of the notice, if known.
+	DOI string
+	// Date is when the notice was published, as reported by its source.
+	Date   string
+	Source string
+	Reason string
+}
+
+// Retracts reports whether the notice means the work should not be cited.
+func (n Notice) Retracts() bool {
+	return n.Kind == KindRetraction || n.Kind == KindWithdrawal
+}
+
+func (n Notice) String() string {
+	details := []string{}
+	if n.Date != "" {
+		details = append(details, n.Date)
+	}
+	if n.DOI != "" {
+		details = append(details, "doi:"+n.DOI)
+	}
+	if n.Source != "" {
+		details = append(details, n.Source)
+	}
+	s := kindLabel(n.Kind)
+	if len(details) > 0 {
+		s += " (" + strings.Join(details, ", ") + ")"
+	}
+	if n.Reason != "" {
+		s += ": " + n.Reason
+	}
+	return s
+}
+
+func kindLabel(kind Kind) string {
+	switch kind {
+	case KindExpressionOfConcern:
+		return "Expression of concern"
+	case "":
+		return "Update"
+	default:
+		return strings.ToUpper(string(kind[:1])) + string(kind[1:])
+	}
+}
+
+// ParseKind maps the update types used by Crossref and Retraction Watch to a
+// Kind. It returns "" for updates that do not affect whether a work can be
+// cited, such as new versions, addenda and reinstatements.
+func ParseKind(s string) Kind {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case strings.Contains(s, "reinstat"):
+		return ""
+	case strings.Contains(s, "partial"):
+		// a partial retraction leaves the rest of the work standing
+		return KindCorrection
+	case strings.Contains(s, "retract"):
+		return KindRetraction
+	case strings.Contains(s, "withdraw"), strings.Contains(s, "removal"):
+		return KindWithdrawal
+	case strings.Contains(s, "concern"):
+		return KindExpressionOfConcern
+	case strings.Contains(s, "correct"), strings.Contains(s, "erratum"), strings.Contains(s, "corrigendum"):
+		return KindCorrection
+	default:
+		return ""
+	}
+}
+
+// FromCrossref converts Crossref updates to notices, dropping those that do
+// not affect whether the work can be cited.
+func FromCrossref(updates []crossref.Update) []Notice {
+	notices := []Notice{}
+	for _, update := range updates {
+		kind := ParseKind(update.Type)
+		if kind == "" {
+			kind = ParseKind(update.Label)
+		}
+		if kind == "" {
+			continue
+		}
+		source := SourceCrossref
+		if strings.EqualFold(update.Source, "retraction-watch") {
+			source = SourceRetractionWatch
+		}
+		notices = append(notices, Notice{
+			Kind:   kind,
+			DOI:    NormalizeDOI(update.DOI),
+			Date:   update.Date(),
+			Source: source,
+		})
+	}
+	return notices
+}
+
+// Merge combines notices from several sources. A notice reported by more
+// than one source is kept once, with the first non-empty value of each field.
+func Merge(lists ...[]Notice) []Notice {
+	merged := []Notice{}
+	index := map[string]int{}
+	for _, list := range lists {
+		for _, notice := range list {
+			key := fmt.Sprintf("%s %s", notice.Kind, notice.DOI)
+			i, ok := index[key]
+			if !ok || notice.DOI == "" {
+				index[key] = len(merged)
+				merged = append(merged, notice)
+				continue
+			}
+			existing := &merged[i]
+			if existing.Date == "" {
+				existing.Date = notice.Date
+			}
+			if existing.Reason == "" {
+				existing.Reason = notice.Reason
+			}
+		}
+	}
+	return merged
+}
+
+// Retracted reports whether any notice retracts or withdraws the work.
+func Retracted(notices []Notice) bool {
+	for _, notice := range notices {
+		if notice.Retracts() {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeDOI returns doi in lower case, without a resolver prefix.
+func NormalizeDOI(doi string) string {
+	doi = strings.ToLower(strings.TrimSpace(doi))
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi.org/", "doi:"} {
+		doi = strings.TrimPrefix(doi, prefix)
+	}
+	return strings.TrimSpace(doi)
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package retraction
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sandialabs/bibcheck/crossref"
+)
+
+const watchCSV = "\ufeffRecord ID,Title,RetractionDate,RetractionDOI,OriginalPaperDOI,RetractionNature,Reason\n" +
+	"1,A retracted paper,3/14/2023 0:00,10.1000/notice.1,10.1000/Paper.1,Retraction,+Error in Data;+Duplication of Image;\n" +
+	"2,A corrected paper,1/2/2020 0:00,unavailable,https://doi.org/10.1000/paper.2,Correction,+Error in Text;\n" +
+	"3,A reinstated paper,1/2/2021 0:00,10.1000/notice.3,10.1000/paper.3,Reinstatement,\n" +
+	"4,No DOI,1/2/2021 0:00,10.1000/notice.4,unavailable,Retraction,\n"
+
+func TestParseWatch(t *testing.T) {
+	w, err := ParseWatch(strings.NewReader(watchCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", w.Len())
+	}
+
+	notices := w.Lookup("https://doi.org/10.1000/paper.1")
+	if len(notices) != 1 {
+		t.Fatalf("notices = %+v", notices)
+	}
+	want := Notice{
+		Kind:   KindRetraction,
+		DOI:    "10.1000/notice.1",
+		Date:   "3/14/2023",
+		Source: SourceRetractionWatch,
+		Reason: "Error in Data; Duplication of Image",
+	}
+	if notices[0] != want {
+		t.Fatalf("notice = %+v, want %+v", notices[0], want)
+	}
+	if !Retracted(notices) {
+		t.Fatal("expected a retraction")
+	}
+
+	corrected := w.Lookup("10.1000/PAPER.2")
+	if len(corrected) != 1 || corrected[0].Kind != KindCorrection || corrected[0].DOI != "" || Retracted(corrected) {
+		t.Fatalf("corrected = %+v", corrected)
+	}
+	if got := w.Lookup("10.1000/paper.3"); got != nil {
+		t.Fatalf("reinstatement reported as %+v", got)
+	}
+}
+
+func TestParseWatchRequiresColumns(t *testing.T) {
+	if _, err := ParseWatch(strings.NewReader("Title,Reason\nx,y\n")); err == nil {
+		t.Fatal("expected an error for a csv without DOI columns")
+	}
+	var w *Watch
+	if w.Lookup("10.1000/paper.1") != nil || w.Len() != 0 {
+		t.Fatal("nil Watch should know no notices")
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	for input, want := range map[string]Kind{
+		"retraction":            KindRetraction,
+		"Retraction":            KindRetraction,
+		"partial_retraction":    KindCorrection,
+		"withdrawal":            KindWithdrawal,
+		"removal":               KindWithdrawal,
+		"expression_of_concern": KindExpressionOfConcern,
+		"Expression of concern": KindExpressionOfConcern,
+		"correction":            KindCorrection,
+		"erratum":               KindCorrection,
+		"corrigendum":           KindCorrection,
+		"new_version":           "",
+		"Reinstatement":         "",
+	} {
+		if got := ParseKind(input); got != want {
+			t.Errorf("ParseKind(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestMergeCombinesSources(t *testing.T) {
+	update := crossref.Update{DOI: "10.1000/NOTICE.1", Type: "retraction", Source: "retraction-watch"}
+	update.Updated.DateParts = [][]int{{2023, 3, 14}}
+	fromCrossref := FromCrossref([]crossref.Update{update, {DOI: "10.1000/v2", Type: "new_version"}})
+	if len(fromCrossref) != 1 || fromCrossref[0].Date != "2023-03-14" || fromCrossref[0].Source != SourceRetractionWatch {
+		t.Fatalf("FromCrossref = %+v", fromCrossref)
+	}
+
+	watched := []Notice{{Kind: KindRetraction, DOI: "10.1000/notice.1", Source: SourceRetractionWatch, Reason: "Error in Data"}}
+	merged := Merge(watched, fromCrossref)
+	if len(merged) != 1 {
+		t.Fatalf("merged = %+v", merged)
+	}
+	if merged[0].Reason != "Error in Data" || merged[0].Date != "2023-03-14" {
+		t.Fatalf("merged notice = %+v", merged[0])
+	}
+	if got := merged[0].String(); got != "Retraction (2023-03-14, doi:10.1000/notice.1, Retraction Watch): Error in Data" {
+		t.Fatalf("String() = %q", got)
+	}
+}
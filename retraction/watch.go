@@ -0,0 +1,131 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package retraction
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Watch is a local copy of the Retraction Watch database, indexed by the DOI
+// of the original paper. A nil *Watch knows no notices.
+type Watch struct {
+	notices map[string][]Notice
+}
+
+// LoadWatch reads a Retraction Watch CSV export from path.
+func LoadWatch(path string) (*Watch, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open retraction watch csv: %w", err)
+	}
+	defer f.Close()
+	w, err := ParseWatch(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return w, nil
+}
+
+// ParseWatch reads a Retraction Watch CSV export. Only the OriginalPaperDOI
+// and RetractionNature columns are required; RetractionDOI, RetractionDate
+// and Reason are used when present.
+func ParseWatch(r io.Reader) (*Watch, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty retraction watch csv")
+	} else if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"originalpaperdoi", "retractionnature"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("retraction watch csv has no %s column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	w := &Watch{notices: map[string][]Notice{}}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("read record: %w", err)
+		}
+		doi := watchDOI(field(record, "originalpaperdoi"))
+		kind := ParseKind(field(record, "retractionnature"))
+		if doi == "" || kind == "" {
+			continue
+		}
+		w.notices[doi] = append(w.notices[doi], Notice{
+			Kind:   kind,
+			DOI:    watchDOI(field(record, "retractiondoi")),
+			Date:   watchDate(field(record, "retractiondate")),
+			Source: SourceRetractionWatch,
+			Reason: watchReason(field(record, "reason")),
+		})
+	}
+	return w, nil
+}
+
+// Len returns the number of works with notices.
+func (w *Watch) Len() int {
+	if w == nil {
+		return 0
+	}
+	return len(w.notices)
+}
+
+// Lookup returns the notices for the work with the given DOI.
+func (w *Watch) Lookup(doi string) []Notice {
+	if w == nil {
+		return nil
+	}
+	return w.notices[NormalizeDOI(doi)]
+}
+
+// watchDOI normalizes a DOI column, which holds "unavailable" or "0" when the
+// DOI is not known.
+func watchDOI(doi string) string {
+	doi = NormalizeDOI(doi)
+	if !strings.HasPrefix(doi, "10.") {
+		return ""
+	}
+	return doi
+}
+
+// watchDate drops the midnight time from dates like "3/14/2023 0:00".
+func watchDate(date string) string {
+	return strings.TrimSuffix(date, " 0:00")
+}
+
+// watchReason turns "+Error in Data;+Duplication of Image;" into
+// "Error in Data; Duplication of Image".
+func watchReason(reason string) string {
+	reasons := []string{}
+	for _, r := range strings.Split(reason, ";") {
+		if r = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(r), "+")); r != "" {
+			reasons = append(reasons, r)
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
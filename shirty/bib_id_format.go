@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -18,7 +19,7 @@ const (
 	BibIdFormatAlphanumeric string = bibliography.BibIDFormatAlphanumeric
 )
 
-func (w *Workflow) BibIdFormat(b *documents.Bibliography) (string, error) {
+func (w *Workflow) BibIdFormat(ctx context.Context, b *documents.Bibliography) (string, error) {
 	text, err := b.Content()
 	if err != nil {
 		return BibIdFormatUnknown, err
@@ -41,7 +42,7 @@ Produce JSON.`),
 		ResponseFormat: openai.NewResponseFormat(schema.BibIDFormatJSONSchema(BibIdFormatNumeric, BibIdFormatAlphanumeric)),
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return BibIdFormatUnknown, fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -20,15 +21,15 @@ func NewBibliographyPageRF() *openai.ResponseFormat {
 	}
 }
 
-func (w *Workflow) PrepareBibliography(filePath string) (*documents.Bibliography, error) {
+func (w *Workflow) PrepareBibliography(ctx context.Context, filePath string) (*documents.Bibliography, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("read pdf error: %w", err)
 	}
-	return w.PrepareBibliographyContent(data)
+	return w.PrepareBibliographyContent(ctx, data)
 }
 
-func (w *Workflow) PrepareBibliographyContent(pdf []byte) (*documents.Bibliography, error) {
+func (w *Workflow) PrepareBibliographyContent(ctx context.Context, pdf []byte) (*documents.Bibliography, error) {
 	pageCount, err := documents.PDFPageCount(pdf)
 	if err != nil {
 		return nil, fmt.Errorf("pdf page count error: %w", err)
@@ -44,11 +45,11 @@ func (w *Workflow) PrepareBibliographyContent(pdf []byte) (*documents.Bibliograp
 		if err != nil {
 			return nil, fmt.Errorf("slice page %d error: %w", page, err)
 		}
-		resp, err := w.TextractContent(pagePDF)
+		resp, err := w.TextractContent(ctx, pagePDF)
 		if err != nil {
 			return nil, fmt.Errorf("textract page %d error: %w", page, err)
 		}
-		match, err := w.pageContainsBibliography(resp.Text)
+		match, err := w.pageContainsBibliography(ctx, resp.Text)
 		if err != nil {
 			return nil, fmt.Errorf("page %d bibliography classification error: %w", page, err)
 		}
@@ -79,7 +80,7 @@ func (w *Workflow) PrepareBibliographyContent(pdf []byte) (*documents.Bibliograp
 		log.Printf("bibliography pages detected: %d-%d of %d", startPage, endPage, pageCount)
 	}
 
-	textractResp, err := w.TextractContent(bibPDF)
+	textractResp, err := w.TextractContent(ctx, bibPDF)
 	if err != nil {
 		return nil, fmt.Errorf("textract bibliography pdf error: %w", err)
 	}
@@ -92,7 +93,7 @@ func (w *Workflow) PrepareBibliographyContent(pdf []byte) (*documents.Bibliograp
 	}, nil
 }
 
-func (w *Workflow) pageContainsBibliography(text string) (bool, error) {
+func (w *Workflow) pageContainsBibliography(ctx context.Context, text string) (bool, error) {
 	req := &openai.ChatRequest{
 		Model: w.model,
 		Messages: []openai.Message{
@@ -106,7 +107,7 @@ func (w *Workflow) pageContainsBibliography(text string) (bool, error) {
 		Temperature:    openai.Temperature(0),
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return false, fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,7 +16,7 @@ func NewClassifyEntryRF() *openai.ResponseFormat {
 	return openai.NewResponseFormat(schema.ClassifyEntryJSONSchema())
 }
 
-func (w *Workflow) Classify(text string) (string, error) {
+func (w *Workflow) Classify(ctx context.Context, text string) (string, error) {
 	model := w.model
 
 	temp := new(float64)
@@ -39,7 +40,7 @@ Hew to the following guidelines
 		ResponseFormat: NewClassifyEntryRF(),
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return entries.KindUnknown, fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -48,24 +49,24 @@ func newFromHtmlRequest(model, html string) *openai.ChatRequest {
 	}
 }
 
-func (c *Workflow) HTMLMetadata(html []byte) (*documents.Metadata, error) {
-	return c.extractDocumentMetadataImpl(newFromHtmlRequest(c.model, string(html)))
+func (c *Workflow) HTMLMetadata(ctx context.Context, html []byte) (*documents.Metadata, error) {
+	return c.extractDocumentMetadataImpl(ctx, newFromHtmlRequest(c.model, string(html)))
 }
 
-func (c *Workflow) TextMetadata(text string) (*documents.Metadata, error) {
-	return c.extractDocumentMetadataImpl(newFromTextRequest(c.model, text))
+func (c *Workflow) TextMetadata(ctx context.Context, text string) (*documents.Metadata, error) {
+	return c.extractDocumentMetadataImpl(ctx, newFromTextRequest(c.model, text))
 }
 
-func (w *Workflow) PDFMetadata(content []byte) (*documents.Metadata, error) {
-	tResp, err := w.TextractContent(content)
+func (w *Workflow) PDFMetadata(ctx context.Context, content []byte) (*documents.Metadata, error) {
+	tResp, err := w.TextractContent(ctx, content)
 	if err != nil {
 		return nil, fmt.Errorf("textract error: %w", err)
 	}
-	return w.TextMetadata(tResp.Text)
+	return w.TextMetadata(ctx, tResp.Text)
 }
 
-func (c *Workflow) extractDocumentMetadataImpl(req *openai.ChatRequest) (*documents.Metadata, error) {
-	content, err := c.oaiClient.ChatGetChoiceZero(req)
+func (c *Workflow) extractDocumentMetadataImpl(ctx context.Context, req *openai.ChatRequest) (*documents.Metadata, error) {
+	content, err := c.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -36,7 +37,7 @@ var (
 `
 )
 
-func (w *Workflow) EntryFromBibliography(b *documents.Bibliography, id int) (string, error) {
+func (w *Workflow) EntryFromBibliography(ctx context.Context, b *documents.Bibliography, id string) (string, error) {
 	text, err := b.Content()
 	if err != nil {
 		return "", err
@@ -54,13 +55,13 @@ func (w *Workflow) EntryFromBibliography(b *documents.Bibliography, id int) (str
 		Model: model,
 		Messages: []openai.Message{
 			openai.MakeSystemMessage(llama_33_70B_Prompt),
-			openai.MakeUserMessage(fmt.Sprintf("Extract bibliography entry %d from the provided document below:\n\nDOCUMENT TEXT:\n\n%s", id, text)),
+			openai.MakeUserMessage(fmt.Sprintf("Extract bibliography entry %s from the provided document below:\n\nDOCUMENT TEXT:\n\n%s", id, text)),
 		},
 		Temperature:    temp,
 		ResponseFormat: openai.NewResponseFormat(schema.BibliographyEntryJSONSchema()),
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,9 +3,12 @@
 package shirty
 
 import (
-	"os"
+	"context"
+	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/sandialabs/bibcheck/cassette"
 )
 
 func Test_EntryFromText_20231113_siefert_pmbs_1(t *testing.T) {
@@ -77,7 +80,7 @@ func Test_20231113_siefert_pmbs_35(t *testing.T) {
 
 func impl(t *testing.T, path string, id int, expected []string) {
 
-	apiKey, ok := os.LookupEnv("SHIRTY_API_KEY")
+	apiKey, ok := cassette.LookupEnv("SHIRTY_API_KEY")
 
 	if !ok {
 		t.Skip("provide SHIRTY_API_KEY")
@@ -88,12 +91,12 @@ func impl(t *testing.T, path string, id int, expected []string) {
 		"https://shirty.sandia.gov/api/v1",
 	)
 
-	bibliography, err := client.PrepareBibliography(path)
+	bibliography, err := client.PrepareBibliography(context.Background(), path)
 	if err != nil {
 		t.Errorf("prepare bibliography error: %v", err)
 	}
 
-	entry, err := client.EntryFromBibliography(bibliography, id)
+	entry, err := client.EntryFromBibliography(context.Background(), bibliography, strconv.Itoa(id))
 	if err != nil {
 		t.Errorf("entry from bibliography error: %v", err)
 	}
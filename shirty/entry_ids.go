@@ -0,0 +1,42 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+package shirty
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandialabs/bibcheck/bibliography"
+	"github.com/sandialabs/bibcheck/documents"
+)
+
+// BibliographyEntryIDs lists the bibliography's entry identifiers. Numeric
+// bibliographies are counted; alphanumeric ones are extracted to read their
+// labels.
+func (w *Workflow) BibliographyEntryIDs(ctx context.Context, b *documents.Bibliography) ([]string, error) {
+	format, err := w.BibIdFormat(ctx, b)
+	if err != nil {
+		return nil, fmt.Errorf("bib id format error: %w", err)
+	}
+
+	switch format {
+	case BibIdFormatNumeric:
+		n, err := w.NumBibEntries(ctx, b)
+		if err != nil {
+			return nil, fmt.Errorf("num bib entries error: %w", err)
+		}
+		return bibliography.NumericIDs(n), nil
+	case BibIdFormatAlphanumeric:
+		entries, err := w.ExtractBib(ctx, b)
+		if err != nil {
+			return nil, fmt.Errorf("extract bib error: %w", err)
+		}
+		ids := make([]string, len(entries))
+		for i, e := range entries {
+			ids[i] = e.EntryId
+		}
+		return bibliography.CleanIDs(ids), nil
+	default:
+		return nil, fmt.Errorf("unexpected bib id format %q", format)
+	}
+}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -16,7 +17,7 @@ type Entry struct {
 	EntryText string `json:"entry_text"`
 }
 
-func (c *Workflow) ExtractBib(b *documents.Bibliography) ([]Entry, error) {
+func (c *Workflow) ExtractBib(ctx context.Context, b *documents.Bibliography) ([]Entry, error) {
 	text, err := b.Content()
 	if err != nil {
 		return nil, err
@@ -45,7 +46,7 @@ Produce JSON.`),
 		ResponseFormat: openai.NewResponseFormat(schema.ExtractBibJSONSchema()),
 	}
 
-	content, err := c.oaiClient.ChatGetChoiceZero(req)
+	content, err := c.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -1,15 +1,16 @@
 package shirty
 
 import (
-	"os"
+	"context"
 	"testing"
 
+	"github.com/sandialabs/bibcheck/cassette"
 	"github.com/sandialabs/bibcheck/openai"
 )
 
 func TestShirtyChatGptOss120b(t *testing.T) {
 
-	apiKey, ok := os.LookupEnv("SHIRTY_API_KEY")
+	apiKey, ok := cassette.LookupEnv("SHIRTY_API_KEY")
 	if !ok {
 		t.Skip("SHIRTY_API_KEY not provided")
 	}
@@ -26,7 +27,7 @@ func TestShirtyChatGptOss120b(t *testing.T) {
 		},
 	}
 
-	resp, err := client.Chat(req)
+	resp, err := client.Chat(context.Background(), req)
 	if err != nil {
 		t.Fatalf("openai client error: %v", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -12,7 +13,7 @@ import (
 	"github.com/sandialabs/bibcheck/schema"
 )
 
-func (w *Workflow) NumBibEntries(b *documents.Bibliography) (int, error) {
+func (w *Workflow) NumBibEntries(ctx context.Context, b *documents.Bibliography) (int, error) {
 	log.Println("NumBibEntries(...)")
 
 	text, err := b.Content()
@@ -35,7 +36,7 @@ Produce JSON.`),
 		ResponseFormat: openai.NewResponseFormat(schema.NumEntriesJSONSchema("num_bib_entries", "integer")),
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return -1, fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -18,7 +19,7 @@ func NewParseAuthorsRF() *openai.ResponseFormat {
 	}
 }
 
-func (w *Workflow) ParseAuthors(text string) (*entries.Authors, error) {
+func (w *Workflow) ParseAuthors(ctx context.Context, text string) (*entries.Authors, error) {
 	model := w.model
 
 	temp := new(float64)
@@ -40,7 +41,7 @@ func (w *Workflow) ParseAuthors(text string) (*entries.Authors, error) {
 		Temperature:    temp,
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("chat choice 0 error: %w", err)
 	}
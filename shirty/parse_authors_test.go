@@ -3,10 +3,11 @@
 package shirty
 
 import (
-	"os"
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/sandialabs/bibcheck/cassette"
 	"github.com/sandialabs/bibcheck/entries"
 )
 
@@ -78,7 +79,7 @@ func Test_ParseAuthors_3(t *testing.T) {
 
 func parse_authors_impl(t *testing.T, entry string, expected entries.Authors) {
 
-	apiKey, ok := os.LookupEnv("SHIRTY_API_KEY")
+	apiKey, ok := cassette.LookupEnv("SHIRTY_API_KEY")
 
 	if !ok {
 		t.Skip("provide SHIRTY_API_KEY")
@@ -89,7 +90,7 @@ func parse_authors_impl(t *testing.T, entry string, expected entries.Authors) {
 		"https://shirty.sandia.gov/api/v1",
 	)
 
-	actual, err := client.ParseAuthors(entry)
+	actual, err := client.ParseAuthors(context.Background(), entry)
 	if err != nil {
 		t.Errorf("ParseAuthors error: %v", err)
 	}
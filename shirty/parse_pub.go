@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -18,7 +19,7 @@ func NewParsePubRF() *openai.ResponseFormat {
 }
 
 // ParsePub returns the title of a journal or a book from a bibliography entry
-func (w *Workflow) ParsePub(text string) (string, error) {
+func (w *Workflow) ParsePub(ctx context.Context, text string) (string, error) {
 	model := w.model
 
 	temp := new(float64)
@@ -39,7 +40,7 @@ func (w *Workflow) ParsePub(text string) (string, error) {
 		Temperature:    temp,
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,9 +3,11 @@
 package shirty
 
 import (
-	"os"
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/sandialabs/bibcheck/cassette"
 )
 
 func Test_ParsePub_1(t *testing.T) {
@@ -38,7 +40,7 @@ func Test_ParsePub_4(t *testing.T) {
 
 func parse_pub_impl(t *testing.T, entry, expected string) {
 
-	apiKey, ok := os.LookupEnv("SHIRTY_API_KEY")
+	apiKey, ok := cassette.LookupEnv("SHIRTY_API_KEY")
 
 	if !ok {
 		t.Skip("provide SHIRTY_API_KEY")
@@ -49,7 +51,7 @@ func parse_pub_impl(t *testing.T, entry, expected string) {
 		"https://shirty.sandia.gov/api/v1",
 	)
 
-	actual, err := client.ParsePub(entry)
+	actual, err := client.ParsePub(context.Background(), entry)
 	if err != nil {
 		t.Errorf("ParseTitle error: %v", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -16,7 +17,7 @@ func NewParseSoftwareRF() *openai.ResponseFormat {
 	return openai.NewResponseFormat(schema.SoftwareJSONSchema())
 }
 
-func (w *Workflow) ParseSoftware(text string) (*entries.Software, error) {
+func (w *Workflow) ParseSoftware(ctx context.Context, text string) (*entries.Software, error) {
 	model := w.model
 
 	req := &openai.ChatRequest{
@@ -30,7 +31,7 @@ Produce JSON.`),
 		ResponseFormat: NewParseSoftwareRF(),
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -17,7 +18,7 @@ func NewParseTitleRF() *openai.ResponseFormat {
 	}
 }
 
-func (w *Workflow) ParseTitle(text string) (string, error) {
+func (w *Workflow) ParseTitle(ctx context.Context, text string) (string, error) {
 	model := w.model
 
 	temp := new(float64)
@@ -37,7 +38,7 @@ func (w *Workflow) ParseTitle(text string) (string, error) {
 		Temperature:    temp,
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,9 +3,11 @@
 package shirty
 
 import (
-	"os"
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/sandialabs/bibcheck/cassette"
 )
 
 func Test_ParseTitle_1(t *testing.T) {
@@ -31,7 +33,7 @@ func Test_ParseTitle_3(t *testing.T) {
 
 func parse_title_impl(t *testing.T, entry, expected string) {
 
-	apiKey, ok := os.LookupEnv("SHIRTY_API_KEY")
+	apiKey, ok := cassette.LookupEnv("SHIRTY_API_KEY")
 
 	if !ok {
 		t.Skip("provide SHIRTY_API_KEY")
@@ -42,7 +44,7 @@ func parse_title_impl(t *testing.T, entry, expected string) {
 		"https://shirty.sandia.gov/api/v1",
 	)
 
-	actual, err := client.ParseTitle(entry)
+	actual, err := client.ParseTitle(context.Background(), entry)
 	if err != nil {
 		t.Errorf("ParseTitle error: %v", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -14,7 +15,7 @@ func NewParseURLRF() *openai.ResponseFormat {
 	return openai.NewResponseFormat(schema.ParseURLJSONSchema())
 }
 
-func (w *Workflow) ParseURL(text string) (string, error) {
+func (w *Workflow) ParseURL(ctx context.Context, text string) (string, error) {
 	model := w.model
 
 	req := &openai.ChatRequest{
@@ -30,7 +31,7 @@ Produce JSON.
 		ResponseFormat: NewParseURLRF(),
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,7 +16,7 @@ func NewParseOnlineRF() *openai.ResponseFormat {
 	return openai.NewResponseFormat(schema.WebsiteJSONSchema())
 }
 
-func (w *Workflow) ParseOnline(text string) (*entries.Online, error) {
+func (w *Workflow) ParseOnline(ctx context.Context, text string) (*entries.Online, error) {
 	model := w.model
 
 	req := &openai.ChatRequest{
@@ -31,7 +32,7 @@ func (w *Workflow) ParseOnline(text string) (*entries.Online, error) {
 		ResponseFormat: NewParseOnlineRF(),
 	}
 
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("chat choice 0 error: %w", err)
 	}
@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -27,30 +28,11 @@ var (
 )
 
 // Summarize returns (mismatch, comment, error).
-func (w *Workflow) Summarize(lr *lookup.Result) (bool, string, error) {
+func (w *Workflow) Summarize(ctx context.Context, lr *lookup.Result) (bool, string, error) {
 	temp := new(float64)
 	*temp = 0.0
 
-	searchResults := []string{}
-	if lr.Arxiv.Entry != nil {
-		searchResults = append(searchResults, lr.Arxiv.Entry.ToString())
-	}
-	if lr.Crossref.Work != nil {
-		searchResults = append(searchResults, lr.Crossref.Work.ToString())
-	}
-	if lr.DOIOrg.Found {
-		searchResults = append(searchResults, "<DOI from bibliography entry exists, no metadata provided.>")
-	}
-	if lr.Elsevier.Result != nil {
-		searchResults = append(searchResults, lr.Elsevier.Result.ToString())
-	}
-	if lr.Online.Metadata != nil {
-		searchResults = append(searchResults, lr.Online.Metadata.ToString())
-	}
-	if lr.OSTI.Record != nil {
-		searchResults = append(searchResults, lr.OSTI.Record.ToString())
-	}
-
+	searchResults := lr.Evidence()
 	if len(searchResults) == 0 {
 		log.Printf("No search results to summarize")
 		return true, "insufficient search result data", nil
@@ -69,7 +51,7 @@ func (w *Workflow) Summarize(lr *lookup.Result) (bool, string, error) {
 		Temperature:    temp,
 		ResponseFormat: openai.NewResponseFormat(schema.SummaryJSONSchema()),
 	}
-	content, err := w.oaiClient.ChatGetChoiceZero(req)
+	content, err := w.oaiClient.ChatGetChoiceZero(ctx, req)
 	if err != nil {
 		return false, "", err
 	}
@@ -4,6 +4,7 @@ package shirty
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/sandialabs/bibcheck/cassette"
 	"github.com/sandialabs/bibcheck/config"
 	"github.com/sandialabs/bibcheck/internal/wasmhttp"
 )
@@ -27,10 +29,10 @@ type TextractResponse struct {
 	Sections         []any  `json:"sections"`
 }
 
-func (w *Workflow) textractImpl(requestBody io.Reader, contentType string) (*TextractResponse, error) {
+func (w *Workflow) textractImpl(ctx context.Context, requestBody io.Reader, contentType string) (*TextractResponse, error) {
 	// Create the request
 	log.Printf("POST %s", w.OpenAIClient().BaseUrl()+"/extract/textract/create")
-	req, err := http.NewRequest("POST", w.OpenAIClient().BaseUrl()+"/extract/textract/create", requestBody)
+	req, err := http.NewRequestWithContext(ctx, "POST", w.OpenAIClient().BaseUrl()+"/extract/textract/create", requestBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -43,7 +45,7 @@ func (w *Workflow) textractImpl(requestBody io.Reader, contentType string) (*Tex
 	wasmhttp.ConfigureRequest(req)
 
 	// Send the request
-	client := &http.Client{}
+	client := &http.Client{Transport: cassette.Transport(w.transport)}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -70,7 +72,7 @@ func (w *Workflow) textractImpl(requestBody io.Reader, contentType string) (*Tex
 	return &textractResp, nil
 }
 
-func (w *Workflow) TextractContent(data []byte) (*TextractResponse, error) {
+func (w *Workflow) TextractContent(ctx context.Context, data []byte) (*TextractResponse, error) {
 	// Create a buffer to write our multipart form
 	var requestBody bytes.Buffer
 	writer := multipart.NewWriter(&requestBody)
@@ -93,10 +95,19 @@ func (w *Workflow) TextractContent(data []byte) (*TextractResponse, error) {
 		return nil, fmt.Errorf("failed to close writer: %w", err)
 	}
 
-	return w.textractImpl(&requestBody, writer.FormDataContentType())
+	return w.textractImpl(ctx, &requestBody, writer.FormDataContentType())
 }
 
-func (w *Workflow) Textract(filePath string) (*TextractResponse, error) {
+// DocumentText returns the text of a whole PDF document.
+func (w *Workflow) DocumentText(ctx context.Context, pdf []byte) (string, error) {
+	resp, err := w.TextractContent(ctx, pdf)
+	if err != nil {
+		return "", err
+	}
+	return resp.Text, nil
+}
+
+func (w *Workflow) Textract(ctx context.Context, filePath string) (*TextractResponse, error) {
 	// Create a buffer to write our multipart form
 	var requestBody bytes.Buffer
 	writer := multipart.NewWriter(&requestBody)
@@ -126,5 +137,5 @@ func (w *Workflow) Textract(filePath string) (*TextractResponse, error) {
 		return nil, fmt.Errorf("failed to close writer: %w", err)
 	}
 
-	return w.textractImpl(&requestBody, writer.FormDataContentType())
+	return w.textractImpl(ctx, &requestBody, writer.FormDataContentType())
 }
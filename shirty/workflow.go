@@ -3,6 +3,7 @@
 package shirty
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/sandialabs/bibcheck/openai"
@@ -15,6 +16,8 @@ type Workflow struct {
 	apiKey    string
 	model     string
 	oaiClient *openai.Client
+	// transport carries the requests that do not go through oaiClient.
+	transport http.RoundTripper
 }
 
 type WorkflowOpt func(*Workflow)
@@ -51,6 +54,20 @@ func WithAuditEnabled(enabled bool) WorkflowOpt {
 	}
 }
 
+// WithTransport sends requests through t instead of http.DefaultTransport.
+func WithTransport(t http.RoundTripper) WorkflowOpt {
+	return func(w *Workflow) {
+		openai.WithTransport(t)(w.oaiClient)
+		w.transport = t
+	}
+}
+
+func WithResponseCache(enabled bool) WorkflowOpt {
+	return func(w *Workflow) {
+		openai.WithResponseCache(enabled)(w.oaiClient)
+	}
+}
+
 func (w *Workflow) OpenAIClient() *openai.Client {
 	return w.oaiClient
 }
@@ -3,9 +3,11 @@
 package analyze_test
 
 import (
-	"os"
+	"context"
+	"strconv"
 	"testing"
 
+	"github.com/sandialabs/bibcheck/cassette"
 	"github.com/sandialabs/bibcheck/documents"
 	"github.com/sandialabs/bibcheck/lookup"
 	"github.com/sandialabs/bibcheck/openrouter"
@@ -39,7 +41,7 @@ func impl(t *testing.T, path string, id int, expected bool) {
 	var lr *lookup.Result
 	var err error
 
-	if apiKey, ok := os.LookupEnv("SHIRTY_API_KEY"); ok {
+	if apiKey, ok := cassette.LookupEnv("SHIRTY_API_KEY"); ok {
 
 		client := shirty.NewWorkflow(
 			apiKey,
@@ -47,15 +49,15 @@ func impl(t *testing.T, path string, id int, expected bool) {
 		)
 
 		var bibliography *documents.Bibliography
-		bibliography, err = client.PrepareBibliography(path)
+		bibliography, err = client.PrepareBibliography(context.Background(), path)
 		if err != nil {
 			t.Errorf("prepare bibliography error: %v", err)
 		}
 
-		lr, err = lookup.EntryFromBibliography(bibliography, id, "auto",
+		lr, err = lookup.EntryFromBibliography(context.Background(), bibliography, strconv.Itoa(id), "auto",
 			client, client, client, client, nil)
 
-	} else if apiKey, ok := os.LookupEnv("OPENROUTER_API_KEY"); ok {
+	} else if apiKey, ok := cassette.LookupEnv("OPENROUTER_API_KEY"); ok {
 
 		client := openrouter.NewClient(apiKey)
 
@@ -65,7 +67,7 @@ func impl(t *testing.T, path string, id int, expected bool) {
 			t.Errorf("encode error: %v", err)
 		}
 
-		lr, err = lookup.EntryFromBase64(encoded, id, "auto",
+		lr, err = lookup.EntryFromBase64(context.Background(), encoded, strconv.Itoa(id), "auto",
 			client, client, client, client, nil)
 
 	} else {
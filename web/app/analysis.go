@@ -31,7 +31,7 @@ func (a *app) renderAnalysis() vecty.ComponentOrHTML {
 							a.reset()
 						}),
 					),
-					vecty.Text("New PDF"),
+					vecty.Text("New file"),
 				),
 			),
 			elem.Section(
@@ -48,6 +48,7 @@ func (a *app) renderAnalysis() vecty.ComponentOrHTML {
 			elem.Section(
 				vecty.Markup(vecty.Class("entries")),
 				a.renderEntries(),
+				renderOrphans(a.state.Orphans),
 			),
 		),
 		renderFooter(),
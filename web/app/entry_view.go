@@ -5,6 +5,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/hexops/vecty"
 	"github.com/hexops/vecty/elem"
 	"github.com/sandialabs/bibcheck/web/workflow"
@@ -93,5 +95,26 @@ func renderSummaryCard(entry workflow.EntryState, summary workflow.SummaryView)
 			elem.Strong(vecty.Text(summaryTitle(summary.Status))),
 		),
 		elem.Preformatted(vecty.Text(nonEmpty(summary.Comment, summaryFallback(entry, summary.Status)))),
+		renderMatch(entry.Match),
+	)
+}
+
+func renderMatch(m *workflow.MatchView) vecty.MarkupOrChild {
+	if m == nil {
+		return nil
+	}
+
+	rows := make(vecty.List, 0, len(m.Fields))
+	for _, f := range m.Fields {
+		text := f.Name + ": " + f.Verdict
+		if f.Detail != "" {
+			text += " (" + f.Detail + ")"
+		}
+		rows = append(rows, elem.ListItem(vecty.Text(text)))
+	}
+	return elem.Div(
+		vecty.Markup(vecty.Class("match-breakdown")),
+		elem.Strong(vecty.Text(fmt.Sprintf("Field match vs %s: %s (%.2f)", m.Source, m.Verdict, m.Score))),
+		elem.UnorderedList(rows),
 	)
 }
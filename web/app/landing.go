@@ -77,10 +77,8 @@ func (a *app) renderLanding() vecty.ComponentOrHTML {
 						elem.Span(vecty.Text("Bibliography entry")),
 						elem.Input(
 							vecty.Markup(
-								prop.Type(prop.TypeNumber),
-								vecty.Attribute("min", "1"),
-								vecty.Attribute("step", "1"),
-								prop.Placeholder("All entries"),
+								prop.Type(prop.TypeText),
+								prop.Placeholder("All entries (or e.g. 12, Smi20)"),
 								prop.Value(a.entry),
 								event.Input(func(e *vecty.Event) {
 									a.entry = e.Target.Get("value").String()
@@ -90,6 +88,20 @@ func (a *app) renderLanding() vecty.ComponentOrHTML {
 							),
 						),
 					),
+					elem.Label(
+						vecty.Markup(vecty.Class("checkbox-field")),
+						elem.Input(
+							vecty.Markup(
+								prop.Type(prop.TypeCheckbox),
+								prop.Checked(a.orphans),
+								event.Change(func(e *vecty.Event) {
+									a.orphans = e.Target.Get("checked").Bool()
+									vecty.Rerender(a)
+								}),
+							),
+						),
+						elem.Span(vecty.Text("Cross-check in-text citations (reading a PDF's body costs an extra LLM call)")),
+					),
 					vecty.If(showShirtyKey,
 						elem.Label(
 							vecty.Markup(vecty.Class("field")),
@@ -138,7 +150,7 @@ func (a *app) renderLanding() vecty.ComponentOrHTML {
 						vecty.Markup(
 							prop.ID("pdf-file"),
 							prop.Type(prop.TypeFile),
-							vecty.Attribute("accept", "application/pdf,.pdf"),
+							vecty.Attribute("accept", "application/pdf,.pdf,.bib"),
 							event.Change(func(e *vecty.Event) {
 								a.loadFileList(e.Target.Get("files"))
 							}),
@@ -163,7 +175,7 @@ func (a *app) renderLanding() vecty.ComponentOrHTML {
 							a.start()
 						}),
 					),
-					vecty.Text("Analyze PDF"),
+					vecty.Text("Analyze"),
 				),
 			),
 		),
@@ -175,11 +187,14 @@ func dropTitle(filename string) string {
 	if filename != "" {
 		return filename
 	}
-	return "Drop a PDF here"
+	return "Drop a PDF or .bib file here"
 }
 
 func dropSubtitle(filename string) string {
 	if filename != "" {
+		if isBibTeX(filename) {
+			return "BibTeX ready for analysis"
+		}
 		return "PDF ready for analysis"
 	}
 	return "or choose a file"
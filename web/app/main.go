@@ -25,6 +25,7 @@ type app struct {
 	shirtyBaseURL string
 	openRouterKey string
 	entry         string
+	orphans       bool
 	filename      string
 	pdf           []byte
 	dragging      bool
@@ -64,9 +65,17 @@ func (a *app) Render() vecty.ComponentOrHTML {
 }
 
 func (a *app) ready() bool {
+	if isBibTeX(a.filename) {
+		// BibTeX entries are parsed without an LLM
+		return len(a.pdf) > 0
+	}
 	return len(a.pdf) > 0 && (shirtyKey(a) != "" || openRouterKey(a) != "")
 }
 
+func isBibTeX(filename string) bool {
+	return strings.HasSuffix(strings.ToLower(filename), ".bib")
+}
+
 func (a *app) start() {
 	entry, err := selectedEntry(a.entry)
 	if err != nil {
@@ -75,15 +84,21 @@ func (a *app) start() {
 		return
 	}
 
-	rt, err := workflow.NewRuntime(workflow.Keys{
+	keys := workflow.Keys{
 		ShirtyAPIKey:     shirtyKey(a),
 		ShirtyBaseURL:    shirtyBaseURL(a),
 		OpenRouterAPIKey: openRouterKey(a),
-	})
-	if err != nil {
-		a.errorMessage = err.Error()
-		vecty.Rerender(a)
-		return
+	}
+	analyze := workflow.AnalyzeBibTeX
+	rt := workflow.NewBibTeXRuntime(keys)
+	if !isBibTeX(a.filename) {
+		analyze = workflow.AnalyzePDFWithOptions
+		rt, err = workflow.NewRuntime(keys)
+		if err != nil {
+			a.errorMessage = err.Error()
+			vecty.Rerender(a)
+			return
+		}
 	}
 
 	a.running = true
@@ -99,8 +114,8 @@ func (a *app) start() {
 	vecty.Rerender(a)
 
 	pdf := append([]byte(nil), a.pdf...)
-	options := workflow.Options{Entry: entry}
-	go workflow.AnalyzePDFWithOptions(ctx, rt, pdf, options, func(state workflow.State) {
+	options := workflow.Options{Entry: entry, Orphans: a.orphans}
+	go analyze(ctx, rt, pdf, options, func(state workflow.State) {
 		if runID != a.runID {
 			return
 		}
@@ -180,8 +195,8 @@ func (a *app) loadFileList(files js.Value) {
 	}
 	file := files.Index(0)
 	name := file.Get("name").String()
-	if !strings.HasSuffix(strings.ToLower(name), ".pdf") && file.Get("type").String() != "application/pdf" {
-		a.errorMessage = "Select a PDF file."
+	if !strings.HasSuffix(strings.ToLower(name), ".pdf") && file.Get("type").String() != "application/pdf" && !isBibTeX(name) {
+		a.errorMessage = "Select a PDF or BibTeX file."
 		vecty.Rerender(a)
 		return
 	}
@@ -206,7 +221,7 @@ func (a *app) loadFileList(files js.Value) {
 	onerror = js.FuncOf(func(this js.Value, args []js.Value) any {
 		defer onload.Release()
 		defer onerror.Release()
-		a.errorMessage = "Could not read the selected PDF."
+		a.errorMessage = "Could not read the selected file."
 		vecty.Rerender(a)
 		return nil
 	})
@@ -215,14 +230,13 @@ func (a *app) loadFileList(files js.Value) {
 	reader.Call("readAsArrayBuffer", file)
 }
 
-func selectedEntry(value string) (int, error) {
+func selectedEntry(value string) (string, error) {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
-		return 0, nil
+		return "", nil
 	}
-	entry, err := strconv.Atoi(trimmed)
-	if err != nil || entry < 1 {
-		return 0, fmt.Errorf("Bibliography entry must be a positive whole number.")
+	if n, err := strconv.Atoi(trimmed); err == nil && n < 1 {
+		return "", fmt.Errorf("Bibliography entry must be a positive number or an ID such as Smi20.")
 	}
-	return entry, nil
+	return trimmed, nil
 }
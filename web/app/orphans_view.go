@@ -0,0 +1,81 @@
+// Copyright 2025 National Technology and Engineering Solutions of Sandia
+// SPDX-License-Identifier: BSD-3-Clause
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hexops/vecty"
+	"github.com/hexops/vecty/elem"
+	"github.com/sandialabs/bibcheck/web/workflow"
+)
+
+func renderOrphans(orphans *workflow.OrphansView) vecty.MarkupOrChild {
+	if orphans == nil {
+		return nil
+	}
+
+	status := "completed"
+	if len(orphans.Uncited) > 0 || len(orphans.Unresolved) > 0 {
+		status = "review"
+	}
+	return elem.Article(
+		vecty.Markup(vecty.Class("entry-card", "orphans-card")),
+		elem.Header(
+			elem.Heading2(vecty.Text("In-text citations")),
+			elem.Span(vecty.Markup(vecty.Class("status", statusClass(status))), vecty.Text(orphansSummary(orphans))),
+		),
+		elem.Div(
+			vecty.Markup(vecty.Class("orphans-columns")),
+			elem.Div(
+				vecty.Markup(vecty.Class("entry-pane")),
+				elem.Heading3(vecty.Text("Never cited")),
+				renderUncited(orphans),
+			),
+			elem.Div(
+				vecty.Markup(vecty.Class("entry-pane")),
+				elem.Heading3(vecty.Text("Cited, but not in the bibliography")),
+				renderUnresolved(orphans),
+			),
+		),
+	)
+}
+
+func orphansSummary(orphans *workflow.OrphansView) string {
+	if orphans.Note != "" {
+		return "not checked"
+	}
+	return fmt.Sprintf("%d of %d entries cited", orphans.Cited, orphans.Entries)
+}
+
+func renderUncited(orphans *workflow.OrphansView) vecty.ComponentOrHTML {
+	if orphans.Note != "" {
+		return elem.Div(vecty.Markup(vecty.Class("empty-card")), vecty.Text(orphans.Note))
+	}
+	if len(orphans.Uncited) == 0 {
+		return elem.Div(vecty.Markup(vecty.Class("empty-card")), vecty.Text("Every entry is cited."))
+	}
+	return elem.Preformatted(vecty.Text("Entries " + strings.Join(orphans.Uncited, ", ")))
+}
+
+func renderUnresolved(orphans *workflow.OrphansView) vecty.ComponentOrHTML {
+	if orphans.Note != "" {
+		return elem.Div(vecty.Markup(vecty.Class("empty-card")), vecty.Text(orphans.Note))
+	}
+	if len(orphans.Unresolved) == 0 {
+		return elem.Div(vecty.Markup(vecty.Class("empty-card")), vecty.Text("Every citation has an entry."))
+	}
+
+	rows := make(vecty.List, 0, len(orphans.Unresolved))
+	for _, cite := range orphans.Unresolved {
+		text := cite.Ref + ", cited as " + cite.Citation
+		if cite.Count > 1 {
+			text += fmt.Sprintf(" (%d times)", cite.Count)
+		}
+		rows = append(rows, elem.ListItem(vecty.Text(text)))
+	}
+	return elem.UnorderedList(rows)
+}
@@ -13,7 +13,7 @@ import (
 
 func providerText(provider workflow.ProviderKind) string {
 	if provider == "" {
-		return "selected provider"
+		return "direct lookups"
 	}
 	return string(provider)
 }
@@ -107,6 +107,10 @@ func summaryTitle(status string) string {
 		return "Looks okay"
 	case "review":
 		return "Review suggested"
+	case "retracted":
+		return "Retracted"
+	case "wrong-doi":
+		return "Wrong DOI"
 	case "error":
 		return "Error"
 	case "active":
@@ -128,6 +132,10 @@ func summaryFallback(entry workflow.EntryState, status string) string {
 		return "No issues found."
 	case "review":
 		return "No matching metadata found."
+	case "retracted":
+		return "The cited work has been retracted or withdrawn."
+	case "wrong-doi":
+		return "The DOI exists but points to a different work."
 	default:
 		return statusCopy(entry.AnalysisStatus)
 	}
@@ -6,14 +6,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 
 	analysisrunner "github.com/sandialabs/bibcheck/analysis"
+	"github.com/sandialabs/bibcheck/bibliography"
+	"github.com/sandialabs/bibcheck/bibtex"
+	"github.com/sandialabs/bibcheck/citations"
 	"github.com/sandialabs/bibcheck/config"
 	"github.com/sandialabs/bibcheck/crossref"
 	"github.com/sandialabs/bibcheck/documents"
 	"github.com/sandialabs/bibcheck/entries"
 	"github.com/sandialabs/bibcheck/lookup"
+	"github.com/sandialabs/bibcheck/match"
 	"github.com/sandialabs/bibcheck/openrouter"
 	"github.com/sandialabs/bibcheck/shirty"
 )
@@ -39,6 +45,7 @@ type EntryState struct {
 	AnalysisStatus string
 	LookupCards    []LookupCard
 	Summary        SummaryView
+	Match          *MatchView
 	Error          string
 }
 
@@ -53,95 +60,149 @@ type SummaryView struct {
 	Comment string
 }
 
+// MatchView is the deterministic field comparison of an entry.
+type MatchView struct {
+	Source  string
+	Score   float64
+	Verdict string
+	Fields  []MatchFieldView
+}
+
+type MatchFieldView struct {
+	Name    string
+	Verdict string
+	Detail  string
+}
+
+// OrphansView is the cross-check of a document's in-text citations against
+// its bibliography.
+type OrphansView struct {
+	// Note says why citations were not checked.
+	Note       string
+	Entries    int
+	Cited      int
+	Uncited    []string
+	Unresolved []UnresolvedCitationView
+}
+
+type UnresolvedCitationView struct {
+	Ref      string
+	Citation string
+	Style    string
+	Count    int
+}
+
 type State struct {
 	Provider  ProviderKind
 	Phase     string
 	Total     int
 	Completed int
 	Entries   []EntryState
+	Orphans   *OrphansView
 	Error     string
 }
 
 type Progress func(State)
 
 type Options struct {
-	Entry   int
+	// Entry selects a single entry by its bibliography ID or citation key.
+	Entry   string
 	Workers int
+	// Orphans cross-checks the in-text citations of a whole PDF against its
+	// bibliography, which costs an extra LLM call to read the body.
+	Orphans bool
 }
 
 type Provider interface {
-	PrepareBibliographyContent([]byte) (*documents.Bibliography, error)
-	EntryFromBibliography(*documents.Bibliography, int) (string, error)
+	PrepareBibliographyContent(context.Context, []byte) (*documents.Bibliography, error)
+	EntryFromBibliography(context.Context, *documents.Bibliography, string) (string, error)
+	documents.EntryIDLister
 	entries.Classifier
 	entries.Parser
 	documents.MetaExtractor
-	Summarize(*lookup.Result) (bool, string, error)
-}
-
-type Counter interface {
-	CountBibliographyEntries(*documents.Bibliography) (int, error)
+	Summarize(context.Context, *lookup.Result) (bool, string, error)
 }
 
 type Runtime struct {
 	Kind           ProviderKind
 	Provider       Provider
-	Counter        Counter
 	CrossrefClient *crossref.Client
+	// Transport carries the runtime's requests, to the provider and for
+	// lookups; nil uses http.DefaultTransport.
+	Transport http.RoundTripper
+	// NoHTTPCache keeps lookups from the on-disk HTTP cache.
+	NoHTTPCache bool
 }
 
-type shirtyCounter struct {
-	client *shirty.Workflow
-}
+// RuntimeOpt configures NewRuntime and NewBibTeXRuntime.
+type RuntimeOpt func(*Runtime)
 
-func (c shirtyCounter) CountBibliographyEntries(b *documents.Bibliography) (int, error) {
-	return c.client.NumBibEntries(b)
+// WithTransport sends the runtime's requests through t, e.g. to keep them
+// away from hosts the caller must not reach.
+func WithTransport(t http.RoundTripper) RuntimeOpt {
+	return func(rt *Runtime) { rt.Transport = t }
 }
 
-type openRouterCounter struct {
-	client *openrouter.Client
+// WithoutHTTPCache keeps lookups from the on-disk HTTP cache, so that every
+// request reaches Transport rather than being answered with a response
+// stored by some other run.
+func WithoutHTTPCache() RuntimeOpt {
+	return func(rt *Runtime) { rt.NoHTTPCache = true }
 }
 
-func (c openRouterCounter) CountBibliographyEntries(b *documents.Bibliography) (int, error) {
-	return c.client.NumBibliographyEntries(b)
-}
-
-func NewRuntime(keys Keys) (*Runtime, error) {
+func NewRuntime(keys Keys, options ...RuntimeOpt) (*Runtime, error) {
 	shirtyKey := strings.TrimSpace(keys.ShirtyAPIKey)
 	shirtyBaseURL := strings.TrimSpace(keys.ShirtyBaseURL)
 	openRouterKey := strings.TrimSpace(keys.OpenRouterAPIKey)
 
+	rt := newRuntime(options)
 	if shirtyKey != "" {
 		if shirtyBaseURL == "" {
 			shirtyBaseURL = config.DefaultShirtyBaseURL
 		}
-		client := shirty.NewWorkflow(shirtyKey, shirtyBaseURL, shirty.WithAuditEnabled(false))
-		return &Runtime{
-			Kind:           ProviderShirty,
-			Provider:       client,
-			Counter:        shirtyCounter{client: client},
-			CrossrefClient: crossref.NewClient(),
-		}, nil
+		rt.Kind = ProviderShirty
+		rt.Provider = shirty.NewWorkflow(shirtyKey, shirtyBaseURL, shirty.WithAuditEnabled(false), shirty.WithResponseCache(false), shirty.WithTransport(rt.Transport))
+		return rt, nil
 	}
 
 	if openRouterKey != "" {
-		client := openrouter.NewClient(openRouterKey)
-		return &Runtime{
-			Kind:           ProviderOpenRouter,
-			Provider:       client,
-			Counter:        openRouterCounter{client: client},
-			CrossrefClient: crossref.NewClient(),
-		}, nil
+		rt.Kind = ProviderOpenRouter
+		rt.Provider = openrouter.NewClient(openRouterKey, openrouter.WithTransport(rt.Transport))
+		return rt, nil
 	}
 
 	return nil, errors.New("provide a Shirty or OpenRouter API key")
 }
 
+// NewBibTeXRuntime is like NewRuntime, but BibTeX analysis does not need an
+// LLM, so it falls back to a runtime without a provider when no key is set.
+func NewBibTeXRuntime(keys Keys, options ...RuntimeOpt) *Runtime {
+	if rt, err := NewRuntime(keys, options...); err == nil {
+		return rt
+	}
+	return newRuntime(options)
+}
+
+// newRuntime returns a runtime without a provider.
+func newRuntime(options []RuntimeOpt) *Runtime {
+	rt := &Runtime{Kind: ProviderNone}
+	for _, option := range options {
+		option(rt)
+	}
+	crossrefOptions := []crossref.Option{crossref.WithTransport(rt.Transport)}
+	if rt.NoHTTPCache {
+		crossrefOptions = append(crossrefOptions, crossref.WithCache(nil))
+	}
+	rt.CrossrefClient = crossref.NewClient(crossrefOptions...)
+	return rt
+}
+
 func AnalyzePDF(ctx context.Context, rt *Runtime, pdf []byte, progress Progress) State {
 	return AnalyzePDFWithOptions(ctx, rt, pdf, Options{}, progress)
 }
 
 func AnalyzePDFWithOptions(ctx context.Context, rt *Runtime, pdf []byte, options Options, progress Progress) State {
-	if rt == nil || rt.Provider == nil || rt.Counter == nil {
+	if rt == nil || rt.Provider == nil {
 		state := State{Phase: "Starting"}
 		return fail(progress, state, errors.New("missing analysis runtime"))
 	}
@@ -159,27 +220,100 @@ func AnalyzePDFWithOptions(ctx context.Context, rt *Runtime, pdf []byte, options
 		return fail(progress, state, errors.New("selected PDF is empty"))
 	}
 
-	bibliography, err := rt.Provider.PrepareBibliographyContent(pdf)
+	bib, err := rt.Provider.PrepareBibliographyContent(ctx, pdf)
 	if err != nil {
 		return fail(progress, state, fmt.Errorf("prepare bibliography: %w", err))
 	}
 
-	entryIDs := []int{options.Entry}
-	if options.Entry < 1 {
-		state.Phase = "Counting entries"
+	entryIDs := []string{bibliography.CleanID(options.Entry)}
+	if entryIDs[0] == "" {
+		state.Phase = "Listing entries"
 		emit(progress, state)
-		count, err := rt.Counter.CountBibliographyEntries(bibliography)
+		entryIDs, err = rt.Provider.BibliographyEntryIDs(ctx, bib)
 		if err != nil {
-			return fail(progress, state, fmt.Errorf("count bibliography entries: %w", err))
+			return fail(progress, state, fmt.Errorf("list bibliography entries: %w", err))
 		}
-		if count < 1 {
-			return fail(progress, state, fmt.Errorf("expected at least one bibliography entry, found %d", count))
+		if len(entryIDs) < 1 {
+			return fail(progress, state, errors.New("expected at least one bibliography entry, found 0"))
 		}
+	}
+
+	extract := func(ctx context.Context, id string) (string, error) {
+		return rt.Provider.EntryFromBibliography(ctx, bib, id)
+	}
+	var crossCheck func(context.Context, []EntryState) *OrphansView
+	if options.Orphans && options.Entry == "" {
+		crossCheck = func(ctx context.Context, entries []EntryState) *OrphansView {
+			// providers without text extraction leave the body to pdfcpu
+			extractor, _ := rt.Provider.(documents.TextExtractor)
+			text, err := documents.BodyText(ctx, pdf, bib, extractor)
+			if err != nil {
+				return &OrphansView{Note: fmt.Sprintf("read document body: %v", err), Entries: len(entries)}
+			}
+			return BuildOrphansView(entries, citations.Find(citations.Body(text)))
+		}
+	}
+	return analyzeEntries(ctx, rt, state, entryIDs, extract, rt.Provider, crossCheck, options, progress)
+}
 
-		entryIDs = make([]int, count)
-		for i := range entryIDs {
-			entryIDs[i] = i + 1
+// AnalyzeBibTeX checks the entries of a BibTeX database. The entries are
+// parsed directly, so rt.Provider is only needed for summaries and online
+// resources and may be nil.
+func AnalyzeBibTeX(ctx context.Context, rt *Runtime, data []byte, options Options, progress Progress) State {
+	if rt == nil {
+		state := State{Phase: "Starting"}
+		return fail(progress, state, errors.New("missing analysis runtime"))
+	}
+
+	state := State{
+		Provider: rt.Kind,
+		Phase:    "Reading BibTeX",
+	}
+	emit(progress, state)
+
+	if err := ctx.Err(); err != nil {
+		return fail(progress, state, err)
+	}
+	list, err := bibtex.Parse(data)
+	if err != nil {
+		return fail(progress, state, err)
+	}
+	if len(list) < 1 {
+		return fail(progress, state, errors.New("expected at least one BibTeX entry, found 0"))
+	}
+
+	keys := make([]string, len(list))
+	for i, e := range list {
+		keys[i] = e.Key
+	}
+	entryIDs := keys
+	if id := bibliography.CleanID(options.Entry); id != "" {
+		if !slices.Contains(keys, id) {
+			return fail(progress, state, fmt.Errorf("no BibTeX entry with key %q", id))
 		}
+		entryIDs = []string{id}
+	}
+
+	var fallback entries.Parser
+	if rt.Provider != nil {
+		fallback = rt.Provider
+	}
+	extract := func(_ context.Context, key string) (string, error) {
+		return list[slices.Index(keys, key)].Text(), nil
+	}
+	return analyzeEntries(ctx, rt, state, entryIDs, extract, bibtex.NewParser(list, fallback), nil, options, progress)
+}
+
+func analyzeEntries(ctx context.Context, rt *Runtime, state State, entryIDs []string,
+	extract func(context.Context, string) (string, error),
+	entryParser entries.Parser,
+	crossCheck func(context.Context, []EntryState) *OrphansView,
+	options Options,
+	progress Progress,
+) State {
+	var docMeta documents.MetaExtractor
+	if rt.Provider != nil {
+		docMeta = rt.Provider
 	}
 
 	state.Total = len(entryIDs)
@@ -187,16 +321,19 @@ func AnalyzePDFWithOptions(ctx context.Context, rt *Runtime, pdf []byte, options
 	runnerResult, err := analysisrunner.Run(ctx, analysisrunner.Config{
 		EntryIDs: entryIDs,
 		Workers:  options.Workers,
-		Extract: func(id int) (string, error) {
-			return rt.Provider.EntryFromBibliography(bibliography, id)
-		},
-		Lookup: func(text string) (*lookup.Result, error) {
-			return lookup.Entry(text, "auto", rt.Provider, rt.Provider, rt.Provider, &lookup.EntryConfig{
+		Extract:  extract,
+		Lookup: func(ctx context.Context, text string) (*lookup.Result, error) {
+			return lookup.Entry(ctx, text, "auto", rt.Provider, docMeta, entryParser, &lookup.EntryConfig{
 				CrossrefClient: rt.CrossrefClient,
+				Transport:      rt.Transport,
+				NoHTTPCache:    rt.NoHTTPCache,
 			})
 		},
-		Summarize: func(result *lookup.Result) (analysisrunner.Summary, error) {
-			mismatch, comment, err := rt.Provider.Summarize(result)
+		Summarize: func(ctx context.Context, result *lookup.Result) (analysisrunner.Summary, error) {
+			if rt.Provider == nil {
+				return analysisrunner.MatchSummary(result), nil
+			}
+			mismatch, comment, err := rt.Provider.Summarize(ctx, result)
 			return analysisrunner.Summary{Mismatch: mismatch, Comment: comment}, err
 		},
 		Progress: func(snapshot analysisrunner.Snapshot) {
@@ -209,17 +346,49 @@ func AnalyzePDFWithOptions(ctx context.Context, rt *Runtime, pdf []byte, options
 		return fail(progress, state, err)
 	}
 
+	if crossCheck != nil {
+		state.Phase = "Cross-checking citations"
+		emit(progress, state)
+		state.Orphans = crossCheck(ctx, state.Entries)
+	}
+
 	state.Phase = "Done"
 	emit(progress, state)
 	return state
 }
 
+// BuildOrphansView reports the entries no citation refers to and the
+// citations that refer to no entry.
+func BuildOrphansView(entries []EntryState, cites []citations.Citation) *OrphansView {
+	view := &OrphansView{Entries: len(entries)}
+	list := make([]citations.Entry, len(entries))
+	for i, entry := range entries {
+		list[i] = citations.Entry{ID: entry.ID, Text: entry.Text}
+	}
+	report := citations.Check(list, cites)
+	if report == nil {
+		view.Note = "No in-text citations found."
+		return view
+	}
+	view.Cited = len(report.Cited)
+	view.Uncited = report.Uncited
+	for _, cite := range report.Unresolved {
+		view.Unresolved = append(view.Unresolved, UnresolvedCitationView{
+			Ref:      cite.Ref,
+			Citation: cite.Text,
+			Style:    string(cite.Style),
+			Count:    cite.Count,
+		})
+	}
+	return view
+}
+
 func stateFromSnapshot(state State, snapshot analysisrunner.Snapshot) State {
 	state.Completed = snapshot.Completed
 	state.Entries = make([]EntryState, len(snapshot.Entries))
 	for i, entry := range snapshot.Entries {
 		view := EntryState{
-			ID:             fmt.Sprintf("%d", entry.ID),
+			ID:             entry.ID,
 			TextStatus:     webStatus(entry.ExtractionStatus),
 			Text:           entry.Text,
 			AnalysisStatus: webStatus(entry.LookupStatus),
@@ -235,13 +404,18 @@ func stateFromSnapshot(state State, snapshot analysisrunner.Snapshot) State {
 			entry.Result.Summary.Error = entry.SummaryError
 		}
 		if entry.Result != nil {
-			if entry.SummaryStatus == analysisrunner.StatusCompleted {
+			// a summary without a comment, as when nothing matched and there
+			// is no LLM summarizer, leaves the state to the lookup sources
+			if entry.SummaryStatus == analysisrunner.StatusCompleted && entry.Summary.Comment != "" {
 				entry.Result.Summary.Status = lookup.SearchStatusDone
 				entry.Result.Summary.Matches = !entry.Summary.Mismatch
 				entry.Result.Summary.Comment = entry.Summary.Comment
 			}
 			view.LookupCards = BuildLookupCards(entry.Result)
 			view.Summary = BuildSummaryView(entry.Result)
+			if entry.SummaryStatus == analysisrunner.StatusCompleted {
+				view.Match = BuildMatchView(entry.Summary.Match)
+			}
 		}
 		state.Entries[i] = view
 	}
@@ -266,20 +440,17 @@ func BuildLookupCards(result *lookup.Result) []LookupCard {
 		return nil
 	}
 
-	cards := make([]LookupCard, 0, 6)
-	appendCard := func(card LookupCard, ok bool) {
-		if ok {
-			cards = append(cards, card)
+	cards := []LookupCard{}
+	for _, status := range result.Statuses() {
+		if status.State == lookup.SourceSkipped {
+			continue
 		}
+		cards = append(cards, LookupCard{
+			Name:   status.Name,
+			Status: string(status.State),
+			Detail: status.Detail,
+		})
 	}
-
-	appendCard(buildDOILookupCard(result), result.DOIOrg.Found || result.DOIOrg.Error != nil || result.DOIOrg.ID != "")
-	appendCard(buildOSTILookupCard(result), result.OSTI.Record != nil || result.OSTI.Error != nil || result.OSTI.ID != "")
-	appendCard(buildArxivLookupCard(result), result.Arxiv.Entry != nil || result.Arxiv.Error != nil || result.Arxiv.ID != "")
-	appendCard(buildElsevierLookupCard(result), result.Elsevier.Result != nil || result.Elsevier.Error != nil || result.Elsevier.Status == lookup.SearchStatusDone)
-	appendCard(buildCrossrefLookupCard(result), result.Crossref.Work != nil || result.Crossref.Error != nil || result.Crossref.Status == lookup.SearchStatusDone || result.Crossref.Comment != "")
-	appendCard(buildOnlineLookupCard(result), result.Online.Metadata != nil || result.Online.Error != nil || result.Online.Status == lookup.SearchStatusDone)
-
 	return cards
 }
 
@@ -287,6 +458,16 @@ func BuildSummaryView(result *lookup.Result) SummaryView {
 	if result == nil {
 		return SummaryView{Status: "unknown"}
 	}
+	if result.Retracted() {
+		return SummaryView{Status: "retracted", Comment: retractionComment(result)}
+	}
+	if result.WrongDOI() {
+		comment := "The DOI exists but points to a different work: " + result.DOIOrg.Work.ToString()
+		if result.Summary.Comment != "" {
+			comment += "\n\n" + result.Summary.Comment
+		}
+		return SummaryView{Status: "wrong-doi", Comment: comment}
+	}
 	if result.Summary.Status == lookup.SearchStatusDone {
 		if result.Summary.Matches {
 			return SummaryView{Status: "ok", Comment: result.Summary.Comment}
@@ -296,15 +477,47 @@ func BuildSummaryView(result *lookup.Result) SummaryView {
 	if result.Summary.Error != nil {
 		return SummaryView{Status: "error", Comment: result.Summary.Error.Error()}
 	}
-	if hasLookupError(result) {
+	if result.HasError() {
 		return SummaryView{Status: "error", Comment: "One or more lookup methods returned an error."}
 	}
-	if hasLookupMatch(result) {
+	if result.HasMatch() {
 		return SummaryView{Status: "unknown"}
 	}
 	return SummaryView{Status: "review", Comment: "No matching metadata found."}
 }
 
+// retractionComment lists the notices for a retracted work, followed by the
+// summarizer's comment, if any.
+func retractionComment(result *lookup.Result) string {
+	lines := []string{"The cited work has been retracted or withdrawn."}
+	for _, notice := range result.Retraction.Notices {
+		lines = append(lines, notice.String())
+	}
+	if result.Summary.Comment != "" {
+		lines = append(lines, "", result.Summary.Comment)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func BuildMatchView(m *match.Result) *MatchView {
+	if m == nil {
+		return nil
+	}
+	view := &MatchView{
+		Source:  m.Source,
+		Score:   m.Score,
+		Verdict: string(m.Verdict),
+	}
+	for _, f := range m.Fields {
+		view.Fields = append(view.Fields, MatchFieldView{
+			Name:    f.Name,
+			Verdict: string(f.Verdict),
+			Detail:  f.Detail,
+		})
+	}
+	return view
+}
+
 func FormatAnalysis(result *lookup.Result) string {
 	if result == nil {
 		return ""
@@ -325,20 +538,10 @@ func FormatAnalysis(result *lookup.Result) string {
 		fmt.Fprintf(&b, "summary error: %v\n", result.Summary.Error)
 	}
 
-	if result.Arxiv.Status == lookup.SearchStatusDone && result.Arxiv.Entry != nil {
-		fmt.Fprintf(&b, "arxiv: %s\n", result.Arxiv.Entry.ToString())
-	}
-	if result.OSTI.Status == lookup.SearchStatusDone && result.OSTI.Record != nil {
-		fmt.Fprintf(&b, "OSTI: %s\n", result.OSTI.Record.ToString())
-	}
-	if result.Crossref.Status == lookup.SearchStatusDone && result.Crossref.Work != nil {
-		fmt.Fprintf(&b, "crossref: %s\n", result.Crossref.Work.ToString())
-	}
-	if result.DOIOrg.Status == lookup.SearchStatusDone && result.DOIOrg.Found {
-		fmt.Fprintf(&b, "doi.org: exists\n")
-	}
-	if result.Online.Status == lookup.SearchStatusDone && result.Online.Metadata != nil {
-		fmt.Fprintf(&b, "URL: %s\n", result.Online.Metadata.ToString())
+	for _, status := range result.Statuses() {
+		if status.Evidence != "" {
+			fmt.Fprintf(&b, "%s: %s\n", status.Name, status.Evidence)
+		}
 	}
 	if b.Len() == 0 {
 		return "No matching metadata found."
@@ -346,96 +549,6 @@ func FormatAnalysis(result *lookup.Result) string {
 	return strings.TrimSpace(b.String())
 }
 
-func buildDOILookupCard(result *lookup.Result) LookupCard {
-	card := LookupCard{Name: "DOI", Status: "not-found", Detail: result.DOIOrg.ID}
-	if result.DOIOrg.Found {
-		card.Status = "found"
-		card.Detail = "exists"
-	} else if result.DOIOrg.Error != nil {
-		card.Status = "error"
-		card.Detail = result.DOIOrg.Error.Error()
-	}
-	return card
-}
-
-func buildOSTILookupCard(result *lookup.Result) LookupCard {
-	card := LookupCard{Name: "OSTI", Status: "not-found", Detail: result.OSTI.ID}
-	if result.OSTI.Record != nil {
-		card.Status = "found"
-		card.Detail = result.OSTI.Record.ToString()
-	} else if result.OSTI.Error != nil {
-		card.Status = "error"
-		card.Detail = result.OSTI.Error.Error()
-	}
-	return card
-}
-
-func buildArxivLookupCard(result *lookup.Result) LookupCard {
-	card := LookupCard{Name: "arXiv", Status: "not-found", Detail: result.Arxiv.ID}
-	if result.Arxiv.Entry != nil {
-		card.Status = "found"
-		card.Detail = result.Arxiv.Entry.ToString()
-	} else if result.Arxiv.Error != nil {
-		card.Status = "error"
-		card.Detail = result.Arxiv.Error.Error()
-	}
-	return card
-}
-
-func buildElsevierLookupCard(result *lookup.Result) LookupCard {
-	card := LookupCard{Name: "Elsevier", Status: "no-match"}
-	if result.Elsevier.Result != nil {
-		card.Status = "matched"
-		card.Detail = result.Elsevier.Result.ToString()
-	} else if result.Elsevier.Error != nil {
-		card.Status = "error"
-		card.Detail = result.Elsevier.Error.Error()
-	}
-	return card
-}
-
-func buildCrossrefLookupCard(result *lookup.Result) LookupCard {
-	card := LookupCard{Name: "Crossref", Status: "no-match", Detail: result.Crossref.Comment}
-	if result.Crossref.Work != nil {
-		card.Status = "matched"
-		card.Detail = result.Crossref.Work.ToString()
-	} else if result.Crossref.Error != nil {
-		card.Status = "error"
-		card.Detail = result.Crossref.Error.Error()
-	}
-	return card
-}
-
-func buildOnlineLookupCard(result *lookup.Result) LookupCard {
-	card := LookupCard{Name: "Online", Status: "not-found"}
-	if result.Online.Metadata != nil {
-		card.Status = "found"
-		card.Detail = result.Online.Metadata.ToString()
-	} else if result.Online.Error != nil {
-		card.Status = "error"
-		card.Detail = result.Online.Error.Error()
-	}
-	return card
-}
-
-func hasLookupError(result *lookup.Result) bool {
-	return result.DOIOrg.Error != nil ||
-		result.OSTI.Error != nil ||
-		result.Arxiv.Error != nil ||
-		result.Elsevier.Error != nil ||
-		result.Crossref.Error != nil ||
-		result.Online.Error != nil
-}
-
-func hasLookupMatch(result *lookup.Result) bool {
-	return result.DOIOrg.Found ||
-		result.OSTI.Record != nil ||
-		result.Arxiv.Entry != nil ||
-		result.Elsevier.Result != nil ||
-		result.Crossref.Work != nil ||
-		result.Online.Metadata != nil
-}
-
 func emit(progress Progress, state State) {
 	if progress != nil {
 		progress(cloneState(state))
@@ -454,6 +567,11 @@ func cloneState(state State) State {
 	copy(entries, state.Entries)
 	for i := range entries {
 		entries[i].LookupCards = append([]LookupCard(nil), entries[i].LookupCards...)
+		if entries[i].Match != nil {
+			m := *entries[i].Match
+			m.Fields = append([]MatchFieldView(nil), m.Fields...)
+			entries[i].Match = &m
+		}
 	}
 	state.Entries = entries
 	return state
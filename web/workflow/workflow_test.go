@@ -3,14 +3,21 @@
 package workflow
 
 import (
+	"context"
 	"errors"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 
+	"github.com/sandialabs/bibcheck/citations"
 	"github.com/sandialabs/bibcheck/config"
 	"github.com/sandialabs/bibcheck/crossref"
+	"github.com/sandialabs/bibcheck/doi"
 	"github.com/sandialabs/bibcheck/elsevier"
 	"github.com/sandialabs/bibcheck/lookup"
+	"github.com/sandialabs/bibcheck/match"
+	"github.com/sandialabs/bibcheck/retraction"
 	"github.com/sandialabs/bibcheck/shirty"
 )
 
@@ -115,6 +122,54 @@ func TestBuildSummaryViewError(t *testing.T) {
 	}
 }
 
+func TestBuildSummaryViewRetractedOverridesOK(t *testing.T) {
+	result := &lookup.Result{}
+	result.Summary.Status = lookup.SearchStatusDone
+	result.Summary.Matches = true
+	result.Summary.Comment = "metadata agrees"
+	result.Retraction.Notices = []retraction.Notice{
+		{Kind: retraction.KindRetraction, DOI: "10.1000/notice", Source: retraction.SourceCrossref},
+	}
+
+	got := BuildSummaryView(result)
+	if got.Status != "retracted" {
+		t.Fatalf("status = %q, want retracted", got.Status)
+	}
+	if !strings.Contains(got.Comment, "doi:10.1000/notice") || !strings.Contains(got.Comment, "metadata agrees") {
+		t.Fatalf("comment = %q", got.Comment)
+	}
+
+	cards := BuildLookupCards(result)
+	if len(cards) != 1 {
+		t.Fatalf("len(cards) = %d, want 1", len(cards))
+	}
+	assertLookupCard(t, cards[0], "Retraction", string(lookup.SourceFlagged), "Retraction")
+}
+
+func TestBuildSummaryViewWrongDOI(t *testing.T) {
+	result := &lookup.Result{}
+	result.Summary.Status = lookup.SearchStatusDone
+	result.Summary.Matches = true
+	result.DOIOrg.ID = "10.1234/example"
+	result.DOIOrg.Found = true
+	result.DOIOrg.Work = &doi.CSL{DOI: "10.1234/example", Publisher: "Elsewhere Press"}
+	result.DOIOrg.WrongWork = true
+
+	got := BuildSummaryView(result)
+	if got.Status != "wrong-doi" {
+		t.Fatalf("status = %q, want wrong-doi", got.Status)
+	}
+	if !strings.Contains(got.Comment, "Elsewhere Press") {
+		t.Fatalf("comment = %q", got.Comment)
+	}
+
+	cards := BuildLookupCards(result)
+	if len(cards) != 1 {
+		t.Fatalf("len(cards) = %d, want 1", len(cards))
+	}
+	assertLookupCard(t, cards[0], "DOI", string(lookup.SourceWrongWork), "different work")
+}
+
 func TestBuildLookupCardsDOIFound(t *testing.T) {
 	result := &lookup.Result{}
 	result.DOIOrg.ID = "10.1234/example"
@@ -194,3 +249,99 @@ func assertLookupCard(t *testing.T, got LookupCard, name, status, detailContains
 		t.Fatalf("detail = %q, want empty", got.Detail)
 	}
 }
+
+func TestBuildMatchView(t *testing.T) {
+	if BuildMatchView(nil) != nil {
+		t.Fatal("expected nil view")
+	}
+	got := BuildMatchView(&match.Result{
+		Source:  "Crossref",
+		Score:   0.9,
+		Verdict: match.VerdictMatch,
+		Fields:  []match.Field{{Name: match.FieldYear, Verdict: match.VerdictPartial, Detail: "cited 2014, found 2015"}},
+	})
+	if got.Source != "Crossref" || got.Verdict != "match" || len(got.Fields) != 1 {
+		t.Fatalf("unexpected view: %+v", got)
+	}
+	if got.Fields[0].Verdict != "partial" || got.Fields[0].Detail != "cited 2014, found 2015" {
+		t.Fatalf("unexpected field: %+v", got.Fields[0])
+	}
+}
+
+func TestNewBibTeXRuntimeWithoutKeys(t *testing.T) {
+	rt := NewBibTeXRuntime(Keys{})
+	if rt.Kind != ProviderNone || rt.Provider != nil || rt.CrossrefClient == nil {
+		t.Fatalf("unexpected runtime: %+v", rt)
+	}
+}
+
+func TestAnalyzeBibTeXRejectsInvalidInput(t *testing.T) {
+	rt := NewBibTeXRuntime(Keys{})
+	for _, data := range []string{
+		"",
+		"@article{key, title = {unbalanced}",
+		"@article{key, title = {First}}\n@misc{key, title = {Second}}",
+	} {
+		state := AnalyzeBibTeX(context.Background(), rt, []byte(data), Options{}, nil)
+		if state.Phase != "Error" || state.Error == "" {
+			t.Fatalf("%q: unexpected state: %+v", data, state)
+		}
+	}
+}
+
+func TestAnalyzeBibTeXRejectsUnknownKey(t *testing.T) {
+	rt := NewBibTeXRuntime(Keys{})
+	data := []byte(`@misc{known, title = {Known}}`)
+	state := AnalyzeBibTeX(context.Background(), rt, data, Options{Entry: "[unknown]"}, nil)
+	if state.Phase != "Error" || !strings.Contains(state.Error, `"unknown"`) {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+}
+
+func TestBuildOrphansView(t *testing.T) {
+	entries := []EntryState{
+		{ID: "1", Text: "D. Knuth. Literate programming. 1984."},
+		{ID: "2", Text: "L. Lamport. LaTeX. 1994."},
+	}
+	got := BuildOrphansView(entries, citations.Find("as shown [1] and [3], again [3]"))
+	if got.Note != "" || got.Cited != 1 || got.Entries != 2 {
+		t.Fatalf("view = %+v", got)
+	}
+	if len(got.Uncited) != 1 || got.Uncited[0] != "2" {
+		t.Fatalf("Uncited = %v", got.Uncited)
+	}
+	want := UnresolvedCitationView{Ref: "3", Citation: "[3]", Style: "numeric", Count: 2}
+	if len(got.Unresolved) != 1 || got.Unresolved[0] != want {
+		t.Fatalf("Unresolved = %+v", got.Unresolved)
+	}
+
+	if got := BuildOrphansView(entries, nil); got.Note == "" {
+		t.Fatalf("expected a note without citations, got %+v", got)
+	}
+}
+
+// emptyTransport answers every request with an empty JSON object, so no
+// source finds a record.
+type emptyTransport struct{}
+
+func (emptyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func TestAnalyzeBibTeXUnmatchedEntryNeedsReview(t *testing.T) {
+	t.Setenv("HTTP_CACHE_ENABLED", "false")
+	rt := NewBibTeXRuntime(Keys{}, WithTransport(emptyTransport{}))
+	data := []byte(`@article{made-up, author = {Nobody, A.}, title = {A Paper That Does Not Exist}, journal = {J. Nothing}, year = {2020}}`)
+	state := AnalyzeBibTeX(context.Background(), rt, data, Options{}, nil)
+	if state.Phase != "Done" || len(state.Entries) != 1 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if summary := state.Entries[0].Summary; summary.Status != "review" {
+		t.Fatalf("summary = %+v, want review", summary)
+	}
+}